@@ -0,0 +1,117 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+// adminUserResponse is a user as seen by administrators
+type adminUserResponse struct {
+	UserResponse
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+}
+
+func newAdminUserResponse(user db.User) adminUserResponse {
+	return adminUserResponse{
+		UserResponse: newUserResponse(user),
+		DisabledAt:   user.DisabledAt,
+	}
+}
+
+func (server *Server) listUsers(ctx *gin.Context) {
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	pageSize, err := server.pageSize(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	users, err := server.store.ListUsers(ctx, db.ListUsersParams{
+		CursorUsername:  cursor.key(),
+		CursorCreatedAt: cursor.createdAt(),
+		Limit:           pageSize + 1,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]adminUserResponse, len(users))
+	for i, user := range users {
+		rsp[i] = newAdminUserResponse(user)
+	}
+	ctx.JSON(http.StatusOK, newListResponse(rsp, pageSize, func(user adminUserResponse) pageCursor {
+		return pageCursor{CreatedAt: user.CreatedAt, Key: user.Username}
+	}))
+}
+
+type userURI struct {
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+func (server *Server) disableUser(ctx *gin.Context) {
+	server.setUserDisabled(ctx, true)
+}
+
+func (server *Server) enableUser(ctx *gin.Context) {
+	server.setUserDisabled(ctx, false)
+}
+
+// setUserDisabled disables or re-enables an account. Disabling rejects all
+// tokens of the user right away, so they cannot log in or renew a session.
+func (server *Server) setUserDisabled(ctx *gin.Context, disabled bool) {
+	var uri userURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if disabled && uri.Username == authPayLoad.Username {
+		err := errors.New("cannot disable your own account")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.SetUserDisabled(ctx, db.SetUserDisabledParams{
+		Username:  uri.Username,
+		Disabled:  disabled,
+		RevokedAt: time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if disabled {
+		server.revocations.revokeUser(user.Username, user.TokensRevokedAt)
+	}
+	ctx.JSON(http.StatusOK, newAdminUserResponse(user))
+}
+
+func (server *Server) getSystemStats(ctx *gin.Context) {
+	stats, err := server.store.GetSystemStats(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,268 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/token"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func TestAdminRoutesRequireAdmin(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Admin",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, authorizationTypeBearer, "admin", util.AdminRole, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "User",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", util.UserRole, time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NoRole",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", "", time.Minute)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "NoAuthorization",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetSystemStats(gomock.Any()).
+				AnyTimes().
+				Return(db.GetSystemStatsRow{Users: 3}, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/admin/stats", nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListUsersAPI(t *testing.T) {
+	n := 3
+	users := make([]db.User, n)
+	for i := range users {
+		users[i], _ = randomUser(t)
+		users[i].CreatedAt = time.Now().Add(time.Duration(i) * time.Second)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListUsers(gomock.Any(), gomock.Eq(db.ListUsersParams{Limit: 3})).
+		Times(1).
+		Return(users, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/admin/users?page_size=2", nil)
+	require.NoError(t, err)
+
+	addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "admin", util.AdminRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NotContains(t, recorder.Body.String(), "hashed_password")
+
+	var rsp listResponse[adminUserResponse]
+	err = json.Unmarshal(recorder.Body.Bytes(), &rsp)
+	require.NoError(t, err)
+	require.Len(t, rsp.Items, 2)
+	require.Equal(t, users[0].Username, rsp.Items[0].Username)
+
+	cursor, err := decodeCursor(rsp.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, users[1].Username, cursor.Key)
+}
+
+func TestDisableUserAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	disabledAt := time.Now()
+	disabled := user
+	disabled.DisabledAt = &disabledAt
+	disabled.TokensRevokedAt = disabledAt
+
+	testCases := []struct {
+		name          string
+		username      string
+		action        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Disable",
+			username: user.Username,
+			action:   "disable",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SetUserDisabledParams) (db.User, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, true, arg.Disabled)
+						require.WithinDuration(t, time.Now(), arg.RevokedAt, time.Second)
+						return disabled, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp adminUserResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.NotNil(t, rsp.DisabledAt)
+			},
+		},
+		{
+			name:     "Enable",
+			username: user.Username,
+			action:   "enable",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SetUserDisabledParams) (db.User, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, false, arg.Disabled)
+						require.WithinDuration(t, time.Now(), arg.RevokedAt, time.Second)
+						return user, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "Self",
+			username: "admin",
+			action:   "disable",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			username: user.Username,
+			action:   "disable",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidUsername",
+			username: "not-valid",
+			action:   "disable",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetUserDisabled(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/admin/users/%s/%s", tc.username, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "admin", util.AdminRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetSystemStatsAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetSystemStats(gomock.Any()).
+		Times(1).
+		Return(db.GetSystemStatsRow{}, sql.ErrConnDone)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/admin/stats", nil)
+	require.NoError(t, err)
+
+	addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "admin", util.AdminRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
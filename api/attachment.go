@@ -0,0 +1,211 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+// attachmentContentTypes lists the receipt formats accepted for upload. The type
+// is sniffed from the content, the type claimed by the client is ignored.
+var attachmentContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+type attachmentURI struct {
+	ExpenseID    int64 `uri:"id" binding:"required,min=1"`
+	AttachmentID int64 `uri:"attachment_id" binding:"required,min=1"`
+}
+
+func (server *Server) uploadAttachment(ctx *gin.Context) {
+	var uri expenseURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// leave room for the multipart framing around the file
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, server.config.MaxAttachmentSize+1<<20)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if fileHeader.Size > server.config.MaxAttachmentSize {
+		err := fmt.Errorf("file is larger than %d bytes", server.config.MaxAttachmentSize)
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+		return
+	}
+	if fileHeader.Size == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("file is empty")))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.ownedExpense(ctx, uri.ID, authPayLoad.Username); !valid {
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	head = head[:n]
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !attachmentContentTypes[contentType] {
+		err := fmt.Errorf("unsupported attachment type %s", contentType)
+		ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(err))
+		return
+	}
+
+	key := fmt.Sprintf("expenses/%d/%s", uri.ID, uuid.NewString())
+	err = server.blobs.Put(ctx, key, io.MultiReader(bytes.NewReader(head), file), contentType)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	attachment, err := server.store.CreateAttachment(ctx, db.CreateAttachmentParams{
+		ExpenseID:   uri.ID,
+		BlobKey:     key,
+		FileName:    filepath.Base(fileHeader.Filename),
+		ContentType: contentType,
+		Size:        fileHeader.Size,
+	})
+	if err != nil {
+		server.deleteBlobs(key)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, attachment)
+}
+
+func (server *Server) listAttachments(ctx *gin.Context) {
+	var uri expenseURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.ownedExpense(ctx, uri.ID, authPayLoad.Username); !valid {
+		return
+	}
+
+	attachments, err := server.store.ListExpenseAttachments(ctx, uri.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, attachments)
+}
+
+// ownedAttachment loads an attachment of an expense the owner can access,
+// writing the error response itself
+func (server *Server) ownedAttachment(ctx *gin.Context, uri attachmentURI, owner string) (db.Attachment, bool) {
+	attachment, err := server.store.GetAttachment(ctx, uri.AttachmentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return attachment, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return attachment, false
+	}
+	if attachment.ExpenseID != uri.ExpenseID {
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("attachment does not belong to the expense")))
+		return attachment, false
+	}
+	if _, valid := server.ownedExpense(ctx, attachment.ExpenseID, owner); !valid {
+		return attachment, false
+	}
+	return attachment, true
+}
+
+func (server *Server) downloadAttachment(ctx *gin.Context) {
+	var uri attachmentURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	attachment, valid := server.ownedAttachment(ctx, uri, authPayLoad.Username)
+	if !valid {
+		return
+	}
+
+	blob, err := server.blobs.Get(ctx, attachment.BlobKey)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer blob.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, blob, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (server *Server) deleteAttachment(ctx *gin.Context) {
+	var uri attachmentURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	attachment, valid := server.ownedAttachment(ctx, uri, authPayLoad.Username)
+	if !valid {
+		return
+	}
+
+	if err := server.store.DeleteAttachment(ctx, attachment.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.deleteBlobs(attachment.BlobKey)
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// deleteBlobs removes blobs whose rows are already gone. Failures are only
+// logged: the request has succeeded and a leftover blob is unreachable.
+func (server *Server) deleteBlobs(keys ...string) {
+	for _, key := range keys {
+		if err := server.blobs.Delete(context.Background(), key); err != nil {
+			log.Printf("cannot delete blob %s: %v", key, err)
+		}
+	}
+}
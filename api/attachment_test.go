@@ -0,0 +1,380 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/storage"
+	"github.com/symyzi/financial-helper/util"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func newAttachmentRequest(t *testing.T, url, filename string, content []byte) *http.Request {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	request, err := http.NewRequest(http.MethodPost, url, body)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func TestUploadAttachmentAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	expense := RandomExpense(wallet.ID, util.RandomInt(1, 1000))
+	png := append(append([]byte{}, pngHeader...), util.RandomString(100)...)
+
+	testCases := []struct {
+		name          string
+		filename      string
+		content       []byte
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, blobs storage.BlobStore)
+	}{
+		{
+			name:     "OK",
+			filename: "receipt.png",
+			content:  png,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					CreateAttachment(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateAttachmentParams) (db.Attachment, error) {
+						require.Equal(t, expense.ID, arg.ExpenseID)
+						require.Equal(t, "receipt.png", arg.FileName)
+						require.Equal(t, "image/png", arg.ContentType)
+						require.Equal(t, int64(len(png)), arg.Size)
+						return db.Attachment{
+							ID:          1,
+							ExpenseID:   arg.ExpenseID,
+							BlobKey:     arg.BlobKey,
+							FileName:    arg.FileName,
+							ContentType: arg.ContentType,
+							Size:        arg.Size,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs storage.BlobStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var attachment db.Attachment
+				err := json.Unmarshal(recorder.Body.Bytes(), &attachment)
+				require.NoError(t, err)
+
+				blob, err := blobs.Get(context.Background(), attachment.BlobKey)
+				require.NoError(t, err)
+				defer blob.Close()
+				data, err := io.ReadAll(blob)
+				require.NoError(t, err)
+				require.Equal(t, png, data)
+			},
+		},
+		{
+			name:     "UnsupportedType",
+			filename: "receipt.png",
+			content:  []byte("#!/bin/sh\necho hello\n"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					CreateAttachment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs storage.BlobStore) {
+				require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			},
+		},
+		{
+			name:     "TooLarge",
+			filename: "receipt.png",
+			content:  append(append([]byte{}, pngHeader...), make([]byte, 1<<20)...),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAttachment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs storage.BlobStore) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			},
+		},
+		{
+			name:     "ForeignExpense",
+			filename: "receipt.png",
+			content:  png,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(RandomWallet("other_user"), nil)
+				store.EXPECT().
+					CreateAttachment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs storage.BlobStore) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			filename: "receipt.png",
+			content:  png,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					CreateAttachment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Attachment{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs storage.BlobStore) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/wallets/%d/expenses/%d/attachments", wallet.ID, expense.ID)
+			request := newAttachmentRequest(t, url, tc.filename, tc.content)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder, server.blobs)
+		})
+	}
+}
+
+func TestDownloadAttachmentAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	expense := RandomExpense(wallet.ID, util.RandomInt(1, 1000))
+	content := "%PDF-1.4 receipt"
+	attachment := db.Attachment{
+		ID:          util.RandomInt(1, 1000),
+		ExpenseID:   expense.ID,
+		BlobKey:     fmt.Sprintf("expenses/%d/receipt", expense.ID),
+		FileName:    "receipt.pdf",
+		ContentType: "application/pdf",
+		Size:        int64(len(content)),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAttachment(gomock.Any(), gomock.Eq(attachment.ID)).
+		Times(1).
+		Return(attachment, nil)
+	store.EXPECT().
+		GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+		Times(1).
+		Return(expense, nil)
+	store.EXPECT().
+		GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+		Times(1).
+		Return(wallet, nil)
+
+	server := newTestServer(t, store)
+	err := server.blobs.Put(context.Background(), attachment.BlobKey, strings.NewReader(content), attachment.ContentType)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/wallets/%d/expenses/%d/attachments/%d", wallet.ID, expense.ID, attachment.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename=receipt.pdf`, recorder.Header().Get("Content-Disposition"))
+	require.Equal(t, content, recorder.Body.String())
+}
+
+func TestDeleteAttachmentAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	expense := RandomExpense(wallet.ID, util.RandomInt(1, 1000))
+	attachment := db.Attachment{
+		ID:        util.RandomInt(1, 1000),
+		ExpenseID: expense.ID,
+		BlobKey:   fmt.Sprintf("expenses/%d/receipt", expense.ID),
+	}
+
+	testCases := []struct {
+		name          string
+		expenseID     int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, blobs storage.BlobStore)
+	}{
+		{
+			name:      "OK",
+			expenseID: expense.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAttachment(gomock.Any(), gomock.Eq(attachment.ID)).
+					Times(1).
+					Return(attachment, nil)
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					DeleteAttachment(gomock.Any(), gomock.Eq(attachment.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs storage.BlobStore) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				_, err := blobs.Get(context.Background(), attachment.BlobKey)
+				require.ErrorIs(t, err, storage.ErrNotFound)
+			},
+		},
+		{
+			name:      "OtherExpense",
+			expenseID: expense.ID + 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAttachment(gomock.Any(), gomock.Eq(attachment.ID)).
+					Times(1).
+					Return(attachment, nil)
+				store.EXPECT().
+					DeleteAttachment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs storage.BlobStore) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				_, err := blobs.Get(context.Background(), attachment.BlobKey)
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			err := server.blobs.Put(context.Background(), attachment.BlobKey, strings.NewReader("data"), "image/png")
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/wallets/%d/expenses/%d/attachments/%d", wallet.ID, tc.expenseID, attachment.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder, server.blobs)
+		})
+	}
+}
+
+func TestDeleteExpenseRemovesAttachments(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	expense := RandomExpense(wallet.ID, util.RandomInt(1, 1000))
+	attachment := db.Attachment{
+		ID:        util.RandomInt(1, 1000),
+		ExpenseID: expense.ID,
+		BlobKey:   fmt.Sprintf("expenses/%d/receipt", expense.ID),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+		Times(1).
+		Return(expense, nil)
+	store.EXPECT().
+		GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+		Times(1).
+		Return(wallet, nil)
+	store.EXPECT().
+		DeleteExpenseTx(gomock.Any(), gomock.Eq(expense.ID)).
+		Times(1).
+		Return(db.DeleteExpenseTxResult{Expense: expense, Wallet: wallet, BlobKeys: []string{attachment.BlobKey}}, nil)
+
+	server := newTestServer(t, store)
+	err := server.blobs.Put(context.Background(), attachment.BlobKey, strings.NewReader("data"), "image/png")
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/wallets/%d/expenses/%d", wallet.ID, expense.ID)
+	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	_, err = server.blobs.Get(context.Background(), attachment.BlobKey)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/symyzi/financial-helper/backup"
+	"github.com/symyzi/financial-helper/token"
+)
+
+// maxBackupSize bounds the size of an uploaded backup archive
+const maxBackupSize = 100 << 20
+
+// createBackup downloads an archive of everything the user owns
+func (server *Server) createBackup(ctx *gin.Context) {
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	archive, err := backup.Create(ctx, server.store, authPayLoad.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	filename := fmt.Sprintf("backup-%s-%s.json", archive.Profile.Username, archive.CreatedAt.Format(dateLayout))
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	ctx.JSON(http.StatusOK, archive)
+}
+
+// restoreBackup recreates the archive in the request body under the user
+func (server *Server) restoreBackup(ctx *gin.Context) {
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBackupSize)
+	archive, err := backup.Read(body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := backup.Restore(ctx, server.store, archive, authPayLoad.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
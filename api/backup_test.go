@@ -0,0 +1,245 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/backup"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/token"
+)
+
+func randomOwnerData(owner string) db.OwnerData {
+	wallet := RandomWallet(owner)
+	category := RandomCategory(owner)
+	return db.OwnerData{
+		Wallets:    []db.Wallet{wallet},
+		Categories: []db.Category{category},
+		Expenses:   []db.Expense{RandomExpense(wallet.ID, category.ID)},
+		Incomes:    []db.Income{},
+		Budgets:    []db.Budget{},
+		Transfers:  []db.Transfer{},
+	}
+}
+
+func TestCreateBackupAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	data := randomOwnerData(user.Username)
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					BackupTx(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(data, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "backup-"+user.Username)
+				require.NotContains(t, recorder.Body.String(), user.HashedPassword)
+
+				archive, err := backup.Read(recorder.Body)
+				require.NoError(t, err)
+				require.Equal(t, user.Username, archive.Profile.Username)
+				require.Len(t, archive.Expenses, 1)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					BackupTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					BackupTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OwnerData{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/backup", nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRestoreBackupAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	data := randomOwnerData("someone_else")
+	archive := backup.Archive{Version: backup.Version, OwnerData: data}
+	wallet := data.Wallets[0]
+
+	result := db.RestoreTxResult{
+		WalletIDs:   map[int64]int64{wallet.ID: wallet.ID + 1},
+		CategoryIDs: map[int64]int64{data.Categories[0].ID: data.Categories[0].ID + 1},
+		Expenses:    1,
+	}
+
+	testCases := []struct {
+		name          string
+		body          func(t *testing.T) []byte
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: func(t *testing.T) []byte {
+				data, err := json.Marshal(archive)
+				require.NoError(t, err)
+				return data
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RestoreTx(gomock.Any(), gomock.Eq(db.RestoreTxParams{Owner: user.Username, Data: data})).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp db.RestoreTxResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, result, rsp)
+			},
+		},
+		{
+			name: "UnsupportedVersion",
+			body: func(t *testing.T) []byte {
+				return []byte(`{"version": 99}`)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "unsupported backup version")
+			},
+		},
+		{
+			name: "BrokenReference",
+			body: func(t *testing.T) []byte {
+				broken := archive
+				broken.Expenses = []db.Expense{RandomExpense(wallet.ID+100, data.Categories[0].ID)}
+				data, err := json.Marshal(broken)
+				require.NoError(t, err)
+				return data
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: func(t *testing.T) []byte {
+				data, err := json.Marshal(archive)
+				require.NoError(t, err)
+				return data
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					RestoreTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RestoreTxResult{}, sql.ErrTxDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/backup/restore", bytes.NewReader(tc.body(t)))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -4,16 +4,24 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/symyzi/financial-helper/db/gen"
 	"github.com/symyzi/financial-helper/token"
+	"github.com/symyzi/financial-helper/util"
 )
 
+const dateLayout = "2006-01-02"
+
 type budgetCreateRequest struct {
-	WalletID   int64 `json:"wallet_id"`
-	Amount     int64 `json:"amount"`
-	CategoryID int64 `json:"category_id"`
+	WalletID   int64  `json:"wallet_id"`
+	Amount     int64  `json:"amount"`
+	CategoryID int64  `json:"category_id"`
+	Period     string `json:"period" binding:"omitempty,oneof=weekly monthly yearly custom"`
+	StartDate  string `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate    string `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	Recurring  *bool  `json:"recurring"`
 }
 
 func (server *Server) createBudget(ctx *gin.Context) {
@@ -23,6 +31,12 @@ func (server *Server) createBudget(ctx *gin.Context) {
 		return
 	}
 
+	arg, err := req.params()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	wallet, err := server.store.GetWallet(ctx, req.WalletID)
 	if err != nil {
@@ -34,18 +48,53 @@ func (server *Server) createBudget(ctx *gin.Context) {
 		return
 	}
 
+	budget, err := server.store.CreateBudget(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, budget)
+}
+
+// params fills in the period defaults: a recurring monthly budget starting today
+func (req budgetCreateRequest) params() (db.CreateBudgetParams, error) {
 	arg := db.CreateBudgetParams{
 		WalletID:   req.WalletID,
 		Amount:     req.Amount,
 		CategoryID: req.CategoryID,
+		Period:     req.Period,
+		StartDate:  util.Day(time.Now()),
+		Recurring:  true,
+	}
+	if arg.Period == "" {
+		arg.Period = util.PeriodMonthly
+	}
+	if req.Recurring != nil {
+		arg.Recurring = *req.Recurring
 	}
 
-	budget, err := server.store.CreateBudget(ctx, arg)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	if req.StartDate != "" {
+		startDate, err := time.Parse(dateLayout, req.StartDate)
+		if err != nil {
+			return arg, err
+		}
+		arg.StartDate = startDate
 	}
-	ctx.JSON(http.StatusOK, budget)
+	if req.EndDate != "" {
+		endDate, err := time.Parse(dateLayout, req.EndDate)
+		if err != nil {
+			return arg, err
+		}
+		if endDate.Before(arg.StartDate) {
+			return arg, errors.New("end_date must not be before start_date")
+		}
+		arg.EndDate = &endDate
+	}
+
+	if arg.Period == util.PeriodCustom && arg.EndDate == nil {
+		return arg, errors.New("end_date is required for a custom period")
+	}
+	return arg, nil
 }
 
 type budgetDeleteRequest struct {
@@ -130,41 +179,296 @@ func (server *Server) getBudget(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, budget)
 }
 
-type budgetListRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+type budgetUpdateURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// budgetUpdateRequest changes the fields that are set. A null end_date keeps the
+// end date, clear_end_date removes it.
+type budgetUpdateRequest struct {
+	Amount       *int64  `json:"amount" binding:"omitempty,gt=0"`
+	CategoryID   *int64  `json:"category_id" binding:"omitempty,min=1"`
+	Period       *string `json:"period" binding:"omitempty,oneof=weekly monthly yearly custom"`
+	StartDate    *string `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate      *string `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	Recurring    *bool   `json:"recurring"`
+	ClearEndDate bool    `json:"clear_end_date"`
+}
+
+// params validates the changed fields together with the ones the budget keeps
+func (req budgetUpdateRequest) params(budget db.Budget) (db.UpdateBudgetParams, error) {
+	arg := db.UpdateBudgetParams{
+		ID: budget.ID,
+	}
+	if req.Amount != nil {
+		arg.Amount = sql.NullInt64{Int64: *req.Amount, Valid: true}
+	}
+	if req.Recurring != nil {
+		arg.Recurring = sql.NullBool{Bool: *req.Recurring, Valid: true}
+	}
+
+	period := budget.Period
+	if req.Period != nil {
+		period = *req.Period
+		arg.Period = sql.NullString{String: period, Valid: true}
+	}
+	startDate := budget.StartDate
+	if req.StartDate != nil {
+		date, err := time.Parse(dateLayout, *req.StartDate)
+		if err != nil {
+			return arg, err
+		}
+		startDate = date
+		arg.StartDate = &date
+	}
+	endDate := budget.EndDate
+	if req.EndDate != nil {
+		date, err := time.Parse(dateLayout, *req.EndDate)
+		if err != nil {
+			return arg, err
+		}
+		endDate = &date
+		arg.EndDate = &date
+	}
+	if req.ClearEndDate {
+		if req.EndDate != nil {
+			return arg, errors.New("end_date cannot be set and cleared at once")
+		}
+		endDate = nil
+		arg.ClearEndDate = true
+	}
+
+	if endDate != nil && endDate.Before(startDate) {
+		return arg, errors.New("end_date must not be before start_date")
+	}
+	if period == util.PeriodCustom && endDate == nil {
+		return arg, errors.New("end_date is required for a custom period")
+	}
+	return arg, nil
+}
+
+func (server *Server) updateBudget(ctx *gin.Context) {
+	var uri budgetUpdateURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req budgetUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	budget, err := server.store.GetBudgetByID(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validWallet(ctx, budget.WalletID, authPayLoad.Username); !valid {
+		return
+	}
+
+	arg, err := req.params(budget)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.CategoryID != nil {
+		category, err := server.store.GetCategoryByID(ctx, *req.CategoryID)
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if category.Owner != authPayLoad.Username {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+			return
+		}
+		arg.CategoryID = sql.NullInt64{Int64: category.ID, Valid: true}
+	}
+
+	budget, err = server.store.UpdateBudget(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, budget)
+}
+
+type budgetListURI struct {
+	WalletID int64 `uri:"id" binding:"required,min=1"`
 }
 
 func (server *Server) listBudgets(ctx *gin.Context) {
-	var req budgetListRequest
+	var uri budgetListURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req pageRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	pageSize, err := server.pageSize(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
 	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validWallet(ctx, uri.WalletID, authPayLoad.Username); !valid {
+		return
+	}
 
 	arg := db.ListBudgetsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		WalletID:        uri.WalletID,
+		CursorID:        cursor.id(),
+		CursorCreatedAt: cursor.createdAt(),
+		Limit:           pageSize + 1,
 	}
-
 	budgets, err := server.store.ListBudgets(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	ctx.JSON(http.StatusOK, newListResponse(budgets, pageSize, func(budget db.Budget) pageCursor {
+		return pageCursor{CreatedAt: budget.CreatedAt, ID: budget.ID}
+	}))
+}
 
-	for _, budget := range budgets {
-		wallet, err := server.store.GetWallet(ctx, budget.WalletID)
-		if err != nil {
+type budgetProgressResponse struct {
+	BudgetID    int64     `json:"budget_id"`
+	CategoryID  int64     `json:"category_id"`
+	Period      string    `json:"period"`
+	Active      bool      `json:"active"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Planned     int64     `json:"planned"`
+	Spent       int64     `json:"spent"`
+	Remaining   int64     `json:"remaining"`
+	PercentUsed float64   `json:"percent_used"`
+	Projected   int64     `json:"projected"`
+}
+
+// budgetProgress sums the budget's expenses for the period containing at.
+// A budget with no period at that time is reported as inactive.
+func (server *Server) budgetProgress(ctx *gin.Context, budget db.Budget, at time.Time) (budgetProgressResponse, error) {
+	rsp := budgetProgressResponse{
+		BudgetID:   budget.ID,
+		CategoryID: budget.CategoryID,
+		Period:     budget.Period,
+		Planned:    budget.Amount,
+		Remaining:  budget.Amount,
+	}
+
+	var endDate time.Time
+	if budget.EndDate != nil {
+		endDate = *budget.EndDate
+	}
+	from, to, ok := util.PeriodWindow(budget.Period, budget.StartDate, endDate, budget.Recurring, at)
+	if !ok {
+		return rsp, nil
+	}
+
+	progress, err := server.store.GetBudgetProgress(ctx, db.GetBudgetProgressParams{
+		ID:          budget.ID,
+		PeriodStart: from,
+		PeriodEnd:   to,
+		At:          at,
+	})
+	if err != nil {
+		return rsp, err
+	}
+
+	rsp.Active = true
+	rsp.PeriodStart = from
+	rsp.PeriodEnd = to
+	rsp.Planned = progress.Planned
+	rsp.Spent = progress.Spent
+	rsp.Remaining = progress.Remaining
+	rsp.PercentUsed = progress.PercentUsed
+	rsp.Projected = progress.Projected
+	return rsp, nil
+}
+
+type budgetProgressRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getBudgetProgress(ctx *gin.Context) {
+	var req budgetProgressRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	budget, err := server.store.GetBudgetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
-		if wallet.Owner != authPayLoad.Username {
-			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if _, valid := server.validWallet(ctx, budget.WalletID, authPayLoad.Username); !valid {
+		return
+	}
+
+	rsp, err := server.budgetProgress(ctx, budget, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type listBudgetProgressRequest struct {
+	WalletID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listBudgetProgress(ctx *gin.Context) {
+	var req listBudgetProgressRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	wallet, valid := server.validWallet(ctx, req.WalletID, authPayLoad.Username)
+	if !valid {
+		return
+	}
+
+	budgets, err := server.store.ListBudgetsByWallet(ctx, wallet.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	rsp := make([]budgetProgressResponse, 0, len(budgets))
+	for _, budget := range budgets {
+		progress, err := server.budgetProgress(ctx, budget, now)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 			return
 		}
+		rsp = append(rsp, progress)
 	}
-	ctx.JSON(http.StatusOK, budgets)
+	ctx.JSON(http.StatusOK, rsp)
 }
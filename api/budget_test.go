@@ -54,6 +54,9 @@ func TestCreateBudgetAPI(t *testing.T) {
 					WalletID:   budget.WalletID,
 					Amount:     budget.Amount,
 					CategoryID: budget.CategoryID,
+					Period:     util.PeriodMonthly,
+					StartDate:  util.Day(time.Now()),
+					Recurring:  true,
 				}
 				store.EXPECT().
 					CreateBudget(gomock.Any(), gomock.Eq(arg)).
@@ -64,6 +67,117 @@ func TestCreateBudgetAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recoder.Code)
 			},
 		},
+		{
+			name: "CustomPeriod",
+			body: gin.H{
+				"wallet_id":   budget.WalletID,
+				"amount":      budget.Amount,
+				"category_id": budget.CategoryID,
+				"period":      util.PeriodCustom,
+				"start_date":  "2024-03-10",
+				"end_date":    "2024-03-24",
+				"recurring":   false,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(budget.WalletID)).
+					Times(1).
+					Return(wallet, nil)
+
+				endDate := time.Date(2024, time.March, 24, 0, 0, 0, 0, time.UTC)
+				arg := db.CreateBudgetParams{
+					WalletID:   budget.WalletID,
+					Amount:     budget.Amount,
+					CategoryID: budget.CategoryID,
+					Period:     util.PeriodCustom,
+					StartDate:  time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+					EndDate:    &endDate,
+					Recurring:  false,
+				}
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(budget, nil)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "CustomPeriodWithoutEndDate",
+			body: gin.H{
+				"wallet_id":   budget.WalletID,
+				"amount":      budget.Amount,
+				"category_id": budget.CategoryID,
+				"period":      util.PeriodCustom,
+				"start_date":  "2024-03-10",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+		{
+			name: "EndDateBeforeStartDate",
+			body: gin.H{
+				"wallet_id":   budget.WalletID,
+				"amount":      budget.Amount,
+				"category_id": budget.CategoryID,
+				"period":      util.PeriodMonthly,
+				"start_date":  "2024-03-10",
+				"end_date":    "2024-03-01",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+		{
+			name: "InvalidPeriod",
+			body: gin.H{
+				"wallet_id":   budget.WalletID,
+				"amount":      budget.Amount,
+				"category_id": budget.CategoryID,
+				"period":      "daily",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					CreateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
 		{
 			name: "InternalError",
 			body: gin.H{
@@ -345,14 +459,16 @@ func TestListBudgetsAPI(t *testing.T) {
 	category := RandomCategory(user.Username)
 	n := 5
 
-	budgets := make([]db.Budget, n)
+	budgets := make([]db.Budget, n+1)
 
-	for i := 0; i < n; i++ {
+	for i := range budgets {
 		budgets[i] = RandomBudget(wallet.ID, category.ID)
+		budgets[i].CreatedAt = time.Now().Add(time.Duration(i) * time.Second).UTC()
 	}
+	cursor := pageCursor{CreatedAt: budgets[n-1].CreatedAt, ID: budgets[n-1].ID}
 
 	type Query struct {
-		pageID   int
+		cursor   string
 		pageSize int
 	}
 
@@ -366,7 +482,6 @@ func TestListBudgetsAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -376,12 +491,44 @@ func TestListBudgetsAPI(t *testing.T) {
 
 				store.EXPECT().
 					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
-					AnyTimes().
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.ListBudgetsParams{
+					WalletID: wallet.ID,
+					Limit:    int32(n + 1),
+				}
+
+				store.EXPECT().
+					ListBudgets(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(budgets[:n], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchBudgets(t, recorder.Body, budgets[:n], "")
+			},
+		},
+		{
+			name: "NextPage",
+			query: Query{
+				cursor:   encodeCursor(cursor),
+				pageSize: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
 					Return(wallet, nil)
 
 				arg := db.ListBudgetsParams{
-					Limit:  int32(n),
-					Offset: 0,
+					WalletID:        wallet.ID,
+					CursorID:        sql.NullInt64{Int64: cursor.ID, Valid: true},
+					CursorCreatedAt: cursor.CreatedAt,
+					Limit:           int32(n + 1),
 				}
 
 				store.EXPECT().
@@ -391,13 +538,38 @@ func TestListBudgetsAPI(t *testing.T) {
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchBudgets(t, recorder.Body, budgets)
+				requireBodyMatchBudgets(t, recorder.Body, budgets[:n], encodeCursor(cursor))
+			},
+		},
+		{
+			name:  "DefaultPageSize",
+			query: Query{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.ListBudgetsParams{
+					WalletID: wallet.ID,
+					Limit:    6,
+				}
+
+				store.EXPECT().
+					ListBudgets(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(budgets[:n], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
 			name: "NoAuthorization",
 			query: Query{
-				pageID:   1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -411,10 +583,31 @@ func TestListBudgetsAPI(t *testing.T) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name: "UnauthorizedUser",
+			query: Query{
+				pageSize: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					ListBudgets(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -436,9 +629,9 @@ func TestListBudgetsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
+				cursor:   "not-a-cursor",
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -462,7 +655,6 @@ func TestListBudgetsAPI(t *testing.T) {
 		{
 			name: "InvalidPageSize",
 			query: Query{
-				pageID:   1,
 				pageSize: 100000,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -502,8 +694,12 @@ func TestListBudgetsAPI(t *testing.T) {
 			require.NoError(t, err)
 
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
-			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
+			if tc.query.pageSize != 0 {
+				q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			}
 			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -659,14 +855,601 @@ func TestDeleteBudgetAPI(t *testing.T) {
 	}
 }
 
-func requireBodyMatchBudgets(t *testing.T, body *bytes.Buffer, budgets []db.Budget) {
+func TestGetBudgetProgressAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	budget := RandomBudget(wallet.ID, category.ID)
+
+	progress := db.GetBudgetProgressRow{
+		Planned:     budget.Amount,
+		Spent:       budget.Amount / 2,
+		Remaining:   budget.Amount - budget.Amount/2,
+		PercentUsed: 50,
+		Projected:   budget.Amount,
+	}
+
+	expired := budget
+	expired.Recurring = false
+	expired.StartDate = time.Now().AddDate(-1, 0, 0)
+
+	testCases := []struct {
+		name          string
+		budgetID      int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			budgetID: budget.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetBudgetProgress(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.GetBudgetProgressParams) (db.GetBudgetProgressRow, error) {
+						require.Equal(t, budget.ID, arg.ID)
+						require.Equal(t, 1, arg.PeriodStart.Day())
+						require.Equal(t, arg.PeriodStart.AddDate(0, 1, 0), arg.PeriodEnd)
+						require.False(t, arg.At.Before(arg.PeriodStart))
+						return progress, nil
+					})
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var rsp budgetProgressResponse
+				err := json.Unmarshal(recoder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.True(t, rsp.Active)
+				require.Equal(t, budget.ID, rsp.BudgetID)
+				require.Equal(t, progress.Spent, rsp.Spent)
+				require.Equal(t, progress.Remaining, rsp.Remaining)
+				require.Equal(t, progress.PercentUsed, rsp.PercentUsed)
+				require.Equal(t, progress.Projected, rsp.Projected)
+			},
+		},
+		{
+			name:     "Inactive",
+			budgetID: expired.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(expired.ID)).
+					Times(1).
+					Return(expired, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetBudgetProgress(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var rsp budgetProgressResponse
+				err := json.Unmarshal(recoder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.False(t, rsp.Active)
+				require.Equal(t, expired.Amount, rsp.Planned)
+				require.Equal(t, expired.Amount, rsp.Remaining)
+				require.Zero(t, rsp.Spent)
+			},
+		},
+		{
+			name:     "Unauthorized",
+			budgetID: budget.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetBudgetProgress(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			budgetID: budget.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(db.Budget{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetBudgetProgress(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			budgetID: budget.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetBudgetProgress(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetBudgetProgressRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidID",
+			budgetID: 0,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/wallets/%d/budgets/%d/progress", wallet.ID, tc.budgetID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListBudgetProgressAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+
+	n := 3
+	budgets := make([]db.Budget, n)
+	for i := range budgets {
+		budgets[i] = RandomBudget(wallet.ID, category.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					ListBudgetsByWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(budgets, nil)
+				store.EXPECT().
+					GetBudgetProgress(gomock.Any(), gomock.Any()).
+					Times(n).
+					Return(db.GetBudgetProgressRow{}, nil)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var rsp []budgetProgressResponse
+				err := json.Unmarshal(recoder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Len(t, rsp, n)
+				for i, progress := range rsp {
+					require.Equal(t, budgets[i].ID, progress.BudgetID)
+					require.True(t, progress.Active)
+				}
+			},
+		},
+		{
+			name: "Unauthorized",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					ListBudgetsByWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					ListBudgetsByWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(budgets, nil)
+				store.EXPECT().
+					GetBudgetProgress(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetBudgetProgressRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/wallets/%d/budgets/progress", wallet.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateBudgetAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	budget := RandomBudget(wallet.ID, category.ID)
+
+	updated := budget
+	updated.Amount = budget.Amount + 1
+	updated.Period = util.PeriodWeekly
+
+	endDate := budget.StartDate.AddDate(0, 1, 0)
+	withEndDate := budget
+	withEndDate.EndDate = &endDate
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"amount": updated.Amount,
+				"period": updated.Period,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.UpdateBudgetParams{
+					ID:     budget.ID,
+					Amount: sql.NullInt64{Int64: updated.Amount, Valid: true},
+					Period: sql.NullString{String: updated.Period, Valid: true},
+				}
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchBudget(t, recorder.Body, updated)
+			},
+		},
+		{
+			name: "ClearEndDate",
+			body: gin.H{
+				"clear_end_date": true,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(withEndDate, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.UpdateBudgetParams{
+					ID:           budget.ID,
+					ClearEndDate: true,
+				}
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(budget, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchBudget(t, recorder.Body, budget)
+			},
+		},
+		{
+			name: "SetAndClearEndDate",
+			body: gin.H{
+				"end_date":       endDate.Format(dateLayout),
+				"clear_end_date": true,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(withEndDate, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "CustomPeriodWithoutEndDate",
+			body: gin.H{
+				"period": util.PeriodCustom,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "EndDateBeforeStartDate",
+			body: gin.H{
+				"end_date": budget.StartDate.AddDate(0, 0, -1).Format(dateLayout),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ForeignCategory",
+			body: gin.H{
+				"category_id": category.ID + 1,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID+1)).
+					Times(1).
+					Return(RandomCategory("other_user"), nil)
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			body: gin.H{
+				"amount": updated.Amount,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"amount": updated.Amount,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBudgetByID(gomock.Any(), gomock.Eq(budget.ID)).
+					Times(1).
+					Return(budget, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					UpdateBudget(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Budget{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/wallets/%d/budgets/%d", wallet.ID, budget.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func requireBodyMatchBudgets(t *testing.T, body *bytes.Buffer, budgets []db.Budget, nextCursor string) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotBudgets []db.Budget
+	var gotBudgets listResponse[db.Budget]
 	err = json.Unmarshal(data, &gotBudgets)
 	require.NoError(t, err)
-	require.Equal(t, budgets, gotBudgets)
+	require.Equal(t, budgets, gotBudgets.Items)
+	require.Equal(t, nextCursor, gotBudgets.NextCursor)
 }
 
 func requireBodyMatchBudget(t *testing.T, body *bytes.Buffer, budget db.Budget) {
@@ -685,5 +1468,8 @@ func RandomBudget(walletID int64, CategoryID int64) db.Budget {
 		WalletID:   walletID,
 		Amount:     util.RandomInt(1, 1000),
 		CategoryID: CategoryID,
+		Period:     util.PeriodMonthly,
+		StartDate:  util.Day(time.Now()),
+		Recurring:  true,
 	}
 }
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/symyzi/financial-helper/categorizer"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+type createCategorizationRuleRequest struct {
+	CategoryID int64   `json:"category_id" binding:"required,min=1"`
+	Priority   int32   `json:"priority"`
+	MatchType  string  `json:"match_type" binding:"omitempty,oneof=substring regex"`
+	Pattern    *string `json:"pattern" binding:"omitempty,min=1"`
+	MinAmount  *int64  `json:"min_amount" binding:"omitempty,min=0"`
+	MaxAmount  *int64  `json:"max_amount" binding:"omitempty,min=0"`
+	WalletID   *int64  `json:"wallet_id" binding:"omitempty,min=1"`
+}
+
+// params checks that the rule has a condition and a valid pattern
+func (req createCategorizationRuleRequest) params(owner string) (db.CreateCategorizationRuleParams, error) {
+	arg := db.CreateCategorizationRuleParams{
+		Owner:      owner,
+		CategoryID: req.CategoryID,
+		Priority:   req.Priority,
+		MatchType:  req.MatchType,
+		Pattern:    req.Pattern,
+		MinAmount:  req.MinAmount,
+		MaxAmount:  req.MaxAmount,
+		WalletID:   req.WalletID,
+	}
+	if arg.MatchType == "" {
+		arg.MatchType = categorizer.MatchSubstring
+	}
+
+	if arg.Pattern == nil && arg.MinAmount == nil && arg.MaxAmount == nil && arg.WalletID == nil {
+		return arg, errors.New("a rule needs a pattern, an amount range or a wallet")
+	}
+	if arg.MinAmount != nil && arg.MaxAmount != nil && *arg.MinAmount > *arg.MaxAmount {
+		return arg, errors.New("min_amount must not be greater than max_amount")
+	}
+	if arg.Pattern != nil && arg.MatchType == categorizer.MatchRegex {
+		if _, err := categorizer.CompilePattern(*arg.Pattern); err != nil {
+			return arg, err
+		}
+	}
+	return arg, nil
+}
+
+func (server *Server) createCategorizationRule(ctx *gin.Context) {
+	var req createCategorizationRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg, err := req.params(authPayLoad.Username)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, err := server.store.GetCategoryByID(ctx, arg.CategoryID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if category.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+	if arg.WalletID != nil {
+		if _, valid := server.validWallet(ctx, *arg.WalletID, authPayLoad.Username); !valid {
+			return
+		}
+	}
+
+	rule, err := server.store.CreateCategorizationRule(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rule)
+}
+
+// listCategorizationRules returns all rules of the user in the order they are tried
+func (server *Server) listCategorizationRules(ctx *gin.Context) {
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	rules, err := server.store.ListCategorizationRules(ctx, authPayLoad.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rules)
+}
+
+type deleteCategorizationRuleRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteCategorizationRule(ctx *gin.Context) {
+	var req deleteCategorizationRuleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rule, err := server.store.GetCategorizationRule(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if rule.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	if err := server.store.DeleteCategorizationRule(ctx, req.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, rule)
+}
+
+type applyCategorizationRulesRequest struct {
+	WalletID int64 `json:"wallet_id" binding:"omitempty,min=1"`
+	DryRun   bool  `json:"dry_run"`
+}
+
+// applyCategorizationRules re-runs the rules over the user's uncategorized expenses
+func (server *Server) applyCategorizationRules(ctx *gin.Context) {
+	var req applyCategorizationRulesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if req.WalletID != 0 {
+		if _, valid := server.validWallet(ctx, req.WalletID, authPayLoad.Username); !valid {
+			return
+		}
+	}
+
+	result, err := categorizer.Reapply(ctx, server.store, authPayLoad.Username, req.WalletID, req.DryRun)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,445 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/categorizer"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func RandomCategorizationRule(owner string, categoryID int64) db.CategorizationRule {
+	pattern := util.RandomString(6)
+	return db.CategorizationRule{
+		ID:         util.RandomInt(1, 1000),
+		Owner:      owner,
+		CategoryID: categoryID,
+		Priority:   int32(util.RandomInt(0, 10)),
+		MatchType:  categorizer.MatchSubstring,
+		Pattern:    &pattern,
+	}
+}
+
+func TestCreateCategorizationRuleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	category := RandomCategory(user.Username)
+	wallet := RandomWallet(user.Username)
+	rule := RandomCategorizationRule(user.Username, category.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"category_id": category.ID,
+				"priority":    rule.Priority,
+				"pattern":     *rule.Pattern,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				arg := db.CreateCategorizationRuleParams{
+					Owner:      user.Username,
+					CategoryID: category.ID,
+					Priority:   rule.Priority,
+					MatchType:  categorizer.MatchSubstring,
+					Pattern:    rule.Pattern,
+				}
+				store.EXPECT().
+					CreateCategorizationRule(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rule, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.CategorizationRule
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, rule, got)
+			},
+		},
+		{
+			name: "WalletAndAmountRange",
+			body: gin.H{
+				"category_id": category.ID,
+				"match_type":  "regex",
+				"pattern":     `^uber\b`,
+				"min_amount":  100,
+				"max_amount":  5000,
+				"wallet_id":   wallet.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					CreateCategorizationRule(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(rule, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NoCondition",
+			body: gin.H{
+				"category_id": category.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCategorizationRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRegex",
+			body: gin.H{
+				"category_id": category.ID,
+				"match_type":  "regex",
+				"pattern":     "(",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCategorizationRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAmountRange",
+			body: gin.H{
+				"category_id": category.ID,
+				"min_amount":  500,
+				"max_amount":  100,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCategorizationRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedCategory",
+			body: gin.H{
+				"category_id": category.ID,
+				"pattern":     "coffee",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(RandomCategory("other_user"), nil)
+				store.EXPECT().
+					CreateCategorizationRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"category_id": category.ID,
+				"pattern":     "coffee",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					CreateCategorizationRule(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CategorizationRule{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/categorization-rules", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteCategorizationRuleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	rule := RandomCategorizationRule(user.Username, util.RandomInt(1, 1000))
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategorizationRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(rule, nil)
+				store.EXPECT().
+					DeleteCategorizationRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategorizationRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(db.CategorizationRule{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteCategorizationRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: "unauthorized_user",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategorizationRule(gomock.Any(), gomock.Eq(rule.ID)).
+					Times(1).
+					Return(rule, nil)
+				store.EXPECT().
+					DeleteCategorizationRule(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/categorization-rules/%d", rule.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestApplyCategorizationRulesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	uncategorized := db.Category{ID: category.ID + 1, Name: categorizer.UncategorizedName, Owner: user.Username}
+	rule := RandomCategorizationRule(user.Username, category.ID)
+	expense := RandomExpense(wallet.ID, uncategorized.ID)
+	expense.ExpenseDescription = "Paid " + *rule.Pattern
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "DryRun",
+			body: gin.H{"wallet_id": wallet.ID, "dry_run": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.Category{category, uncategorized}, nil)
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.CategorizationRule{rule}, nil)
+				store.EXPECT().
+					ListCategoryExpenses(gomock.Any(), gomock.Eq(db.ListCategoryExpensesParams{
+						CategoryID: uncategorized.ID,
+						WalletID:   sql.NullInt64{Int64: wallet.ID, Valid: true},
+					})).
+					Times(1).
+					Return([]db.Expense{expense}, nil)
+				store.EXPECT().
+					SetExpenseCategories(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp categorizer.ReapplyResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.True(t, rsp.DryRun)
+				require.Len(t, rsp.Changes, 1)
+				require.Equal(t, expense.ID, rsp.Changes[0].ExpenseID)
+				require.Equal(t, category.ID, rsp.Changes[0].CategoryID)
+				require.Zero(t, rsp.Updated)
+			},
+		},
+		{
+			name: "Apply",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.Category{category, uncategorized}, nil)
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.CategorizationRule{rule}, nil)
+				store.EXPECT().
+					ListCategoryExpenses(gomock.Any(), gomock.Eq(db.ListCategoryExpensesParams{CategoryID: uncategorized.ID})).
+					Times(1).
+					Return([]db.Expense{expense}, nil)
+				store.EXPECT().
+					SetExpenseCategories(gomock.Any(), gomock.Eq(db.SetExpenseCategoriesParams{
+						Ids:            []int64{expense.ID},
+						CategoryIds:    []int64{category.ID},
+						FromCategoryID: uncategorized.ID,
+					})).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp categorizer.ReapplyResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.False(t, rsp.DryRun)
+				require.Equal(t, int64(1), rsp.Updated)
+			},
+		},
+		{
+			name: "UnauthorizedWallet",
+			body: gin.H{"wallet_id": wallet.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(RandomWallet("other_user"), nil)
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/categorization-rules/apply", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
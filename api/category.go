@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	db "github.com/symyzi/financial-helper/db/gen"
 	"github.com/symyzi/financial-helper/token"
 )
@@ -72,6 +73,7 @@ func (server *Server) getCategory(ctx *gin.Context) {
 }
 
 type ListCategoriesRequest struct {
+	pageRequest
 	Owner string `form:"owner" binding:"required"`
 }
 
@@ -89,12 +91,31 @@ func (server *Server) listCategories(ctx *gin.Context) {
 		return
 	}
 
-	categories, err := server.store.GetAllCategories(ctx, req.Owner)
+	pageSize, err := server.pageSize(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListCategoriesParams{
+		Owner:           req.Owner,
+		CursorID:        cursor.id(),
+		CursorCreatedAt: cursor.createdAt(),
+		Limit:           pageSize + 1,
+	}
+	categories, err := server.store.ListCategories(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, categories)
+	ctx.JSON(http.StatusOK, newListResponse(categories, pageSize, func(category db.Category) pageCursor {
+		return pageCursor{CreatedAt: category.CreatedAt, ID: category.ID}
+	}))
 }
 
 type deleteCategoryRequest struct {
@@ -131,3 +152,61 @@ func (server *Server) deleteCategory(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, gin.H{})
 }
+
+type updateCategoryURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateCategoryRequest struct {
+	Name *string `json:"name" binding:"omitempty,min=1"`
+}
+
+func (server *Server) updateCategory(ctx *gin.Context) {
+	var uri updateCategoryURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, err := server.store.GetCategoryByID(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if category.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	arg := db.UpdateCategoryParams{
+		ID: category.ID,
+	}
+	if req.Name != nil {
+		arg.Name = sql.NullString{String: *req.Name, Valid: true}
+	}
+
+	category, err = server.store.UpdateCategory(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, category)
+}
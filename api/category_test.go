@@ -13,6 +13,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	db "github.com/symyzi/financial-helper/db/gen"
 	mockdb "github.com/symyzi/financial-helper/db/mock"
@@ -346,10 +347,12 @@ func TestListCategories(t *testing.T) {
 		RandomCategory(user.Username),
 		RandomCategory(user.Username),
 	}
+	cursor := pageCursor{CreatedAt: categories[1].CreatedAt, ID: categories[1].ID}
 
 	testCases := []struct {
 		name          string
 		owner         string
+		query         string
 		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
@@ -362,16 +365,62 @@ func TestListCategories(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 
+				arg := db.ListCategoriesParams{
+					Owner: user.Username,
+					Limit: 6,
+				}
+				store.EXPECT().
+					ListCategories(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(categories, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var gotCategories listResponse[db.Category]
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &gotCategories))
+				require.Len(t, gotCategories.Items, len(categories))
+				require.Empty(t, gotCategories.NextCursor)
+			},
+		},
+		{
+			name:  "NextPage",
+			owner: user.Username,
+			query: "&page_size=2",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListCategoriesParams{
+					Owner: user.Username,
+					Limit: 3,
+				}
 				store.EXPECT().
-					GetAllCategories(gomock.Any(), gomock.Eq(user.Username)).
+					ListCategories(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(categories, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				var gotCategories []db.Category
+				var gotCategories listResponse[db.Category]
 				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &gotCategories))
-				require.Len(t, gotCategories, len(categories))
+				require.Len(t, gotCategories.Items, 2)
+				require.Equal(t, encodeCursor(cursor), gotCategories.NextCursor)
+			},
+		},
+		{
+			name:  "InvalidCursor",
+			owner: user.Username,
+			query: "&cursor=not-a-cursor",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListCategories(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
@@ -395,7 +444,7 @@ func TestListCategories(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
-					GetAllCategories(gomock.Any(), user.Username).
+					ListCategories(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(nil, sql.ErrConnDone)
 			},
@@ -416,7 +465,7 @@ func TestListCategories(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/categories?owner=%s", tc.owner)
+			url := fmt.Sprintf("/categories?owner=%s%s", tc.owner, tc.query)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
@@ -427,6 +476,140 @@ func TestListCategories(t *testing.T) {
 	}
 }
 
+func TestUpdateCategory(t *testing.T) {
+	user, _ := randomUser(t)
+	category := RandomCategory(user.Username)
+
+	updated := category
+	updated.Name = util.RandomString(6)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name": updated.Name,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+
+				arg := db.UpdateCategoryParams{
+					ID:   category.ID,
+					Name: sql.NullString{String: updated.Name, Valid: true},
+				}
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategory(t, recorder.Body, updated)
+			},
+		},
+		{
+			name: "DuplicateName",
+			body: gin.H{
+				"name": updated.Name,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Category{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{
+				"name": updated.Name,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{
+				"name": updated.Name,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/categories/%d", category.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 func requireBodyMatchCategory(t *testing.T, body *bytes.Buffer, category db.Category) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
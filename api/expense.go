@@ -1,15 +1,21 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/symyzi/financial-helper/categorizer"
 	db "github.com/symyzi/financial-helper/db/gen"
 	"github.com/symyzi/financial-helper/token"
 )
 
+// createExpenseRequest leaves CategoryID zero to pick the category by the owner's
+// categorization rules
 type createExpenseRequest struct {
 	WalletID           int64  `json:"wallet_id"`
 	Amount             int64  `json:"amount"`
@@ -43,52 +49,178 @@ func (server *Server) createExpense(ctx *gin.Context) {
 		ExpenseDescription: req.ExpenseDescription,
 		CategoryID:         req.CategoryID,
 	}
-	expense, err := server.store.CreateExpense(ctx, arg)
+	if arg.CategoryID == 0 {
+		arg.CategoryID, err = categorizer.Categorize(ctx, server.store, wallet.Owner, categorizer.Expense{
+			WalletID:    wallet.ID,
+			Amount:      arg.Amount,
+			Description: arg.ExpenseDescription,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	result, err := server.store.CreateExpenseTx(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, expense)
+	ctx.JSON(http.StatusOK, result.Expense)
+}
+
+type listExpensesURI struct {
+	WalletID int64 `uri:"id" binding:"required,min=1"`
 }
 
 type listExpensesRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	pageRequest
+	FromDate    string  `form:"from_date" binding:"omitempty,datetime=2006-01-02"`
+	ToDate      string  `form:"to_date" binding:"omitempty,datetime=2006-01-02"`
+	CategoryIDs []int64 `form:"category_id" binding:"omitempty,dive,min=1"`
+	TagIDs      []int64 `form:"tag_id" binding:"omitempty,dive,min=1"`
+	MinAmount   *int64  `form:"min_amount" binding:"omitempty,min=0"`
+	MaxAmount   *int64  `form:"max_amount" binding:"omitempty,min=0"`
+	Description string  `form:"description" binding:"omitempty,max=100"`
+	Sort        string  `form:"sort" binding:"omitempty,oneof=date_asc date_desc amount_asc amount_desc"`
+}
+
+// likeEscaper escapes the LIKE wildcards in a user supplied substring
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// expenseQuery is the ListExpenses query of one sort order with its arguments.
+// Every order has its own query, so the cursor and ORDER BY can use an index.
+type expenseQuery struct {
+	sort string
+	list func(ctx context.Context, store db.Querier) ([]db.Expense, error)
+}
+
+// params translates the query string filters into the ListExpenses query of the
+// requested order. Both dates are inclusive; the newest expenses come first by default.
+func (req listExpensesRequest) params(walletID int64, pageSize int32) (expenseQuery, error) {
+	query := expenseQuery{sort: req.Sort}
+	if query.sort == "" {
+		query.sort = "date_desc"
+	}
+	arg := db.ListExpensesByDateDescParams{
+		WalletID:    walletID,
+		CategoryIds: req.CategoryIDs,
+		TagIds:      req.TagIDs,
+		Limit:       pageSize + 1,
+	}
+
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return query, err
+	}
+	if cursor != nil && cursor.Sort != query.sort {
+		return query, errors.New("cursor does not match the sort order")
+	}
+	arg.CursorID = cursor.id()
+	arg.CursorCreatedAt = cursor.createdAt()
+
+	if req.FromDate != "" {
+		fromDate, err := time.Parse(dateLayout, req.FromDate)
+		if err != nil {
+			return query, err
+		}
+		arg.FromTime = sql.NullTime{Time: fromDate, Valid: true}
+	}
+	if req.ToDate != "" {
+		toDate, err := time.Parse(dateLayout, req.ToDate)
+		if err != nil {
+			return query, err
+		}
+		arg.ToTime = sql.NullTime{Time: toDate.AddDate(0, 0, 1), Valid: true}
+	}
+	if arg.FromTime.Valid && arg.ToTime.Valid && !arg.FromTime.Time.Before(arg.ToTime.Time) {
+		return query, errors.New("from_date must not be after to_date")
+	}
+
+	if req.MinAmount != nil {
+		arg.MinAmount = sql.NullInt64{Int64: *req.MinAmount, Valid: true}
+	}
+	if req.MaxAmount != nil {
+		arg.MaxAmount = sql.NullInt64{Int64: *req.MaxAmount, Valid: true}
+	}
+	if arg.MinAmount.Valid && arg.MaxAmount.Valid && arg.MinAmount.Int64 > arg.MaxAmount.Int64 {
+		return query, errors.New("min_amount must not be greater than max_amount")
+	}
+
+	if req.Description != "" {
+		arg.Description = sql.NullString{String: likeEscaper.Replace(req.Description), Valid: true}
+	}
+
+	amountArg := db.ListExpensesByAmountDescParams{
+		WalletID:     arg.WalletID,
+		FromTime:     arg.FromTime,
+		ToTime:       arg.ToTime,
+		CategoryIds:  arg.CategoryIds,
+		MinAmount:    arg.MinAmount,
+		MaxAmount:    arg.MaxAmount,
+		Description:  arg.Description,
+		TagIds:       arg.TagIds,
+		CursorID:     arg.CursorID,
+		CursorAmount: cursor.amount(),
+		Limit:        arg.Limit,
+	}
+
+	switch query.sort {
+	case "date_asc":
+		query.list = func(ctx context.Context, store db.Querier) ([]db.Expense, error) {
+			return store.ListExpensesByDateAsc(ctx, db.ListExpensesByDateAscParams(arg))
+		}
+	case "amount_desc":
+		query.list = func(ctx context.Context, store db.Querier) ([]db.Expense, error) {
+			return store.ListExpensesByAmountDesc(ctx, amountArg)
+		}
+	case "amount_asc":
+		query.list = func(ctx context.Context, store db.Querier) ([]db.Expense, error) {
+			return store.ListExpensesByAmountAsc(ctx, db.ListExpensesByAmountAscParams(amountArg))
+		}
+	default:
+		query.list = func(ctx context.Context, store db.Querier) ([]db.Expense, error) {
+			return store.ListExpensesByDateDesc(ctx, arg)
+		}
+	}
+	return query, nil
 }
 
 func (server *Server) listExpenses(ctx *gin.Context) {
+	var uri listExpensesURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 	var req listExpensesRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-
-	arg := db.ListExpensesParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	pageSize, err := server.pageSize(req.pageRequest)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
-
-	expenses, err := server.store.ListExpenses(ctx, arg)
+	query, err := req.params(uri.WalletID, pageSize)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	for _, expense := range expenses {
-		wallet, err := server.store.GetWallet(ctx, expense.WalletID)
-		if err != nil {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-		if wallet.Owner != authPayLoad.Username {
-			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
-			return
-		}
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validWallet(ctx, uri.WalletID, authPayLoad.Username); !valid {
+		return
 	}
 
-	ctx.JSON(http.StatusOK, expenses)
+	expenses, err := query.list(ctx, server.store)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newListResponse(expenses, pageSize, func(expense db.Expense) pageCursor {
+		return pageCursor{CreatedAt: expense.CreatedAt, ID: expense.ID, Amount: expense.Amount, Sort: query.sort}
+	}))
 }
 
 type getExpenseRequest struct {
@@ -165,10 +297,106 @@ func (server *Server) deleteExpense(ctx *gin.Context) {
 		return
 	}
 
-	err = server.store.DeleteExpense(ctx, req.ID)
+	// the attachment rows go with the expense, their blobs are removed afterwards
+	result, err := server.store.DeleteExpenseTx(ctx, req.ID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	server.deleteBlobs(result.BlobKeys...)
 	ctx.JSON(http.StatusOK, expense)
 }
+
+type updateExpenseURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateExpenseRequest struct {
+	Amount             *int64  `json:"amount" binding:"omitempty,gt=0"`
+	ExpenseDescription *string `json:"expense_description"`
+	CategoryID         *int64  `json:"category_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) updateExpense(ctx *gin.Context) {
+	var uri updateExpenseURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateExpenseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	expense, err := server.store.GetExpense(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validWallet(ctx, expense.WalletID, authPayLoad.Username); !valid {
+		return
+	}
+
+	arg := db.UpdateExpenseParams{
+		ID: expense.ID,
+	}
+	if req.Amount != nil {
+		arg.Amount = sql.NullInt64{Int64: *req.Amount, Valid: true}
+	}
+	if req.ExpenseDescription != nil {
+		arg.ExpenseDescription = sql.NullString{String: *req.ExpenseDescription, Valid: true}
+	}
+	if req.CategoryID != nil {
+		category, err := server.store.GetCategoryByID(ctx, *req.CategoryID)
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if category.Owner != authPayLoad.Username {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+			return
+		}
+		arg.CategoryID = sql.NullInt64{Int64: category.ID, Valid: true}
+	}
+
+	result, err := server.store.UpdateExpenseTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrSplitTotal) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Expense)
+}
+
+type expenseURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// ownedExpense loads an expense and checks that its wallet belongs to owner,
+// writing the error response itself
+func (server *Server) ownedExpense(ctx *gin.Context, id int64, owner string) (db.Expense, bool) {
+	expense, err := server.store.GetExpense(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return expense, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return expense, false
+	}
+
+	if _, valid := server.validWallet(ctx, expense.WalletID, owner); !valid {
+		return expense, false
+	}
+	return expense, true
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+type expenseSplitRequest struct {
+	CategoryID int64 `json:"category_id" binding:"required,min=1"`
+	Amount     int64 `json:"amount" binding:"required,gt=0"`
+}
+
+// setExpenseSplitsRequest replaces all splits of an expense, an empty list removes them
+type setExpenseSplitsRequest struct {
+	Splits []expenseSplitRequest `json:"splits" binding:"dive"`
+}
+
+func (server *Server) listExpenseSplits(ctx *gin.Context) {
+	var uri expenseURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.ownedExpense(ctx, uri.ID, authPayLoad.Username); !valid {
+		return
+	}
+
+	splits, err := server.store.ListExpenseSplits(ctx, uri.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, splits)
+}
+
+func (server *Server) setExpenseSplits(ctx *gin.Context) {
+	var uri expenseURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req setExpenseSplitsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if len(req.Splits) == 1 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("an expense is split across at least two categories")))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.ownedExpense(ctx, uri.ID, authPayLoad.Username); !valid {
+		return
+	}
+
+	arg := db.SetExpenseSplitsTxParams{
+		ExpenseID: uri.ID,
+		Splits:    make([]db.ExpenseSplitParams, 0, len(req.Splits)),
+	}
+	checked := make(map[int64]bool)
+	for _, split := range req.Splits {
+		if !checked[split.CategoryID] {
+			category, err := server.store.GetCategoryByID(ctx, split.CategoryID)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					ctx.JSON(http.StatusNotFound, errorResponse(err))
+					return
+				}
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+			if category.Owner != authPayLoad.Username {
+				ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+				return
+			}
+			checked[split.CategoryID] = true
+		}
+		arg.Splits = append(arg.Splits, db.ExpenseSplitParams{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount,
+		})
+	}
+
+	result, err := server.store.SetExpenseSplitsTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrSplitTotal) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+)
+
+func TestSetExpenseSplitsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category1 := RandomCategory(user.Username)
+	category2 := RandomCategory(user.Username)
+	expense := RandomExpense(wallet.ID, category1.ID)
+	expense.Amount = 1000
+
+	splits := []db.ExpenseSplit{
+		{ID: 1, ExpenseID: expense.ID, CategoryID: category1.ID, Amount: 600},
+		{ID: 2, ExpenseID: expense.ID, CategoryID: category2.ID, Amount: 400},
+	}
+	body := gin.H{
+		"splits": []gin.H{
+			{"category_id": category1.ID, "amount": 600},
+			{"category_id": category2.ID, "amount": 400},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category1.ID)).
+					Times(1).
+					Return(category1, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category2.ID)).
+					Times(1).
+					Return(category2, nil)
+
+				arg := db.SetExpenseSplitsTxParams{
+					ExpenseID: expense.ID,
+					Splits: []db.ExpenseSplitParams{
+						{CategoryID: category1.ID, Amount: 600},
+						{CategoryID: category2.ID, Amount: 400},
+					},
+				}
+				store.EXPECT().
+					SetExpenseSplitsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.SetExpenseSplitsTxResult{Expense: expense, Splits: splits}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.SetExpenseSplitsTxResult
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, splits, got.Splits)
+			},
+		},
+		{
+			name:     "RemoveSplits",
+			username: user.Username,
+			body:     gin.H{"splits": []gin.H{}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				arg := db.SetExpenseSplitsTxParams{
+					ExpenseID: expense.ID,
+					Splits:    []db.ExpenseSplitParams{},
+				}
+				store.EXPECT().
+					SetExpenseSplitsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.SetExpenseSplitsTxResult{Expense: expense, Splits: []db.ExpenseSplit{}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "SingleSplit",
+			username: user.Username,
+			body: gin.H{
+				"splits": []gin.H{{"category_id": category1.ID, "amount": 1000}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetExpenseSplitsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidAmount",
+			username: user.Username,
+			body: gin.H{
+				"splits": []gin.H{
+					{"category_id": category1.ID, "amount": 1000},
+					{"category_id": category2.ID, "amount": 0},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetExpenseSplitsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "TotalMismatch",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ any, id int64) (db.Category, error) {
+						return db.Category{ID: id, Owner: user.Username}, nil
+					})
+				store.EXPECT().
+					SetExpenseSplitsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.SetExpenseSplitsTxResult{}, fmt.Errorf("%w: 1000 != 900", db.ErrSplitTotal))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "ForeignCategory",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category1.ID)).
+					Times(1).
+					Return(RandomCategory("other_user"), nil)
+				store.EXPECT().
+					SetExpenseSplitsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "CategoryNotFound",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category1.ID)).
+					Times(1).
+					Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().
+					SetExpenseSplitsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: "other_user",
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					SetExpenseSplitsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "ExpenseNotFound",
+			username: user.Username,
+			body:     body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(db.Expense{}, sql.ErrNoRows)
+				store.EXPECT().
+					SetExpenseSplitsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			url := fmt.Sprintf("/wallets/%d/expenses/%d/splits", wallet.ID, expense.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListExpenseSplitsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	expense := RandomExpense(wallet.ID, category.ID)
+	splits := []db.ExpenseSplit{
+		{ID: 1, ExpenseID: expense.ID, CategoryID: category.ID, Amount: 1},
+		{ID: 2, ExpenseID: expense.ID, CategoryID: category.ID, Amount: expense.Amount - 1},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+		Times(1).
+		Return(expense, nil)
+	store.EXPECT().
+		GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+		Times(1).
+		Return(wallet, nil)
+	store.EXPECT().
+		ListExpenseSplits(gomock.Any(), gomock.Eq(expense.ID)).
+		Times(1).
+		Return(splits, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/wallets/%d/expenses/%d/splits", wallet.ID, expense.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []db.ExpenseSplit
+	err = json.Unmarshal(recorder.Body.Bytes(), &got)
+	require.NoError(t, err)
+	require.Equal(t, splits, got)
+}
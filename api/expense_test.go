@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -58,9 +59,88 @@ func TestCreateExpenseAPI(t *testing.T) {
 					CategoryID:         expense.CategoryID,
 				}
 				store.EXPECT().
-					CreateExpense(gomock.Any(), gomock.Eq(arg)).
+					CreateExpenseTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(expense, nil)
+					Return(db.ExpenseTxResult{Expense: expense, Wallet: wallet}, nil)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "CategoryFromRule",
+			body: gin.H{
+				"wallet_id":           expense.WalletID,
+				"amount":              expense.Amount,
+				"expense_description": "Taxi home",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(expense.WalletID)).
+					Times(1).
+					Return(wallet, nil)
+				pattern := "taxi"
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.CategorizationRule{{ID: 1, CategoryID: category.ID, MatchType: "substring", Pattern: &pattern}}, nil)
+				store.EXPECT().
+					GetOrCreateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				arg := db.CreateExpenseParams{
+					WalletID:           expense.WalletID,
+					Amount:             expense.Amount,
+					ExpenseDescription: "Taxi home",
+					CategoryID:         category.ID,
+				}
+				store.EXPECT().
+					CreateExpenseTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ExpenseTxResult{Expense: expense, Wallet: wallet}, nil)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "Uncategorized",
+			body: gin.H{
+				"wallet_id":           expense.WalletID,
+				"amount":              expense.Amount,
+				"expense_description": "Groceries",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(expense.WalletID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.CategorizationRule{}, nil)
+				uncategorized := db.Category{ID: category.ID + 1, Name: "Uncategorized", Owner: user.Username}
+				store.EXPECT().
+					GetOrCreateCategory(gomock.Any(), gomock.Eq(db.GetOrCreateCategoryParams{Name: "Uncategorized", Owner: user.Username})).
+					Times(1).
+					Return(uncategorized, nil)
+
+				arg := db.CreateExpenseParams{
+					WalletID:           expense.WalletID,
+					Amount:             expense.Amount,
+					ExpenseDescription: "Groceries",
+					CategoryID:         uncategorized.ID,
+				}
+				store.EXPECT().
+					CreateExpenseTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ExpenseTxResult{Expense: expense, Wallet: wallet}, nil)
 			},
 			checkResponse: func(recoder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recoder.Code)
@@ -83,9 +163,9 @@ func TestCreateExpenseAPI(t *testing.T) {
 					Times(1).
 					Return(wallet, nil)
 				store.EXPECT().
-					CreateExpense(gomock.Any(), gomock.Any()).
+					CreateExpenseTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.Expense{}, sql.ErrConnDone)
+					Return(db.ExpenseTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recoder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recoder.Code)
@@ -116,9 +196,9 @@ func TestCreateExpenseAPI(t *testing.T) {
 				}
 
 				store.EXPECT().
-					CreateExpense(gomock.Any(), gomock.Eq(arg)).
+					CreateExpenseTx(gomock.Any(), gomock.Eq(arg)).
 					AnyTimes().
-					Return(db.Expense{}, sql.ErrConnDone)
+					Return(db.ExpenseTxResult{}, sql.ErrConnDone)
 			},
 
 			checkResponse: func(recoder *httptest.ResponseRecorder) {
@@ -172,9 +252,9 @@ func TestCreateExpenseAPI(t *testing.T) {
 				}
 
 				store.EXPECT().
-					CreateExpense(gomock.Any(), gomock.Eq(arg)).
+					CreateExpenseTx(gomock.Any(), gomock.Eq(arg)).
 					AnyTimes().
-					Return(db.Expense{}, sql.ErrConnDone)
+					Return(db.ExpenseTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recoder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recoder.Code)
@@ -353,15 +433,22 @@ func TestListExpensesAPI(t *testing.T) {
 	category := RandomCategory(user.Username)
 	n := 5
 
-	expenses := make([]db.Expense, n)
+	expenses := make([]db.Expense, n+1)
 
-	for i := 0; i < n; i++ {
+	for i := range expenses {
 		expenses[i] = RandomExpense(wallet.ID, category.ID)
 	}
+	cursor := pageCursor{
+		CreatedAt: expenses[n-1].CreatedAt,
+		ID:        expenses[n-1].ID,
+		Amount:    expenses[n-1].Amount,
+		Sort:      "amount_asc",
+	}
 
 	type Query struct {
-		pageID   int
+		cursor   string
 		pageSize int
+		filters  url.Values
 	}
 
 	testCases := []struct {
@@ -374,7 +461,6 @@ func TestListExpensesAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -387,25 +473,231 @@ func TestListExpensesAPI(t *testing.T) {
 					AnyTimes().
 					Return(wallet, nil)
 
-				arg := db.ListExpensesParams{
-					Limit:  int32(n),
-					Offset: 0,
+				arg := db.ListExpensesByDateDescParams{
+					WalletID: wallet.ID,
+					Limit:    int32(n + 1),
+				}
+
+				store.EXPECT().
+					ListExpensesByDateDesc(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(expenses[:n], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchExpenses(t, recorder.Body, expenses[:n], "")
+			},
+		},
+		{
+			name: "Filters",
+			query: Query{
+				cursor:   encodeCursor(cursor),
+				pageSize: n,
+				filters: url.Values{
+					"from_date":   {"2024-01-01"},
+					"to_date":     {"2024-01-31"},
+					"category_id": {"3", "7"},
+					"tag_id":      {"11"},
+					"min_amount":  {"100"},
+					"max_amount":  {"5000"},
+					"description": {"50%_off"},
+					"sort":        {"amount_asc"},
+				},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.ListExpensesByAmountAscParams{
+					WalletID:     wallet.ID,
+					FromTime:     sql.NullTime{Time: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+					ToTime:       sql.NullTime{Time: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+					CategoryIds:  []int64{3, 7},
+					TagIds:       []int64{11},
+					MinAmount:    sql.NullInt64{Int64: 100, Valid: true},
+					MaxAmount:    sql.NullInt64{Int64: 5000, Valid: true},
+					Description:  sql.NullString{String: `50\%\_off`, Valid: true},
+					CursorID:     sql.NullInt64{Int64: cursor.ID, Valid: true},
+					CursorAmount: cursor.Amount,
+					Limit:        int32(n + 1),
 				}
 
 				store.EXPECT().
-					ListExpenses(gomock.Any(), gomock.Eq(arg)).
+					ListExpensesByAmountAsc(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(expenses, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchExpenses(t, recorder.Body, expenses)
+				requireBodyMatchExpenses(t, recorder.Body, expenses[:n], encodeCursor(cursor))
+			},
+		},
+		{
+			name: "SortDateAsc",
+			query: Query{
+				pageSize: n,
+				filters:  url.Values{"sort": {"date_asc"}},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.ListExpensesByDateAscParams{
+					WalletID: wallet.ID,
+					Limit:    int32(n + 1),
+				}
+				store.EXPECT().
+					ListExpensesByDateAsc(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(expenses[:n], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SortAmountDesc",
+			query: Query{
+				pageSize: n,
+				filters:  url.Values{"sort": {"amount_desc"}},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.ListExpensesByAmountDescParams{
+					WalletID: wallet.ID,
+					Limit:    int32(n + 1),
+				}
+				store.EXPECT().
+					ListExpensesByAmountDesc(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(expenses[:n], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CursorSortMismatch",
+			query: Query{
+				cursor:   encodeCursor(cursor),
+				pageSize: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExpensesByDateDesc(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			query: Query{
+				pageSize: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					ListExpensesByDateDesc(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidDateRange",
+			query: Query{
+				pageSize: n,
+				filters: url.Values{
+					"from_date": {"2024-02-01"},
+					"to_date":   {"2024-01-01"},
+				},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExpensesByDateDesc(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAmountRange",
+			query: Query{
+				pageSize: n,
+				filters: url.Values{
+					"min_amount": {"500"},
+					"max_amount": {"100"},
+				},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExpensesByDateDesc(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidSort",
+			query: Query{
+				pageSize: n,
+				filters: url.Values{
+					"sort": {"name"},
+				},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExpensesByDateDesc(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
 			name: "NoAuthorization",
 			query: Query{
-				pageID:   1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -422,7 +714,6 @@ func TestListExpensesAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -435,7 +726,7 @@ func TestListExpensesAPI(t *testing.T) {
 					Return(wallet, nil)
 
 				store.EXPECT().
-					ListExpenses(gomock.Any(), gomock.Any()).
+					ListExpensesByDateDesc(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return([]db.Expense{}, sql.ErrConnDone)
 			},
@@ -444,9 +735,9 @@ func TestListExpensesAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
+				cursor:   "not-a-cursor",
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -459,7 +750,7 @@ func TestListExpensesAPI(t *testing.T) {
 					Return(wallet, nil)
 
 				store.EXPECT().
-					ListExpenses(gomock.Any(), gomock.Any()).
+					ListExpensesByDateDesc(gomock.Any(), gomock.Any()).
 					Times(0)
 
 			},
@@ -470,7 +761,6 @@ func TestListExpensesAPI(t *testing.T) {
 		{
 			name: "InvalidPageSize",
 			query: Query{
-				pageID:   1,
 				pageSize: 100000,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -483,7 +773,7 @@ func TestListExpensesAPI(t *testing.T) {
 					Return(wallet, nil)
 
 				store.EXPECT().
-					ListExpenses(gomock.Any(), gomock.Any()).
+					ListExpensesByDateDesc(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -505,13 +795,20 @@ func TestListExpensesAPI(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/wallets/%d/expenses", wallet.ID)
-			request, err := http.NewRequest(http.MethodGet, url, nil)
+			path := fmt.Sprintf("/wallets/%d/expenses", wallet.ID)
+			request, err := http.NewRequest(http.MethodGet, path, nil)
 			require.NoError(t, err)
 
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
 			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			for key, values := range tc.query.filters {
+				for _, value := range values {
+					q.Add(key, value)
+				}
+			}
 			request.URL.RawQuery = q.Encode()
 
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -551,9 +848,9 @@ func TestDeleteExpenseAPI(t *testing.T) {
 					Return(expense, nil)
 
 				store.EXPECT().
-					DeleteExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					DeleteExpenseTx(gomock.Any(), gomock.Eq(expense.ID)).
 					Times(1).
-					Return(nil)
+					Return(db.DeleteExpenseTxResult{Expense: expense, Wallet: wallet}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -619,9 +916,9 @@ func TestDeleteExpenseAPI(t *testing.T) {
 					Return(expense, nil)
 
 				store.EXPECT().
-					DeleteExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					DeleteExpenseTx(gomock.Any(), gomock.Eq(expense.ID)).
 					Times(1).
-					Return(sql.ErrConnDone)
+					Return(db.DeleteExpenseTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -667,14 +964,220 @@ func TestDeleteExpenseAPI(t *testing.T) {
 	}
 }
 
-func requireBodyMatchExpenses(t *testing.T, body *bytes.Buffer, expenses []db.Expense) {
+func TestUpdateExpenseAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	expense := RandomExpense(wallet.ID, category.ID)
+
+	newCategory := RandomCategory(user.Username)
+	updated := expense
+	updated.Amount = expense.Amount + 1
+	updated.CategoryID = newCategory.ID
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"amount":      updated.Amount,
+				"category_id": newCategory.ID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(newCategory.ID)).
+					Times(1).
+					Return(newCategory, nil)
+
+				arg := db.UpdateExpenseParams{
+					ID:         expense.ID,
+					Amount:     sql.NullInt64{Int64: updated.Amount, Valid: true},
+					CategoryID: sql.NullInt64{Int64: newCategory.ID, Valid: true},
+				}
+				store.EXPECT().
+					UpdateExpenseTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ExpenseTxResult{Expense: updated, Wallet: wallet}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchExpense(t, recorder.Body, updated)
+			},
+		},
+		{
+			name: "ForeignCategory",
+			body: gin.H{
+				"category_id": newCategory.ID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(newCategory.ID)).
+					Times(1).
+					Return(RandomCategory("other_user"), nil)
+				store.EXPECT().
+					UpdateExpenseTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "SplitExpenseAmount",
+			body: gin.H{
+				"amount": updated.Amount,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					UpdateExpenseTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExpenseTxResult{}, db.ErrSplitTotal)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			body: gin.H{
+				"amount": updated.Amount,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					UpdateExpenseTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAmount",
+			body: gin.H{
+				"amount": -1,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					UpdateExpenseTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{
+				"amount": updated.Amount,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(db.Expense{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdateExpenseTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/wallets/%d/expenses/%d", wallet.ID, expense.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func requireBodyMatchExpenses(t *testing.T, body *bytes.Buffer, expenses []db.Expense, nextCursor string) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotExpenses []db.Expense
+	var gotExpenses listResponse[db.Expense]
 	err = json.Unmarshal(data, &gotExpenses)
 	require.NoError(t, err)
-	require.Equal(t, expenses, gotExpenses)
+	require.Equal(t, expenses, gotExpenses.Items)
+	require.Equal(t, nextCursor, gotExpenses.NextCursor)
 }
 
 func requireBodyMatchExpense(t *testing.T, body *bytes.Buffer, expense db.Expense) {
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/symyzi/financial-helper/exporter"
+	"github.com/symyzi/financial-helper/token"
+)
+
+var exportContentTypes = map[string]string{
+	exporter.FormatCSV:   "text/csv; charset=utf-8",
+	exporter.FormatJSONL: "application/x-ndjson",
+}
+
+type exportURI struct {
+	WalletID int64  `uri:"id" binding:"omitempty,min=1"`
+	Format   string `uri:"format" binding:"required,oneof=csv jsonl"`
+}
+
+type exportRequest struct {
+	FromDate string `form:"from_date" binding:"omitempty,datetime=2006-01-02"`
+	ToDate   string `form:"to_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// filter builds the export filter; both dates are inclusive
+func (req exportRequest) filter(owner string, walletID int64) (exporter.Filter, error) {
+	filter := exporter.Filter{Owner: owner}
+	if walletID != 0 {
+		filter.WalletID = sql.NullInt64{Int64: walletID, Valid: true}
+	}
+	if req.FromDate != "" {
+		fromDate, err := time.Parse(dateLayout, req.FromDate)
+		if err != nil {
+			return filter, err
+		}
+		filter.FromTime = sql.NullTime{Time: fromDate, Valid: true}
+	}
+	if req.ToDate != "" {
+		toDate, err := time.Parse(dateLayout, req.ToDate)
+		if err != nil {
+			return filter, err
+		}
+		filter.ToTime = sql.NullTime{Time: toDate.AddDate(0, 0, 1), Valid: true}
+	}
+	if filter.FromTime.Valid && filter.ToTime.Valid && !filter.FromTime.Time.Before(filter.ToTime.Time) {
+		return filter, errors.New("from_date must not be after to_date")
+	}
+	return filter, nil
+}
+
+// flushWriter pushes every exported batch to the client
+type flushWriter struct {
+	exporter.Writer
+	flusher http.Flusher
+}
+
+func (w flushWriter) Flush() error {
+	if err := w.Writer.Flush(); err != nil {
+		return err
+	}
+	w.flusher.Flush()
+	return nil
+}
+
+// exportTransactions streams the expenses and incomes of one wallet, or of every
+// wallet of the user when no wallet is given
+func (server *Server) exportTransactions(ctx *gin.Context) {
+	var uri exportURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req exportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	filter, err := req.filter(authPayLoad.Username, uri.WalletID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if uri.WalletID != 0 {
+		if _, valid := server.validWallet(ctx, uri.WalletID, authPayLoad.Username); !valid {
+			return
+		}
+	}
+
+	writer, err := exporter.NewWriter(ctx.Writer, uri.Format)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Type", exportContentTypes[uri.Format])
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="transactions.%s"`, uri.Format))
+	_, err = exporter.New(server.store).Export(ctx.Request.Context(), flushWriter{writer, ctx.Writer}, filter)
+	if err != nil {
+		if !ctx.Writer.Written() {
+			ctx.Writer.Header().Del("Content-Type")
+			ctx.Writer.Header().Del("Content-Disposition")
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		// the status line is already sent, so all that is left is to cut the download short
+		log.Printf("export for %s aborted: %v", authPayLoad.Username, err)
+		ctx.Abort()
+	}
+}
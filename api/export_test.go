@@ -0,0 +1,213 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/token"
+)
+
+func TestExportTransactionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	rows := []db.ExportTransactionsRow{
+		{
+			Type:         "expense",
+			ID:           1,
+			WalletID:     wallet.ID,
+			WalletName:   wallet.Name,
+			Currency:     wallet.Currency,
+			Amount:       500,
+			Description:  "Coffee",
+			CategoryID:   category.ID,
+			CategoryName: category.Name,
+			CreatedAt:    day,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		query         string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "WalletCSV",
+			path:  fmt.Sprintf("/wallets/%d/exports/csv", wallet.ID),
+			query: "from_date=2024-03-01&to_date=2024-03-31",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				arg := db.ExportTransactionsParams{
+					Owner:    user.Username,
+					WalletID: sql.NullInt64{Int64: wallet.ID, Valid: true},
+					FromTime: sql.NullTime{Time: day, Valid: true},
+					ToTime:   sql.NullTime{Time: day.AddDate(0, 1, 0), Valid: true},
+					Limit:    500,
+				}
+				store.EXPECT().
+					ExportTransactions(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "transactions.csv")
+
+				lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+				require.Len(t, lines, 2)
+				require.True(t, strings.HasPrefix(lines[1], "2024-03-01T00:00:00Z,expense,"))
+				require.Contains(t, lines[1], category.Name)
+			},
+		},
+		{
+			name: "AllWalletsJSONL",
+			path: "/exports/jsonl",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					ExportTransactions(gomock.Any(), gomock.Eq(db.ExportTransactionsParams{Owner: user.Username, Limit: 500})).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+				require.Equal(t, 1, strings.Count(recorder.Body.String(), "\n"))
+				require.Contains(t, recorder.Body.String(), `"category_name":"`+category.Name+`"`)
+			},
+		},
+		{
+			name: "UnknownFormat",
+			path: "/exports/xml",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExportTransactions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidDateRange",
+			path:  "/exports/csv",
+			query: "from_date=2024-03-02&to_date=2024-03-01",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExportTransactions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			path: fmt.Sprintf("/wallets/%d/exports/csv", wallet.ID),
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					ExportTransactions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			path: "/exports/csv",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExportTransactions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			path: "/exports/csv",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExportTransactions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+				require.Empty(t, recorder.Header().Get("Content-Disposition"))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := tc.path
+			if tc.query != "" {
+				url += "?" + tc.query
+			}
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
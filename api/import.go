@@ -0,0 +1,185 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/importer"
+	"github.com/symyzi/financial-helper/token"
+)
+
+// maxImportFileSize bounds the size of an uploaded statement
+const maxImportFileSize = 5 << 20
+
+type importWalletURI struct {
+	WalletID int64  `uri:"id" binding:"required,min=1"`
+	Format   string `uri:"format" binding:"required,oneof=csv ofx qif"`
+}
+
+// importRequest holds the upload options; the column mapping only applies to CSV
+type importRequest struct {
+	DateColumn        string `form:"date_column"`
+	AmountColumn      string `form:"amount_column"`
+	DescriptionColumn string `form:"description_column"`
+	CategoryColumn    string `form:"category_column"`
+	DateFormat        string `form:"date_format"`
+	DecimalSeparator  string `form:"decimal_separator"`
+	Sign              string `form:"sign" binding:"omitempty,oneof=negative positive"`
+	Delimiter         string `form:"delimiter"`
+	NoHeader          bool   `form:"no_header"`
+	DefaultCategoryID int64  `form:"default_category_id" binding:"omitempty,min=1"`
+}
+
+func (req importRequest) mapping() importer.Mapping {
+	return importer.Mapping{
+		DateColumn:        req.DateColumn,
+		AmountColumn:      req.AmountColumn,
+		DescriptionColumn: req.DescriptionColumn,
+		CategoryColumn:    req.CategoryColumn,
+		DateFormat:        req.DateFormat,
+		DecimalSeparator:  req.DecimalSeparator,
+		Sign:              req.Sign,
+		Delimiter:         req.Delimiter,
+		NoHeader:          req.NoHeader,
+	}
+}
+
+type importResponse struct {
+	Rows       []importer.Row `json:"rows"`
+	New        int            `json:"new"`
+	Duplicates int            `json:"duplicates"`
+	Skipped    int            `json:"skipped"`
+	Invalid    int            `json:"invalid"`
+	Expenses   []db.Expense   `json:"expenses,omitempty"`
+	Wallet     *db.Wallet     `json:"wallet,omitempty"`
+}
+
+func newImportResponse(rows []importer.Row) importResponse {
+	rsp := importResponse{Rows: rows}
+	for _, row := range rows {
+		switch row.Status {
+		case importer.StatusNew:
+			rsp.New++
+		case importer.StatusDuplicate:
+			rsp.Duplicates++
+		case importer.StatusSkipped:
+			rsp.Skipped++
+		case importer.StatusInvalid:
+			rsp.Invalid++
+		}
+	}
+	return rsp
+}
+
+// validate checks the options a format needs to categorize its rows. OFX carries
+// no categories, so every row falls back to the default one.
+func (req importRequest) validate(format string) error {
+	switch format {
+	case importer.FormatCSV:
+		if req.CategoryColumn == "" && req.DefaultCategoryID == 0 {
+			return errors.New("category_column or default_category_id is required")
+		}
+	case importer.FormatOFX:
+		if req.DefaultCategoryID == 0 {
+			return errors.New("default_category_id is required")
+		}
+	}
+	return nil
+}
+
+// parseImport checks the wallet and default category and parses the uploaded
+// statement, writing an error response when any of it fails
+func (server *Server) parseImport(ctx *gin.Context) (db.Wallet, []importer.Row, int64, bool) {
+	var uri importWalletURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Wallet{}, nil, 0, false
+	}
+	var req importRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Wallet{}, nil, 0, false
+	}
+	if err := req.validate(uri.Format); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Wallet{}, nil, 0, false
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Wallet{}, nil, 0, false
+	}
+	if fileHeader.Size > maxImportFileSize {
+		err := fmt.Errorf("file is larger than %d bytes", maxImportFileSize)
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+		return db.Wallet{}, nil, 0, false
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	wallet, valid := server.validWallet(ctx, uri.WalletID, authPayLoad.Username)
+	if !valid {
+		return wallet, nil, 0, false
+	}
+
+	if req.DefaultCategoryID != 0 {
+		category, err := server.store.GetCategoryByID(ctx, req.DefaultCategoryID)
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return wallet, nil, 0, false
+		}
+		if category.Owner != authPayLoad.Username {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+			return wallet, nil, 0, false
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return wallet, nil, 0, false
+	}
+	defer file.Close()
+
+	rows, err := importer.Parse(file, uri.Format, req.mapping())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return wallet, nil, 0, false
+	}
+	return wallet, rows, req.DefaultCategoryID, true
+}
+
+func (server *Server) previewImport(ctx *gin.Context) {
+	wallet, rows, defaultCategoryID, valid := server.parseImport(ctx)
+	if !valid {
+		return
+	}
+
+	rows, err := importer.New(server.store).Preview(ctx, wallet, rows, defaultCategoryID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newImportResponse(rows))
+}
+
+func (server *Server) importStatement(ctx *gin.Context) {
+	wallet, rows, defaultCategoryID, valid := server.parseImport(ctx)
+	if !valid {
+		return
+	}
+
+	result, err := importer.New(server.store).Import(ctx, wallet, rows, defaultCategoryID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newImportResponse(result.Rows)
+	rsp.Expenses = result.Expenses
+	rsp.Wallet = &result.Wallet
+	ctx.JSON(http.StatusOK, rsp)
+}
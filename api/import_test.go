@@ -0,0 +1,516 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/importer"
+	"github.com/symyzi/financial-helper/token"
+)
+
+const testStatement = "date,amount,description,category\n" +
+	"2024-03-01,-5.00,Coffee,Food\n" +
+	"2024-03-02,-12.50,Taxi,\n" +
+	"2024-03-03,100.00,Salary,\n"
+
+const testOFXStatement = `<OFX><BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240301<TRNAMT>-5.00<FITID>fit-1<NAME>Coffee</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240302<TRNAMT>-12.50<FITID>fit-2<NAME>Taxi</STMTTRN>
+</BANKTRANLIST></OFX>
+`
+
+const testQIFStatement = "!Type:Bank\nD03/01/2024\nT-5.00\nPCoffee\nLFood\n^\n"
+
+func TestImportAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	food := RandomCategory(user.Username)
+	food.Name = "Food"
+	other := RandomCategory(user.Username)
+
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	mapping := map[string]string{
+		"date_column":         "date",
+		"amount_column":       "amount",
+		"description_column":  "description",
+		"category_column":     "category",
+		"default_category_id": fmt.Sprint(other.ID),
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		fields        map[string]string
+		file          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Preview",
+			path:   "/imports/csv/preview",
+			fields: mapping,
+			file:   testStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.Category{food, other}, nil)
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.CategorizationRule{}, nil)
+				store.EXPECT().
+					ListWalletExpensesBetween(gomock.Any(), gomock.Eq(db.ListWalletExpensesBetweenParams{
+						WalletID: wallet.ID,
+						FromTime: day,
+						ToTime:   day.AddDate(0, 0, 2),
+					})).
+					Times(1).
+					Return([]db.Expense{{WalletID: wallet.ID, Amount: 500, ExpenseDescription: "Coffee", CreatedAt: day}}, nil)
+				store.EXPECT().
+					ImportExpensesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp importResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Len(t, rsp.Rows, 3)
+				require.Equal(t, 1, rsp.New)
+				require.Equal(t, 1, rsp.Duplicates)
+				require.Equal(t, 1, rsp.Skipped)
+				require.Equal(t, food.ID, rsp.Rows[0].CategoryID)
+				require.Equal(t, other.ID, rsp.Rows[1].CategoryID)
+				require.Empty(t, rsp.Expenses)
+			},
+		},
+		{
+			name:   "Import",
+			path:   "/imports/csv",
+			fields: mapping,
+			file:   testStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.Category{food, other}, nil)
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.CategorizationRule{}, nil)
+
+				arg := db.ImportExpensesTxParams{
+					WalletID: wallet.ID,
+					Expenses: []db.CreateImportedExpenseParams{
+						{WalletID: wallet.ID, Amount: 500, ExpenseDescription: "Coffee", CategoryID: food.ID, CreatedAt: day},
+						{WalletID: wallet.ID, Amount: 1250, ExpenseDescription: "Taxi", CategoryID: other.ID, CreatedAt: day.AddDate(0, 0, 1)},
+					},
+				}
+				expenses := []db.Expense{
+					{ID: 1, WalletID: wallet.ID, Amount: 500, ExpenseDescription: "Coffee", CategoryID: food.ID, CreatedAt: day},
+					{ID: 2, WalletID: wallet.ID, Amount: 1250, ExpenseDescription: "Taxi", CategoryID: other.ID, CreatedAt: day.AddDate(0, 0, 1)},
+				}
+				store.EXPECT().
+					ImportExpensesTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ImportExpensesTxResult{Wallet: wallet, Expenses: expenses, Duplicates: []int{}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp importResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, 2, rsp.New)
+				require.Equal(t, 1, rsp.Skipped)
+				require.Len(t, rsp.Expenses, 2)
+				require.NotNil(t, rsp.Wallet)
+			},
+		},
+		{
+			name: "UnknownCategoryWithoutDefault",
+			path: "/imports/csv/preview",
+			fields: map[string]string{
+				"date_column":     "date",
+				"amount_column":   "amount",
+				"category_column": "category",
+			},
+			file: testStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.Category{food}, nil)
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.CategorizationRule{}, nil)
+				store.EXPECT().
+					ListWalletExpensesBetween(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Expense{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp importResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, 1, rsp.New)
+				require.Equal(t, 1, rsp.Invalid)
+				require.Equal(t, importer.StatusInvalid, rsp.Rows[1].Status)
+			},
+		},
+		{
+			name: "NoCategory",
+			path: "/imports/csv",
+			fields: map[string]string{
+				"date_column":   "date",
+				"amount_column": "amount",
+			},
+			file: testStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "MissingFile",
+			path:   "/imports/csv",
+			fields: mapping,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownColumn",
+			path: "/imports/csv",
+			fields: map[string]string{
+				"date_column":         "booked",
+				"amount_column":       "amount",
+				"default_category_id": fmt.Sprint(other.ID),
+			},
+			file: testStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					ImportExpensesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "ForeignDefaultCategory",
+			path:   "/imports/csv",
+			fields: mapping,
+			file:   testStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(RandomCategory("other_user"), nil)
+				store.EXPECT().
+					ImportExpensesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "UnauthorizedUser",
+			path:   "/imports/csv",
+			fields: mapping,
+			file:   testStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					ImportExpensesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "PreviewOFX",
+			path:   "/imports/ofx/preview",
+			fields: map[string]string{"default_category_id": fmt.Sprint(other.ID)},
+			file:   testOFXStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.Category{food, other}, nil)
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.CategorizationRule{}, nil)
+				store.EXPECT().
+					ListWalletExpenseExternalIDs(gomock.Any(), gomock.Eq(db.ListWalletExpenseExternalIDsParams{
+						WalletID:    wallet.ID,
+						ExternalIds: []string{"fit-1", "fit-2"},
+					})).
+					Times(1).
+					Return([]string{"fit-1"}, nil)
+				store.EXPECT().
+					ListWalletExpensesBetween(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp importResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, 1, rsp.New)
+				require.Equal(t, 1, rsp.Duplicates)
+				require.Equal(t, "fit-2", rsp.Rows[1].ExternalID)
+				require.Equal(t, other.ID, rsp.Rows[1].CategoryID)
+			},
+		},
+		{
+			name:   "OFXWithoutDefaultCategory",
+			path:   "/imports/ofx",
+			fields: map[string]string{},
+			file:   testOFXStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "ImportQIF",
+			path:   "/imports/qif",
+			fields: map[string]string{},
+			file:   testQIFStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return([]db.Category{food, other}, nil)
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Any()).
+					Times(0)
+				expense := db.Expense{ID: 1, WalletID: wallet.ID, Amount: 500, ExpenseDescription: "Coffee", CategoryID: food.ID, CreatedAt: day}
+				store.EXPECT().
+					ImportExpensesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.ImportExpensesTxParams) (db.ImportExpensesTxResult, error) {
+						require.Len(t, arg.Expenses, 1)
+						require.Equal(t, food.ID, arg.Expenses[0].CategoryID)
+						require.NotNil(t, arg.Expenses[0].ExternalID)
+						return db.ImportExpensesTxResult{Wallet: wallet, Expenses: []db.Expense{expense}, Duplicates: []int{}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp importResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, 1, rsp.New)
+				require.Len(t, rsp.Expenses, 1)
+			},
+		},
+		{
+			name:   "UnknownFormat",
+			path:   "/imports/xls",
+			fields: mapping,
+			file:   testStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			path:   "/imports/csv",
+			fields: mapping,
+			file:   testStatement,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					GetAllCategories(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Category{food, other}, nil)
+				store.EXPECT().
+					ListCategorizationRules(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.CategorizationRule{}, nil)
+				store.EXPECT().
+					ImportExpensesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ImportExpensesTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, contentType := newImportBody(t, tc.fields, tc.file)
+			url := fmt.Sprintf("/wallets/%d%s", wallet.ID, tc.path)
+			request, err := http.NewRequest(http.MethodPost, url, body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", contentType)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func newImportBody(t *testing.T, fields map[string]string, file string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	if file != "" {
+		part, err := writer.CreateFormFile("file", "statement.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(file))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+type createIncomeRequest struct {
+	WalletID          int64  `json:"wallet_id" binding:"required,min=1"`
+	Amount            int64  `json:"amount" binding:"required,gt=0"`
+	IncomeDescription string `json:"income_description"`
+	CategoryID        int64  `json:"category_id" binding:"required,min=1"`
+}
+
+func (server *Server) createIncome(ctx *gin.Context) {
+	var req createIncomeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	wallet, err := server.store.GetWallet(ctx, req.WalletID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if wallet.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	category, err := server.store.GetCategoryByID(ctx, req.CategoryID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if category.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	arg := db.CreateIncomeParams{
+		WalletID:          req.WalletID,
+		Amount:            req.Amount,
+		IncomeDescription: req.IncomeDescription,
+		CategoryID:        req.CategoryID,
+	}
+	result, err := server.store.CreateIncomeTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Income)
+}
+
+type listIncomesURI struct {
+	WalletID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listIncomes(ctx *gin.Context) {
+	var uri listIncomesURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	pageSize, err := server.pageSize(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	wallet, err := server.store.GetWallet(ctx, uri.WalletID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if wallet.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	arg := db.ListIncomesParams{
+		WalletID:        wallet.ID,
+		CursorID:        cursor.id(),
+		CursorCreatedAt: cursor.createdAt(),
+		Limit:           pageSize + 1,
+	}
+	incomes, err := server.store.ListIncomes(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newListResponse(incomes, pageSize, func(income db.Income) pageCursor {
+		return pageCursor{CreatedAt: income.CreatedAt, ID: income.ID}
+	}))
+}
+
+type getIncomeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getIncome(ctx *gin.Context) {
+	var req getIncomeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	income, err := server.store.GetIncome(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	wallet, err := server.store.GetWallet(ctx, income.WalletID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if wallet.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, income)
+}
+
+type updateIncomeURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateIncomeRequest struct {
+	Amount            *int64  `json:"amount" binding:"omitempty,gt=0"`
+	IncomeDescription *string `json:"income_description"`
+	CategoryID        *int64  `json:"category_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) updateIncome(ctx *gin.Context) {
+	var uri updateIncomeURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateIncomeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	income, err := server.store.GetIncome(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	wallet, err := server.store.GetWallet(ctx, income.WalletID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if wallet.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	arg := db.UpdateIncomeParams{
+		ID: income.ID,
+	}
+	if req.Amount != nil {
+		arg.Amount = sql.NullInt64{Int64: *req.Amount, Valid: true}
+	}
+	if req.IncomeDescription != nil {
+		arg.IncomeDescription = sql.NullString{String: *req.IncomeDescription, Valid: true}
+	}
+	if req.CategoryID != nil {
+		category, err := server.store.GetCategoryByID(ctx, *req.CategoryID)
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if category.Owner != authPayLoad.Username {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+			return
+		}
+		arg.CategoryID = sql.NullInt64{Int64: category.ID, Valid: true}
+	}
+
+	result, err := server.store.UpdateIncomeTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, result.Income)
+}
+
+type deleteIncomeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteIncome(ctx *gin.Context) {
+	var req deleteIncomeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	income, err := server.store.GetIncome(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	wallet, err := server.store.GetWallet(ctx, income.WalletID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if wallet.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	_, err = server.store.DeleteIncomeTx(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, income)
+}
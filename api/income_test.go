@@ -0,0 +1,575 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/token"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func TestCreateIncomeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	income := RandomIncome(wallet.ID, category.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"wallet_id":          income.WalletID,
+				"amount":             income.Amount,
+				"income_description": income.IncomeDescription,
+				"category_id":        income.CategoryID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(income.WalletID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(income.CategoryID)).
+					Times(1).
+					Return(category, nil)
+
+				arg := db.CreateIncomeParams{
+					WalletID:          income.WalletID,
+					Amount:            income.Amount,
+					IncomeDescription: income.IncomeDescription,
+					CategoryID:        income.CategoryID,
+				}
+				store.EXPECT().
+					CreateIncomeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IncomeTxResult{Income: income, Wallet: wallet}, nil)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+				requireBodyMatchIncome(t, recoder.Body, income)
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			body: gin.H{
+				"wallet_id":          income.WalletID,
+				"amount":             income.Amount,
+				"income_description": income.IncomeDescription,
+				"category_id":        income.CategoryID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(income.WalletID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					CreateIncomeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recoder.Code)
+			},
+		},
+		{
+			name: "ForeignCategory",
+			body: gin.H{
+				"wallet_id":          income.WalletID,
+				"amount":             income.Amount,
+				"income_description": income.IncomeDescription,
+				"category_id":        income.CategoryID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(income.WalletID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(income.CategoryID)).
+					Times(1).
+					Return(RandomCategory("other_user"), nil)
+
+				store.EXPECT().
+					CreateIncomeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recoder.Code)
+			},
+		},
+		{
+			name: "InvalidAmount",
+			body: gin.H{
+				"wallet_id":          income.WalletID,
+				"amount":             -income.Amount,
+				"income_description": income.IncomeDescription,
+				"category_id":        income.CategoryID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"wallet_id":          income.WalletID,
+				"amount":             income.Amount,
+				"income_description": income.IncomeDescription,
+				"category_id":        income.CategoryID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(income.WalletID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(income.CategoryID)).
+					Times(1).
+					Return(category, nil)
+
+				store.EXPECT().
+					CreateIncomeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IncomeTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recoder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/wallets/%d/incomes", wallet.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListIncomesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	n := 5
+
+	incomes := make([]db.Income, n)
+	for i := 0; i < n; i++ {
+		incomes[i] = RandomIncome(wallet.ID, category.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		pageSize      int
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			pageSize: n,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.ListIncomesParams{
+					WalletID: wallet.ID,
+					Limit:    int32(n + 1),
+				}
+				store.EXPECT().
+					ListIncomes(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(incomes, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchIncomes(t, recorder.Body, incomes)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			pageSize: n,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					ListIncomes(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidPageSize",
+			pageSize: 100000,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListIncomes(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/wallets/%d/incomes", wallet.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			q := request.URL.Query()
+			q.Add("page_size", fmt.Sprintf("%d", tc.pageSize))
+			request.URL.RawQuery = q.Encode()
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateIncomeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	income := RandomIncome(wallet.ID, category.ID)
+
+	updated := income
+	updated.Amount = income.Amount + 1
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"amount": updated.Amount,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIncome(gomock.Any(), gomock.Eq(income.ID)).
+					Times(1).
+					Return(income, nil)
+
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.UpdateIncomeParams{
+					ID:     income.ID,
+					Amount: sql.NullInt64{Int64: updated.Amount, Valid: true},
+				}
+				store.EXPECT().
+					UpdateIncomeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IncomeTxResult{Income: updated, Wallet: wallet}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchIncome(t, recorder.Body, updated)
+			},
+		},
+		{
+			name: "ForeignCategory",
+			body: gin.H{
+				"category_id": category.ID + 1,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIncome(gomock.Any(), gomock.Eq(income.ID)).
+					Times(1).
+					Return(income, nil)
+
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID+1)).
+					Times(1).
+					Return(RandomCategory("other_user"), nil)
+
+				store.EXPECT().
+					UpdateIncomeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{
+				"amount": updated.Amount,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIncome(gomock.Any(), gomock.Eq(income.ID)).
+					Times(1).
+					Return(db.Income{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/wallets/%d/incomes/%d", wallet.ID, income.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteIncomeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	income := RandomIncome(wallet.ID, RandomCategory(user.Username).ID)
+
+	testCases := []struct {
+		name          string
+		incomeID      int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			incomeID: income.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIncome(gomock.Any(), gomock.Eq(income.ID)).
+					Times(1).
+					Return(income, nil)
+
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					DeleteIncomeTx(gomock.Any(), gomock.Eq(income.ID)).
+					Times(1).
+					Return(db.IncomeTxResult{Income: income, Wallet: wallet}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "Unauthorized",
+			incomeID: income.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIncome(gomock.Any(), gomock.Eq(income.ID)).
+					Times(1).
+					Return(income, nil)
+
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				store.EXPECT().
+					DeleteIncomeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidID",
+			incomeID: 0,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetIncome(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/wallets/%d/incomes/%d", wallet.ID, tc.incomeID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func requireBodyMatchIncomes(t *testing.T, body *bytes.Buffer, incomes []db.Income) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotIncomes listResponse[db.Income]
+	err = json.Unmarshal(data, &gotIncomes)
+	require.NoError(t, err)
+	require.Equal(t, incomes, gotIncomes.Items)
+}
+
+func requireBodyMatchIncome(t *testing.T, body *bytes.Buffer, income db.Income) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotIncome db.Income
+	err = json.Unmarshal(data, &gotIncome)
+	require.NoError(t, err)
+	require.Equal(t, income, gotIncome)
+}
+
+func RandomIncome(walletID int64, categoryID int64) db.Income {
+	return db.Income{
+		ID:                util.RandomInt(1, 1000),
+		WalletID:          walletID,
+		Amount:            util.RandomInt(1, 1000),
+		IncomeDescription: util.RandomString(12),
+		CategoryID:        categoryID,
+	}
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/symyzi/financial-helper/token"
+)
+
+// getJWKS publishes the keys that verify access tokens, so other services can
+// check them without being able to issue any
+func (server *Server) getJWKS(ctx *gin.Context) {
+	provider, ok := server.tokenMaker.(token.PublicKeyProvider)
+	if !ok {
+		err := errors.New("tokens are not signed with a public key")
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, token.JWKS{Keys: provider.PublicKeys()})
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/token"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func TestGetJWKSAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		tokenType     string
+		checkResponse func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "JWTPublic",
+			tokenType: "jwt_public",
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var jwks token.JWKS
+				err := json.Unmarshal(recorder.Body.Bytes(), &jwks)
+				require.NoError(t, err)
+				require.Len(t, jwks.Keys, 1)
+				require.Equal(t, "test-key", jwks.Keys[0].KeyID)
+				require.Equal(t, "EdDSA", jwks.Keys[0].Algorithm)
+
+				// a token of the server verifies with the published key alone
+				accessToken, _, err := server.tokenMaker.CreateToken("someone", util.UserRole, token.TokenTypeAccess, server.config.AccessTokenDuration)
+				require.NoError(t, err)
+				publicKey, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0].X)
+				require.NoError(t, err)
+				_, err = jwt.ParseWithClaims(accessToken, &token.Payload{}, func(*jwt.Token) (interface{}, error) {
+					return ed25519.PublicKey(publicKey), nil
+				})
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:      "PasetoPublic",
+			tokenType: "paseto_public",
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"alg":"v4.public"`)
+			},
+		},
+		{
+			name:      "Symmetric",
+			tokenType: "paseto",
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			require.NoError(t, err)
+			data, err := token.MarshalPrivateKey(privateKey)
+			require.NoError(t, err)
+			keyFile := filepath.Join(t.TempDir(), "token.pem")
+			require.NoError(t, os.WriteFile(keyFile, data, 0o600))
+
+			server := newTestServer(t, nil)
+			server.config.TokenType = tc.tokenType
+			server.config.TokenKeyID = "test-key"
+			if tc.tokenType != "paseto" {
+				server.config.TokenPrivateKeyFile = keyFile
+			}
+			server.tokenMaker, err = newTokenMaker(server.config)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, server, recorder)
+		})
+	}
+}
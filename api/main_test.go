@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
@@ -13,15 +14,28 @@ import (
 
 func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
-		TokenSymmetricKey:   util.RandomString(32),
-		AccessTokenDuration: time.Minute,
+		TokenSymmetricKey:    util.RandomString(32),
+		AccessTokenDuration:  time.Minute,
+		RefreshTokenDuration: time.Hour,
+		DefaultPageSize:      5,
+		MaxPageSize:          100,
+		AttachmentDir:        t.TempDir(),
+		MaxAttachmentSize:    1 << 20,
 	}
 
 	server, err := NewServer(config, store)
 	require.NoError(t, err)
+	server.revocations.store = acceptTokens{}
 	return server
 }
 
+// acceptTokens reports every token as valid so tests only stub the calls they are about
+type acceptTokens struct{}
+
+func (acceptTokens) GetTokenStatus(ctx context.Context, arg db.GetTokenStatusParams) (db.GetTokenStatusRow, error) {
+	return db.GetTokenStatusRow{}, nil
+}
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 
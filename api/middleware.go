@@ -16,7 +16,8 @@ const (
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+// authMiddleware accepts bearer tokens that verify and have not been revoked
+func authMiddleware(tokenMaker token.Maker, revocations *tokenRevocations) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader("authorization")
 		if len(authorizationHeader) == 0 {
@@ -39,13 +40,37 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		}
 
 		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := tokenMaker.VerifyToken(accessToken, token.TokenTypeAccess)
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
 		}
+		if err := revocations.check(ctx, payload); err != nil {
+			if errors.Is(err, ErrRevokedToken) || errors.Is(err, ErrInactiveUser) {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 
 		ctx.Set(authorizationPayloadKey, payload)
 		ctx.Next()
 	}
 }
+
+// requireRoles lets only tokens carrying one of the roles through; it runs
+// after authMiddleware
+func requireRoles(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		for _, role := range roles {
+			if payload.Role == role {
+				ctx.Next()
+				return
+			}
+		}
+		err := fmt.Errorf("role %q is not allowed to access this resource", payload.Role)
+		ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+type pageRequest struct {
+	Cursor   string `form:"cursor"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// pageCursor is the keyset position of the last row of a page.
+// Clients receive it as an opaque base64 string.
+type pageCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int64     `json:"id,omitempty"`
+	Key       string    `json:"key,omitempty"`
+	Amount    int64     `json:"amount,omitempty"`
+	Sort      string    `json:"sort,omitempty"`
+}
+
+func (cursor *pageCursor) id() sql.NullInt64 {
+	if cursor == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: cursor.ID, Valid: true}
+}
+
+// key is the position of tables keyed by a string instead of an ID
+func (cursor *pageCursor) key() sql.NullString {
+	if cursor == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: cursor.Key, Valid: true}
+}
+
+func (cursor *pageCursor) createdAt() time.Time {
+	if cursor == nil {
+		return time.Time{}
+	}
+	return cursor.CreatedAt
+}
+
+func (cursor *pageCursor) amount() int64 {
+	if cursor == nil {
+		return 0
+	}
+	return cursor.Amount
+}
+
+type listResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// pageSize returns the requested page size, or the configured default
+func (server *Server) pageSize(req pageRequest) (int32, error) {
+	if req.PageSize == 0 {
+		return server.config.DefaultPageSize, nil
+	}
+	if req.PageSize > server.config.MaxPageSize {
+		return 0, fmt.Errorf("page_size must not be greater than %d", server.config.MaxPageSize)
+	}
+	return req.PageSize, nil
+}
+
+// decodeCursor returns nil for an empty cursor, meaning the first page
+func decodeCursor(s string) (*pageCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	cursor := &pageCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || (cursor.ID <= 0 && cursor.Key == "") {
+		return nil, errInvalidCursor
+	}
+	return cursor, nil
+}
+
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// newListResponse expects items to be fetched with a limit of pageSize+1,
+// so an extra row means there is a next page starting after the last item
+func newListResponse[T any](items []T, pageSize int32, cursor func(T) pageCursor) listResponse[T] {
+	rsp := listResponse[T]{Items: items}
+	if int32(len(items)) > pageSize {
+		rsp.Items = items[:pageSize]
+		rsp.NextCursor = encodeCursor(cursor(rsp.Items[pageSize-1]))
+	}
+	return rsp
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursor(t *testing.T) {
+	cursor := pageCursor{
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		ID:        42,
+		Amount:    1500,
+		Sort:      "amount_desc",
+	}
+
+	decoded, err := decodeCursor(encodeCursor(cursor))
+	require.NoError(t, err)
+	require.Equal(t, cursor, *decoded)
+
+	decoded, err = decodeCursor("")
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+
+	_, err = decodeCursor("e30")
+	require.ErrorIs(t, err, errInvalidCursor)
+
+	_, err = decodeCursor("%%%")
+	require.ErrorIs(t, err, errInvalidCursor)
+}
+
+func TestNewListResponse(t *testing.T) {
+	items := []int64{1, 2, 3}
+	cursor := func(id int64) pageCursor {
+		return pageCursor{ID: id}
+	}
+
+	rsp := newListResponse(items, 3, cursor)
+	require.Equal(t, items, rsp.Items)
+	require.Empty(t, rsp.NextCursor)
+
+	rsp = newListResponse(items, 2, cursor)
+	require.Equal(t, items[:2], rsp.Items)
+	require.Equal(t, encodeCursor(pageCursor{ID: 2}), rsp.NextCursor)
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+type createRecurringExpenseRequest struct {
+	WalletID           int64  `json:"wallet_id" binding:"required,min=1"`
+	CategoryID         int64  `json:"category_id" binding:"required,min=1"`
+	Amount             int64  `json:"amount" binding:"required,gt=0"`
+	ExpenseDescription string `json:"expense_description"`
+	Frequency          string `json:"frequency" binding:"required,oneof=daily weekly monthly yearly"`
+	Interval           int32  `json:"interval" binding:"omitempty,min=1"`
+	LastDayOfMonth     bool   `json:"last_day_of_month"`
+	StartDate          string `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate            string `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	MaxOccurrences     *int32 `json:"max_occurrences" binding:"omitempty,min=1"`
+}
+
+func (req createRecurringExpenseRequest) params() (db.CreateRecurringExpenseParams, error) {
+	arg := db.CreateRecurringExpenseParams{
+		WalletID:           req.WalletID,
+		CategoryID:         req.CategoryID,
+		Amount:             req.Amount,
+		ExpenseDescription: req.ExpenseDescription,
+		Frequency:          req.Frequency,
+		Interval:           req.Interval,
+		LastDayOfMonth:     req.LastDayOfMonth,
+		MaxOccurrences:     req.MaxOccurrences,
+	}
+	if arg.Interval == 0 {
+		arg.Interval = 1
+	}
+
+	startDate, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return arg, err
+	}
+	arg.StartDate = startDate
+
+	if req.EndDate != "" {
+		endDate, err := time.Parse(dateLayout, req.EndDate)
+		if err != nil {
+			return arg, err
+		}
+		if endDate.Before(startDate) {
+			return arg, errors.New("end_date must not be before start_date")
+		}
+		arg.EndDate = &endDate
+	}
+	return arg, nil
+}
+
+func (server *Server) createRecurringExpense(ctx *gin.Context) {
+	var req createRecurringExpenseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg, err := req.params()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validWallet(ctx, req.WalletID, authPayLoad.Username); !valid {
+		return
+	}
+
+	category, err := server.store.GetCategoryByID(ctx, req.CategoryID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	if category.Owner != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	recurringExpense, err := server.store.CreateRecurringExpense(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, recurringExpense)
+}
+
+type listRecurringExpensesURI struct {
+	WalletID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listRecurringExpenses(ctx *gin.Context) {
+	var uri listRecurringExpensesURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	pageSize, err := server.pageSize(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validWallet(ctx, uri.WalletID, authPayLoad.Username); !valid {
+		return
+	}
+
+	arg := db.ListRecurringExpensesParams{
+		WalletID:        uri.WalletID,
+		CursorID:        cursor.id(),
+		CursorCreatedAt: cursor.createdAt(),
+		Limit:           pageSize + 1,
+	}
+	recurringExpenses, err := server.store.ListRecurringExpenses(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newListResponse(recurringExpenses, pageSize, func(recurringExpense db.RecurringExpense) pageCursor {
+		return pageCursor{CreatedAt: recurringExpense.CreatedAt, ID: recurringExpense.ID}
+	}))
+}
+
+type recurringExpenseRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// ownRecurringExpense loads the recurring expense from the URI and checks that
+// its wallet belongs to the caller, writing an error response when it does not
+func (server *Server) ownRecurringExpense(ctx *gin.Context) (db.RecurringExpense, bool) {
+	var req recurringExpenseRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.RecurringExpense{}, false
+	}
+
+	recurringExpense, err := server.store.GetRecurringExpense(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return recurringExpense, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return recurringExpense, false
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validWallet(ctx, recurringExpense.WalletID, authPayLoad.Username); !valid {
+		return recurringExpense, false
+	}
+	return recurringExpense, true
+}
+
+func (server *Server) getRecurringExpense(ctx *gin.Context) {
+	recurringExpense, valid := server.ownRecurringExpense(ctx)
+	if !valid {
+		return
+	}
+	ctx.JSON(http.StatusOK, recurringExpense)
+}
+
+func (server *Server) deleteRecurringExpense(ctx *gin.Context) {
+	recurringExpense, valid := server.ownRecurringExpense(ctx)
+	if !valid {
+		return
+	}
+
+	err := server.store.DeleteRecurringExpense(ctx, recurringExpense.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, recurringExpense)
+}
@@ -0,0 +1,491 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/token"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func TestCreateRecurringExpenseAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	recurringExpense := RandomRecurringExpense(wallet.ID, category.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"wallet_id":           wallet.ID,
+				"category_id":         category.ID,
+				"amount":              recurringExpense.Amount,
+				"expense_description": recurringExpense.ExpenseDescription,
+				"frequency":           util.FrequencyMonthly,
+				"last_day_of_month":   true,
+				"start_date":          "2024-01-31",
+				"max_occurrences":     12,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+
+				maxOccurrences := int32(12)
+				arg := db.CreateRecurringExpenseParams{
+					WalletID:           wallet.ID,
+					CategoryID:         category.ID,
+					Amount:             recurringExpense.Amount,
+					ExpenseDescription: recurringExpense.ExpenseDescription,
+					Frequency:          util.FrequencyMonthly,
+					Interval:           1,
+					LastDayOfMonth:     true,
+					StartDate:          time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+					MaxOccurrences:     &maxOccurrences,
+				}
+				store.EXPECT().
+					CreateRecurringExpense(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recurringExpense, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchRecurringExpense(t, recorder.Body, recurringExpense)
+			},
+		},
+		{
+			name: "UnauthorizedCategory",
+			body: gin.H{
+				"wallet_id":   wallet.ID,
+				"category_id": category.ID,
+				"amount":      recurringExpense.Amount,
+				"frequency":   util.FrequencyWeekly,
+				"start_date":  "2024-01-01",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(RandomCategory("other_user"), nil)
+				store.EXPECT().
+					CreateRecurringExpense(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedWallet",
+			body: gin.H{
+				"wallet_id":   wallet.ID,
+				"category_id": category.ID,
+				"amount":      recurringExpense.Amount,
+				"frequency":   util.FrequencyWeekly,
+				"start_date":  "2024-01-01",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					CreateRecurringExpense(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "EndDateBeforeStartDate",
+			body: gin.H{
+				"wallet_id":   wallet.ID,
+				"category_id": category.ID,
+				"amount":      recurringExpense.Amount,
+				"frequency":   util.FrequencyDaily,
+				"start_date":  "2024-01-10",
+				"end_date":    "2024-01-01",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRecurringExpense(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidFrequency",
+			body: gin.H{
+				"wallet_id":   wallet.ID,
+				"category_id": category.ID,
+				"amount":      recurringExpense.Amount,
+				"frequency":   "hourly",
+				"start_date":  "2024-01-10",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRecurringExpense(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"wallet_id":   wallet.ID,
+				"category_id": category.ID,
+				"amount":      recurringExpense.Amount,
+				"frequency":   util.FrequencyYearly,
+				"start_date":  "2024-01-10",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(category.ID)).
+					Times(1).
+					Return(category, nil)
+				store.EXPECT().
+					CreateRecurringExpense(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RecurringExpense{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/wallets/%d/recurring-expenses", wallet.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListRecurringExpensesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+
+	n := 3
+	recurringExpenses := make([]db.RecurringExpense, n)
+	for i := range recurringExpenses {
+		recurringExpenses[i] = RandomRecurringExpense(wallet.ID, category.ID)
+	}
+
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.ListRecurringExpensesParams{
+					WalletID: wallet.ID,
+					Limit:    6,
+				}
+				store.EXPECT().
+					ListRecurringExpenses(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(recurringExpenses, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp listResponse[db.RecurringExpense]
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, recurringExpenses, rsp.Items)
+				require.Empty(t, rsp.NextCursor)
+			},
+		},
+		{
+			name: "Unauthorized",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					ListRecurringExpenses(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					ListRecurringExpenses(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/wallets/%d/recurring-expenses", wallet.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteRecurringExpenseAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+	recurringExpense := RandomRecurringExpense(wallet.ID, category.ID)
+
+	testCases := []struct {
+		name               string
+		recurringExpenseID int64
+		setupAuth          func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs         func(store *mockdb.MockStore)
+		checkResponse      func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:               "OK",
+			recurringExpenseID: recurringExpense.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRecurringExpense(gomock.Any(), gomock.Eq(recurringExpense.ID)).
+					Times(1).
+					Return(recurringExpense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					DeleteRecurringExpense(gomock.Any(), gomock.Eq(recurringExpense.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchRecurringExpense(t, recorder.Body, recurringExpense)
+			},
+		},
+		{
+			name:               "NotFound",
+			recurringExpenseID: recurringExpense.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRecurringExpense(gomock.Any(), gomock.Eq(recurringExpense.ID)).
+					Times(1).
+					Return(db.RecurringExpense{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteRecurringExpense(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:               "Unauthorized",
+			recurringExpenseID: recurringExpense.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRecurringExpense(gomock.Any(), gomock.Eq(recurringExpense.ID)).
+					Times(1).
+					Return(recurringExpense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					DeleteRecurringExpense(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:               "InvalidID",
+			recurringExpenseID: 0,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRecurringExpense(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/wallets/%d/recurring-expenses/%d", wallet.ID, tc.recurringExpenseID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func RandomRecurringExpense(walletID int64, categoryID int64) db.RecurringExpense {
+	startDate := util.Day(time.Now())
+	return db.RecurringExpense{
+		ID:                 util.RandomInt(1, 1000),
+		WalletID:           walletID,
+		CategoryID:         categoryID,
+		Amount:             util.RandomInt(1, 1000),
+		ExpenseDescription: util.RandomString(12),
+		Frequency:          util.FrequencyMonthly,
+		Interval:           1,
+		StartDate:          startDate,
+		NextOccurrence:     startDate,
+		Active:             true,
+	}
+}
+
+func requireBodyMatchRecurringExpense(t *testing.T, body *bytes.Buffer, recurringExpense db.RecurringExpense) {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotRecurringExpense db.RecurringExpense
+	err = json.Unmarshal(data, &gotRecurringExpense)
+	require.NoError(t, err)
+	require.Equal(t, recurringExpense, gotRecurringExpense)
+}
@@ -0,0 +1,279 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+	"github.com/symyzi/financial-helper/util"
+)
+
+const monthLayout = "2006-01"
+
+type monthlyReportRequest struct {
+	FromMonth string  `form:"from" binding:"omitempty,datetime=2006-01"`
+	ToMonth   string  `form:"to" binding:"omitempty,datetime=2006-01"`
+	WalletIDs []int64 `form:"wallet_id" binding:"omitempty,dive,min=1"`
+	TagIDs    []int64 `form:"tag_id" binding:"omitempty,dive,min=1"`
+}
+
+// params resolves the month range, defaulting to the twelve months up to and
+// including the current one. Both ends of the range are inclusive.
+func (req monthlyReportRequest) params(owner string, now time.Time) (db.GetMonthlyCategoryReportParams, error) {
+	arg := db.GetMonthlyCategoryReportParams{
+		Owner:     owner,
+		WalletIds: req.WalletIDs,
+		TagIds:    req.TagIDs,
+	}
+
+	now = now.UTC()
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if req.ToMonth != "" {
+		month, err := time.Parse(monthLayout, req.ToMonth)
+		if err != nil {
+			return arg, err
+		}
+		to = month
+	}
+	from := to.AddDate(0, -11, 0)
+	if req.FromMonth != "" {
+		month, err := time.Parse(monthLayout, req.FromMonth)
+		if err != nil {
+			return arg, err
+		}
+		from = month
+	}
+	if to.Before(from) {
+		return arg, errors.New("to must not be before from")
+	}
+
+	arg.FromTime = from
+	arg.ToTime = to.AddDate(0, 1, 0)
+	return arg, nil
+}
+
+type monthlyReportRow struct {
+	Month          string  `json:"month"`
+	Currency       string  `json:"currency"`
+	CategoryID     int64   `json:"category_id"`
+	CategoryName   string  `json:"category_name"`
+	Count          int64   `json:"count"`
+	Total          int64   `json:"total"`
+	Average        float64 `json:"average"`
+	MonthTotal     int64   `json:"month_total"`
+	PercentOfTotal float64 `json:"percent_of_total"`
+}
+
+type monthlyReportResponse struct {
+	From string             `json:"from"`
+	To   string             `json:"to"`
+	Rows []monthlyReportRow `json:"rows"`
+}
+
+func newMonthlyReportResponse(arg db.GetMonthlyCategoryReportParams, rows []db.GetMonthlyCategoryReportRow) monthlyReportResponse {
+	rsp := monthlyReportResponse{
+		From: arg.FromTime.Format(monthLayout),
+		To:   arg.ToTime.AddDate(0, -1, 0).Format(monthLayout),
+		Rows: make([]monthlyReportRow, len(rows)),
+	}
+	for i, row := range rows {
+		rsp.Rows[i] = monthlyReportRow{
+			Month:          row.Month.Format(monthLayout),
+			Currency:       row.Currency,
+			CategoryID:     row.CategoryID,
+			CategoryName:   row.CategoryName,
+			Count:          row.ExpenseCount,
+			Total:          row.Total,
+			Average:        row.Average,
+			MonthTotal:     row.MonthTotal,
+			PercentOfTotal: row.PercentOfTotal,
+		}
+	}
+	return rsp
+}
+
+func (server *Server) getMonthlyReport(ctx *gin.Context) {
+	var req monthlyReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg, err := req.params(authPayLoad.Username, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	for _, walletID := range req.WalletIDs {
+		if _, valid := server.validWallet(ctx, walletID, authPayLoad.Username); !valid {
+			return
+		}
+	}
+
+	rows, err := server.store.GetMonthlyCategoryReport(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newMonthlyReportResponse(arg, rows))
+}
+
+// maxTimeSeriesBuckets bounds the number of buckets a single request may span
+const maxTimeSeriesBuckets = 1000
+
+type timeSeriesRequest struct {
+	Bucket    string  `form:"bucket" binding:"omitempty,oneof=day week month year"`
+	FromDate  string  `form:"from_date" binding:"omitempty,datetime=2006-01-02"`
+	ToDate    string  `form:"to_date" binding:"omitempty,datetime=2006-01-02"`
+	GroupBy   string  `form:"group_by" binding:"omitempty,oneof=category wallet"`
+	WalletIDs []int64 `form:"wallet_id" binding:"omitempty,dive,min=1"`
+	TagIDs    []int64 `form:"tag_id" binding:"omitempty,dive,min=1"`
+}
+
+// params resolves the range, defaulting to monthly buckets over the last twelve
+// months. Both dates are inclusive.
+func (req timeSeriesRequest) params(owner string, now time.Time) (db.GetTimeSeriesParams, error) {
+	arg := db.GetTimeSeriesParams{
+		Bucket:    req.Bucket,
+		GroupBy:   req.GroupBy,
+		Owner:     owner,
+		WalletIds: req.WalletIDs,
+		TagIds:    req.TagIDs,
+	}
+	if arg.Bucket == "" {
+		arg.Bucket = util.BucketMonth
+	}
+
+	to := util.Day(now)
+	if req.ToDate != "" {
+		date, err := time.Parse(dateLayout, req.ToDate)
+		if err != nil {
+			return arg, err
+		}
+		to = date
+	}
+	from := util.AddBuckets(arg.Bucket, util.BucketStart(arg.Bucket, to), -11)
+	if req.FromDate != "" {
+		date, err := time.Parse(dateLayout, req.FromDate)
+		if err != nil {
+			return arg, err
+		}
+		from = date
+	}
+	if to.Before(from) {
+		return arg, errors.New("to_date must not be before from_date")
+	}
+
+	arg.FromTime = from
+	arg.ToTime = to.AddDate(0, 0, 1)
+	return arg, nil
+}
+
+type timeSeries struct {
+	GroupID  int64   `json:"group_id,omitempty"`
+	Currency string  `json:"currency"`
+	Expenses []int64 `json:"expenses"`
+	Incomes  []int64 `json:"incomes"`
+}
+
+type timeSeriesResponse struct {
+	Bucket  string       `json:"bucket"`
+	GroupBy string       `json:"group_by,omitempty"`
+	Buckets []string     `json:"buckets"`
+	Series  []timeSeries `json:"series"`
+}
+
+// timeSeriesBuckets lists the start of every bucket overlapping [from, to)
+func timeSeriesBuckets(bucket string, from, to time.Time) ([]time.Time, error) {
+	var buckets []time.Time
+	for start := util.BucketStart(bucket, from); start.Before(to); start = util.AddBuckets(bucket, start, 1) {
+		if len(buckets) == maxTimeSeriesBuckets {
+			return nil, fmt.Errorf("range spans more than %d buckets", maxTimeSeriesBuckets)
+		}
+		buckets = append(buckets, start)
+	}
+	return buckets, nil
+}
+
+// newTimeSeriesResponse lays the rows out as one series per group and currency,
+// with a zero for every bucket the group has no entries in
+func newTimeSeriesResponse(arg db.GetTimeSeriesParams, buckets []time.Time, rows []db.GetTimeSeriesRow) timeSeriesResponse {
+	rsp := timeSeriesResponse{
+		Bucket:  arg.Bucket,
+		GroupBy: arg.GroupBy,
+		Buckets: make([]string, len(buckets)),
+		Series:  []timeSeries{},
+	}
+	index := make(map[int64]int, len(buckets))
+	for i, start := range buckets {
+		rsp.Buckets[i] = start.Format(dateLayout)
+		index[start.Unix()] = i
+	}
+
+	type seriesKey struct {
+		groupID  int64
+		currency string
+	}
+	series := map[seriesKey]int{}
+	for _, row := range rows {
+		key := seriesKey{groupID: row.GroupID, currency: row.Currency}
+		n, ok := series[key]
+		if !ok {
+			n = len(rsp.Series)
+			series[key] = n
+			rsp.Series = append(rsp.Series, timeSeries{
+				GroupID:  row.GroupID,
+				Currency: row.Currency,
+				Expenses: make([]int64, len(buckets)),
+				Incomes:  make([]int64, len(buckets)),
+			})
+		}
+
+		i, ok := index[util.BucketStart(arg.Bucket, row.Bucket).Unix()]
+		if !ok {
+			continue
+		}
+		rsp.Series[n].Expenses[i] += row.Expenses
+		rsp.Series[n].Incomes[i] += row.Incomes
+	}
+	return rsp
+}
+
+func (server *Server) getTimeSeries(ctx *gin.Context) {
+	var req timeSeriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg, err := req.params(authPayLoad.Username, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	buckets, err := timeSeriesBuckets(arg.Bucket, arg.FromTime, arg.ToTime)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	for _, walletID := range req.WalletIDs {
+		if _, valid := server.validWallet(ctx, walletID, authPayLoad.Username); !valid {
+			return
+		}
+	}
+
+	rows, err := server.store.GetTimeSeries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newTimeSeriesResponse(arg, buckets, rows))
+}
@@ -0,0 +1,421 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/token"
+)
+
+func TestGetMonthlyReportAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+
+	rows := []db.GetMonthlyCategoryReportRow{
+		{
+			Month:          time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Currency:       wallet.Currency,
+			CategoryID:     category.ID,
+			CategoryName:   category.Name,
+			ExpenseCount:   2,
+			Total:          300,
+			Average:        150,
+			MonthTotal:     300,
+			PercentOfTotal: 100,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: url.Values{
+				"from":      {"2024-01"},
+				"to":        {"2024-03"},
+				"wallet_id": {fmt.Sprint(wallet.ID)},
+				"tag_id":    {"4"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.GetMonthlyCategoryReportParams{
+					Owner:     user.Username,
+					WalletIds: []int64{wallet.ID},
+					TagIds:    []int64{4},
+					FromTime:  time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+					ToTime:    time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+				}
+				store.EXPECT().
+					GetMonthlyCategoryReport(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp monthlyReportResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, "2024-01", rsp.From)
+				require.Equal(t, "2024-03", rsp.To)
+				require.Len(t, rsp.Rows, 1)
+				require.Equal(t, "2024-01", rsp.Rows[0].Month)
+				require.Equal(t, category.ID, rsp.Rows[0].CategoryID)
+				require.Equal(t, int64(2), rsp.Rows[0].Count)
+				require.Equal(t, int64(300), rsp.Rows[0].Total)
+				require.Equal(t, float64(100), rsp.Rows[0].PercentOfTotal)
+			},
+		},
+		{
+			name:  "DefaultRange",
+			query: url.Values{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonthlyCategoryReport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.GetMonthlyCategoryReportRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp monthlyReportResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+
+				from, err := time.Parse(monthLayout, rsp.From)
+				require.NoError(t, err)
+				to, err := time.Parse(monthLayout, rsp.To)
+				require.NoError(t, err)
+				require.Equal(t, to.AddDate(0, -11, 0), from)
+				require.Empty(t, rsp.Rows)
+			},
+		},
+		{
+			name: "ToBeforeFrom",
+			query: url.Values{
+				"from": {"2024-03"},
+				"to":   {"2024-01"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonthlyCategoryReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidMonth",
+			query: url.Values{
+				"from": {"2024-13"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonthlyCategoryReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedWallet",
+			query: url.Values{
+				"wallet_id": {fmt.Sprint(wallet.ID)},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetMonthlyCategoryReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "NoAuthorization",
+			query: url.Values{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonthlyCategoryReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMonthlyCategoryReport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/reports/monthly?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetTimeSeriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	category := RandomCategory(user.Username)
+
+	rows := []db.GetTimeSeriesRow{
+		{
+			Bucket:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			GroupID:  category.ID,
+			Currency: wallet.Currency,
+			Expenses: 100,
+		},
+		{
+			Bucket:   time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			GroupID:  category.ID,
+			Currency: wallet.Currency,
+			Expenses: 50,
+			Incomes:  200,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: url.Values{
+				"bucket":    {"month"},
+				"from_date": {"2024-01-01"},
+				"to_date":   {"2024-03-31"},
+				"group_by":  {"category"},
+				"wallet_id": {fmt.Sprint(wallet.ID)},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.GetTimeSeriesParams{
+					Bucket:    "month",
+					GroupBy:   "category",
+					Owner:     user.Username,
+					WalletIds: []int64{wallet.ID},
+					FromTime:  time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+					ToTime:    time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+				}
+				store.EXPECT().
+					GetTimeSeries(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp timeSeriesResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, []string{"2024-01-01", "2024-02-01", "2024-03-01"}, rsp.Buckets)
+				require.Len(t, rsp.Series, 1)
+				require.Equal(t, category.ID, rsp.Series[0].GroupID)
+				require.Equal(t, []int64{100, 0, 50}, rsp.Series[0].Expenses)
+				require.Equal(t, []int64{0, 0, 200}, rsp.Series[0].Incomes)
+			},
+		},
+		{
+			name: "EmptyRange",
+			query: url.Values{
+				"bucket":    {"week"},
+				"from_date": {"2024-01-03"},
+				"to_date":   {"2024-01-10"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimeSeries(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.GetTimeSeriesRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp timeSeriesResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, []string{"2024-01-01", "2024-01-08"}, rsp.Buckets)
+				require.Empty(t, rsp.Series)
+			},
+		},
+		{
+			name: "TooManyBuckets",
+			query: url.Values{
+				"bucket":    {"day"},
+				"from_date": {"2000-01-01"},
+				"to_date":   {"2024-01-01"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimeSeries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidGroupBy",
+			query: url.Values{
+				"group_by": {"description"},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimeSeries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnauthorizedWallet",
+			query: url.Values{
+				"wallet_id": {fmt.Sprint(wallet.ID)},
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetTimeSeries(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTimeSeries(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/reports/timeseries?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+// defaultRevocationCacheTTL is how long a token is trusted before its status is
+// looked up again
+const defaultRevocationCacheTTL = 30 * time.Second
+
+var (
+	ErrRevokedToken = errors.New("token has been revoked")
+	ErrInactiveUser = errors.New("token user does not exist or is disabled")
+)
+
+// revocationStore is the subset of db.Store used to look up token status
+type revocationStore interface {
+	GetTokenStatus(ctx context.Context, arg db.GetTokenStatusParams) (db.GetTokenStatusRow, error)
+}
+
+type tokenStatus struct {
+	revoked    bool
+	validAfter time.Time
+	checkedAt  time.Time
+	expiresAt  time.Time
+}
+
+// tokenRevocations answers whether a verified token is still accepted. Results
+// are cached per token: revocations are kept until the token expires, accepted
+// tokens are looked up again after ttl. Revocations made by this process take
+// effect immediately, the ones made by other instances within ttl.
+type tokenRevocations struct {
+	store revocationStore
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu        sync.Mutex
+	tokens    map[uuid.UUID]tokenStatus
+	cutoffs   map[string]time.Time
+	lastPrune time.Time
+}
+
+func newTokenRevocations(store revocationStore, ttl time.Duration) *tokenRevocations {
+	if ttl <= 0 {
+		ttl = defaultRevocationCacheTTL
+	}
+	return &tokenRevocations{
+		store:   store,
+		ttl:     ttl,
+		now:     time.Now,
+		tokens:  make(map[uuid.UUID]tokenStatus),
+		cutoffs: make(map[string]time.Time),
+	}
+}
+
+// check returns an error when the token was revoked or issued before the
+// user's password change or last logout from all sessions
+func (revocations *tokenRevocations) check(ctx context.Context, payload *token.Payload) error {
+	now := revocations.now()
+
+	revocations.mu.Lock()
+	status, found := revocations.tokens[payload.ID]
+	cutoff := revocations.cutoffs[payload.Username]
+	revocations.mu.Unlock()
+
+	if !found || (!status.revoked && now.Sub(status.checkedAt) > revocations.ttl) {
+		row, err := revocations.store.GetTokenStatus(ctx, db.GetTokenStatusParams{
+			ID:       payload.ID,
+			Username: payload.Username,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInactiveUser
+			}
+			return err
+		}
+		status = tokenStatus{
+			revoked:    row.Revoked,
+			validAfter: row.ValidAfter,
+			checkedAt:  now,
+			expiresAt:  payload.ExpiredAt,
+		}
+		revocations.remember(payload.ID, status, now)
+	}
+
+	if status.revoked {
+		return ErrRevokedToken
+	}
+	if status.validAfter.After(cutoff) {
+		cutoff = status.validAfter
+	}
+	if payload.IssuedAt.Before(cutoff) {
+		return ErrRevokedToken
+	}
+	return nil
+}
+
+// revokeToken marks a token revoked in this process; the caller persists it
+func (revocations *tokenRevocations) revokeToken(payload *token.Payload) {
+	now := revocations.now()
+	revocations.remember(payload.ID, tokenStatus{revoked: true, checkedAt: now, expiresAt: payload.ExpiredAt}, now)
+}
+
+// revokeUser rejects the user's tokens issued before at in this process
+func (revocations *tokenRevocations) revokeUser(username string, at time.Time) {
+	revocations.mu.Lock()
+	defer revocations.mu.Unlock()
+	if at.After(revocations.cutoffs[username]) {
+		revocations.cutoffs[username] = at
+	}
+}
+
+func (revocations *tokenRevocations) remember(id uuid.UUID, status tokenStatus, now time.Time) {
+	revocations.mu.Lock()
+	defer revocations.mu.Unlock()
+
+	revocations.tokens[id] = status
+	if now.Sub(revocations.lastPrune) < revocations.ttl {
+		return
+	}
+	revocations.lastPrune = now
+	for id, status := range revocations.tokens {
+		if now.After(status.expiresAt) || (!status.revoked && now.Sub(status.checkedAt) > revocations.ttl) {
+			delete(revocations.tokens, id)
+		}
+	}
+}
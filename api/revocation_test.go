@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/token"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func TestAuthMiddlewareRevocation(t *testing.T) {
+	username := util.RandomUsername()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTokenStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetTokenStatusRow{ValidAfter: time.Now().Add(-time.Hour)}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Revoked",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTokenStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetTokenStatusRow{Revoked: true}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "IssuedBeforePasswordChange",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTokenStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetTokenStatusRow{ValidAfter: time.Now().Add(time.Second)}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownUser",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTokenStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetTokenStatusRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTokenStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetTokenStatusRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.revocations.store = store
+			authPath := "/auth"
+			server.router.GET(
+				authPath,
+				authMiddleware(server.tokenMaker, server.revocations),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, authPath, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestTokenRevocationsCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	payload := &token.Payload{
+		ID:        uuid.New(),
+		Username:  util.RandomUsername(),
+		IssuedAt:  now,
+		ExpiredAt: now.Add(time.Hour),
+	}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetTokenStatus(gomock.Any(), gomock.Eq(db.GetTokenStatusParams{ID: payload.ID, Username: payload.Username})).
+		Times(2).
+		Return(db.GetTokenStatusRow{}, nil)
+
+	revocations := newTokenRevocations(store, time.Minute)
+	revocations.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// the second check is answered from the cache
+	require.NoError(t, revocations.check(ctx, payload))
+	require.NoError(t, revocations.check(ctx, payload))
+
+	// an accepted token is looked up again once the entry is stale
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, revocations.check(ctx, payload))
+
+	// local revocations apply without a lookup
+	revocations.revokeUser(payload.Username, payload.IssuedAt.Add(time.Second))
+	require.ErrorIs(t, revocations.check(ctx, payload), ErrRevokedToken)
+
+	other := &token.Payload{ID: uuid.New(), Username: payload.Username, IssuedAt: now, ExpiredAt: now.Add(time.Hour)}
+	revocations.revokeToken(other)
+	require.ErrorIs(t, revocations.check(ctx, other), ErrRevokedToken)
+}
@@ -5,26 +5,35 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/storage"
 	"github.com/symyzi/financial-helper/token"
 	"github.com/symyzi/financial-helper/util"
 )
 
 type Server struct {
-	config     util.Config
-	store      db.Store
-	tokenMaker token.Maker
-	router     *gin.Engine
+	config      util.Config
+	store       db.Store
+	tokenMaker  token.Maker
+	revocations *tokenRevocations
+	blobs       storage.BlobStore
+	router      *gin.Engine
 }
 
 func NewServer(config util.Config, store db.Store) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+	tokenMaker, err := newTokenMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
+	blobs, err := storage.NewLocalStore(config.AttachmentDir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create blob store: %w", err)
+	}
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:      config,
+		store:       store,
+		tokenMaker:  tokenMaker,
+		revocations: newTokenRevocations(store, config.RevocationCacheTTL),
+		blobs:       blobs,
 	}
 	server.setupRouter()
 	return server, nil
@@ -34,30 +43,98 @@ func (server *Server) setupRouter() {
 	router := gin.Default()
 	router.POST("/users", server.createUser)
 	router.POST("/users/login", server.loginUser)
+	router.POST("/tokens/renew_access", server.renewAccessToken)
+	router.GET("/.well-known/jwks.json", server.getJWKS)
 
 	authRoutes := router.Group("/")
-	authRoutes.Use(authMiddleware(server.tokenMaker))
+	authRoutes.Use(authMiddleware(server.tokenMaker, server.revocations))
+
+	authRoutes.POST("/users/logout", server.logoutUser)
+	authRoutes.POST("/users/logout_all", server.logoutAllSessions)
+
+	authRoutes.GET("/sessions", server.listSessions)
+	authRoutes.DELETE("/sessions/:id", server.revokeSession)
 
 	authRoutes.POST("/wallets", server.createWallet)
 	authRoutes.GET("/wallets", server.listWallets)
 	authRoutes.GET("/wallets/:id", server.getWallet)
+	authRoutes.PATCH("/wallets/:id", server.updateWallet)
 	authRoutes.DELETE("/wallets/:id", server.deleteWallet)
 
 	authRoutes.POST("/categories", server.createCategory)
 	authRoutes.GET("/categories/:id", server.getCategory)
 	authRoutes.GET("/categories", server.listCategories)
+	authRoutes.PATCH("/categories/:id", server.updateCategory)
 	authRoutes.DELETE("/categories/:id", server.deleteCategory)
 
+	authRoutes.POST("/tags", server.createTag)
+	authRoutes.GET("/tags", server.listTags)
+	authRoutes.PATCH("/tags/:id", server.updateTag)
+	authRoutes.DELETE("/tags/:id", server.deleteTag)
+
+	authRoutes.POST("/categorization-rules", server.createCategorizationRule)
+	authRoutes.GET("/categorization-rules", server.listCategorizationRules)
+	authRoutes.DELETE("/categorization-rules/:id", server.deleteCategorizationRule)
+	authRoutes.POST("/categorization-rules/apply", server.applyCategorizationRules)
+
+	authRoutes.POST("/transfers", server.createTransfer)
+
+	authRoutes.GET("/reports/monthly", server.getMonthlyReport)
+	authRoutes.GET("/reports/timeseries", server.getTimeSeries)
+
+	authRoutes.GET("/exports/:format", server.exportTransactions)
+
+	authRoutes.GET("/backup", server.createBackup)
+	authRoutes.POST("/backup/restore", server.restoreBackup)
+
+	adminRoutes := authRoutes.Group("/admin")
+	adminRoutes.Use(requireRoles(util.AdminRole))
+
+	adminRoutes.GET("/users", server.listUsers)
+	adminRoutes.POST("/users/:username/disable", server.disableUser)
+	adminRoutes.POST("/users/:username/enable", server.enableUser)
+	adminRoutes.GET("/stats", server.getSystemStats)
+
 	walletRoutes := authRoutes.Group("/wallets/:id")
 
 	walletRoutes.POST("/expenses", server.createExpense)
 	walletRoutes.GET("/expenses", server.listExpenses)
 	walletRoutes.GET("/expenses/:id", server.getExpense)
+	walletRoutes.PATCH("/expenses/:id", server.updateExpense)
 	walletRoutes.DELETE("/expenses/:id", server.deleteExpense)
+	walletRoutes.GET("/expenses/:id/splits", server.listExpenseSplits)
+	walletRoutes.PUT("/expenses/:id/splits", server.setExpenseSplits)
+	walletRoutes.GET("/expenses/:id/tags", server.listExpenseTags)
+	walletRoutes.PUT("/expenses/:id/tags/:tag_id", server.attachExpenseTag)
+	walletRoutes.DELETE("/expenses/:id/tags/:tag_id", server.detachExpenseTag)
+	walletRoutes.POST("/expenses/:id/attachments", server.uploadAttachment)
+	walletRoutes.GET("/expenses/:id/attachments", server.listAttachments)
+	walletRoutes.GET("/expenses/:id/attachments/:attachment_id", server.downloadAttachment)
+	walletRoutes.DELETE("/expenses/:id/attachments/:attachment_id", server.deleteAttachment)
+
+	walletRoutes.POST("/incomes", server.createIncome)
+	walletRoutes.GET("/incomes", server.listIncomes)
+	walletRoutes.GET("/incomes/:id", server.getIncome)
+	walletRoutes.PATCH("/incomes/:id", server.updateIncome)
+	walletRoutes.DELETE("/incomes/:id", server.deleteIncome)
+
+	walletRoutes.GET("/transfers", server.listTransfers)
+
+	walletRoutes.POST("/imports/:format/preview", server.previewImport)
+	walletRoutes.POST("/imports/:format", server.importStatement)
+	walletRoutes.GET("/exports/:format", server.exportTransactions)
+
+	walletRoutes.POST("/recurring-expenses", server.createRecurringExpense)
+	walletRoutes.GET("/recurring-expenses", server.listRecurringExpenses)
+	walletRoutes.GET("/recurring-expenses/:id", server.getRecurringExpense)
+	walletRoutes.DELETE("/recurring-expenses/:id", server.deleteRecurringExpense)
 
 	walletRoutes.POST("/budgets", server.createBudget)
 	walletRoutes.GET("/budgets", server.listBudgets)
+	walletRoutes.GET("/budgets/progress", server.listBudgetProgress)
 	walletRoutes.GET("/budgets/:id", server.getBudget)
+	walletRoutes.GET("/budgets/:id/progress", server.getBudgetProgress)
+	walletRoutes.PATCH("/budgets/:id", server.updateBudget)
 	walletRoutes.DELETE("/budgets/:id", server.deleteBudget)
 
 	server.router = router
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+type renewAccessTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type renewAccessTokenResponse struct {
+	AccessToken          string    `json:"access_token"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+}
+
+// renewAccessToken issues a new access token for the session of a refresh token
+func (server *Server) renewAccessToken(ctx *gin.Context) {
+	var req renewAccessTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.RefreshToken, token.TokenTypeRefresh)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	if !server.checkRevocation(ctx, refreshPayload) {
+		return
+	}
+
+	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if session.IsBlocked {
+		err := errors.New("session is revoked")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	if session.Username != refreshPayload.Username {
+		err := errors.New("incorrect session user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	if session.RefreshToken != req.RefreshToken {
+		err := errors.New("mismatched session token")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	if time.Now().After(session.ExpiresAt) {
+		err := errors.New("expired session")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	// role changes revoke the refresh tokens, so the role they carry is current
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+		refreshPayload.Username,
+		refreshPayload.Role,
+		token.TokenTypeAccess,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := renewAccessTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// sessionResponse leaves out the refresh token of a session
+type sessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIP  string    `json:"client_ip"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newSessionResponse(session db.Session) sessionResponse {
+	return sessionResponse{
+		ID:        session.ID,
+		UserAgent: session.UserAgent,
+		ClientIP:  session.ClientIp,
+		ExpiresAt: session.ExpiresAt,
+		CreatedAt: session.CreatedAt,
+	}
+}
+
+// listSessions returns the sessions of the user that are neither revoked nor expired
+func (server *Server) listSessions(ctx *gin.Context) {
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	sessions, err := server.store.ListActiveSessions(ctx, authPayLoad.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]sessionResponse, len(sessions))
+	for i, session := range sessions {
+		rsp[i] = newSessionResponse(session)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type sessionURI struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// revokeSession blocks a session and revokes its refresh token, so it can no
+// longer be renewed
+func (server *Server) revokeSession(ctx *gin.Context) {
+	var uri sessionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	id, err := uuid.Parse(uri.ID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid session id: %w", err)))
+		return
+	}
+
+	session, err := server.store.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if session.Username != authPayLoad.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return
+	}
+
+	// the session ID is the ID of its refresh token
+	refreshPayload := &token.Payload{ID: session.ID, Username: session.Username, ExpiredAt: session.ExpiresAt}
+	err = server.store.LogoutTx(ctx, db.LogoutTxParams{
+		SessionID: session.ID,
+		Tokens: []db.RevokeTokenParams{
+			{ID: refreshPayload.ID, Username: refreshPayload.Username, ExpiresAt: refreshPayload.ExpiredAt},
+		},
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.revocations.revokeToken(refreshPayload)
+	ctx.JSON(http.StatusOK, newSessionResponse(session))
+}
+
+// checkRevocation rejects a revoked token, writing the error response itself
+func (server *Server) checkRevocation(ctx *gin.Context, payload *token.Payload) bool {
+	err := server.revocations.check(ctx, payload)
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, ErrRevokedToken) || errors.Is(err, ErrInactiveUser) {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return false
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	return false
+}
+
+type logoutUserRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// logoutUser ends the session of a refresh token and revokes it together with
+// the access token of the request
+func (server *Server) logoutUser(ctx *gin.Context) {
+	var req logoutUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.RefreshToken, token.TokenTypeRefresh)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	if refreshPayload.Username != authPayLoad.Username {
+		err := errors.New("refresh token belongs to another user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if session.RefreshToken != req.RefreshToken {
+		err := errors.New("mismatched session token")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	err = server.store.LogoutTx(ctx, db.LogoutTxParams{
+		SessionID: session.ID,
+		Tokens: []db.RevokeTokenParams{
+			{ID: authPayLoad.ID, Username: authPayLoad.Username, ExpiresAt: authPayLoad.ExpiredAt},
+			{ID: refreshPayload.ID, Username: refreshPayload.Username, ExpiresAt: refreshPayload.ExpiredAt},
+		},
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.revocations.revokeToken(authPayLoad)
+	server.revocations.revokeToken(refreshPayload)
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// logoutAllSessions blocks every session of the user and rejects all access
+// and refresh tokens issued until now, including the one of the request
+func (server *Server) logoutAllSessions(ctx *gin.Context) {
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	revokedAt, err := server.store.LogoutAllTx(ctx, authPayLoad.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.revocations.revokeUser(authPayLoad.Username, revokedAt)
+	ctx.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+type tagRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+}
+
+func (server *Server) createTag(ctx *gin.Context) {
+	var req tagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	tag, err := server.store.CreateTag(ctx, db.CreateTagParams{
+		Owner: authPayLoad.Username,
+		Name:  req.Name,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, tag)
+}
+
+func (server *Server) listTags(ctx *gin.Context) {
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	tags, err := server.store.ListTags(ctx, authPayLoad.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, tags)
+}
+
+type tagURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// ownedTag loads a tag and checks that it belongs to owner, writing the error
+// response itself
+func (server *Server) ownedTag(ctx *gin.Context, id int64, owner string) (db.Tag, bool) {
+	tag, err := server.store.GetTag(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return tag, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return tag, false
+	}
+	if tag.Owner != owner {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return tag, false
+	}
+	return tag, true
+}
+
+func (server *Server) updateTag(ctx *gin.Context) {
+	var uri tagURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req tagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.ownedTag(ctx, uri.ID, authPayLoad.Username); !valid {
+		return
+	}
+
+	tag, err := server.store.UpdateTag(ctx, db.UpdateTagParams{
+		ID:   uri.ID,
+		Name: req.Name,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, tag)
+}
+
+// deleteTag removes the tag from all expenses it was attached to
+func (server *Server) deleteTag(ctx *gin.Context) {
+	var uri tagURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.ownedTag(ctx, uri.ID, authPayLoad.Username); !valid {
+		return
+	}
+
+	if err := server.store.DeleteTag(ctx, uri.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+type expenseTagURI struct {
+	ExpenseID int64 `uri:"id" binding:"required,min=1"`
+	TagID     int64 `uri:"tag_id" binding:"required,min=1"`
+}
+
+func (server *Server) listExpenseTags(ctx *gin.Context) {
+	var uri expenseURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.ownedExpense(ctx, uri.ID, authPayLoad.Username); !valid {
+		return
+	}
+
+	tags, err := server.store.ListExpenseTags(ctx, uri.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, tags)
+}
+
+// attachExpenseTag is idempotent, attaching a tag twice is not an error
+func (server *Server) attachExpenseTag(ctx *gin.Context) {
+	var uri expenseTagURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.ownedExpense(ctx, uri.ExpenseID, authPayLoad.Username); !valid {
+		return
+	}
+	tag, valid := server.ownedTag(ctx, uri.TagID, authPayLoad.Username)
+	if !valid {
+		return
+	}
+
+	err := server.store.AddExpenseTag(ctx, db.AddExpenseTagParams{
+		ExpenseID: uri.ExpenseID,
+		TagID:     uri.TagID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, tag)
+}
+
+func (server *Server) detachExpenseTag(ctx *gin.Context) {
+	var uri expenseTagURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.ownedExpense(ctx, uri.ExpenseID, authPayLoad.Username); !valid {
+		return
+	}
+
+	rows, err := server.store.RemoveExpenseTag(ctx, db.RemoveExpenseTagParams{
+		ExpenseID: uri.ExpenseID,
+		TagID:     uri.TagID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("tag is not attached to the expense")))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{})
+}
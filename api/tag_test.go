@@ -0,0 +1,385 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func RandomTag(owner string) db.Tag {
+	return db.Tag{
+		ID:    util.RandomInt(1, 1000),
+		Owner: owner,
+		Name:  util.RandomString(8),
+	}
+}
+
+func TestCreateTagAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	tag := RandomTag(user.Username)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": tag.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateTagParams{Owner: user.Username, Name: tag.Name}
+				store.EXPECT().
+					CreateTag(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(tag, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Tag
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, tag, got)
+			},
+		},
+		{
+			name: "MissingName",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateName",
+			body: gin.H{"name": tag.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTag(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Tag{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"name": tag.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateTag(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Tag{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/tags", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateTagAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	tag := RandomTag(user.Username)
+	renamed := tag
+	renamed.Name = util.RandomString(8)
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTag(gomock.Any(), gomock.Eq(tag.ID)).
+					Times(1).
+					Return(tag, nil)
+				store.EXPECT().
+					UpdateTag(gomock.Any(), gomock.Eq(db.UpdateTagParams{ID: tag.ID, Name: renamed.Name})).
+					Times(1).
+					Return(renamed, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: "other_user",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTag(gomock.Any(), gomock.Eq(tag.ID)).
+					Times(1).
+					Return(tag, nil)
+				store.EXPECT().
+					UpdateTag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetTag(gomock.Any(), gomock.Eq(tag.ID)).
+					Times(1).
+					Return(db.Tag{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdateTag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"name": renamed.Name})
+			require.NoError(t, err)
+			url := fmt.Sprintf("/tags/%d", tag.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteTagAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	tag := RandomTag(user.Username)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetTag(gomock.Any(), gomock.Eq(tag.ID)).
+		Times(1).
+		Return(tag, nil)
+	store.EXPECT().
+		DeleteTag(gomock.Any(), gomock.Eq(tag.ID)).
+		Times(1).
+		Return(nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/tags/%d", tag.ID), nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestExpenseTagsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+	expense := RandomExpense(wallet.ID, util.RandomInt(1, 1000))
+	tag := RandomTag(user.Username)
+
+	testCases := []struct {
+		name          string
+		method        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Attach",
+			method: http.MethodPut,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetTag(gomock.Any(), gomock.Eq(tag.ID)).
+					Times(1).
+					Return(tag, nil)
+				store.EXPECT().
+					AddExpenseTag(gomock.Any(), gomock.Eq(db.AddExpenseTagParams{ExpenseID: expense.ID, TagID: tag.ID})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "AttachForeignTag",
+			method: http.MethodPut,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					GetTag(gomock.Any(), gomock.Eq(tag.ID)).
+					Times(1).
+					Return(RandomTag("other_user"), nil)
+				store.EXPECT().
+					AddExpenseTag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:   "Detach",
+			method: http.MethodDelete,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					RemoveExpenseTag(gomock.Any(), gomock.Eq(db.RemoveExpenseTagParams{ExpenseID: expense.ID, TagID: tag.ID})).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "DetachNotAttached",
+			method: http.MethodDelete,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					RemoveExpenseTag(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "ForeignExpense",
+			method: http.MethodDelete,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExpense(gomock.Any(), gomock.Eq(expense.ID)).
+					Times(1).
+					Return(expense, nil)
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(RandomWallet("other_user"), nil)
+				store.EXPECT().
+					RemoveExpenseTag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/wallets/%d/expenses/%d/tags/%d", wallet.ID, expense.ID, tag.ID)
+			request, err := http.NewRequest(tc.method, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/symyzi/financial-helper/token"
+	"github.com/symyzi/financial-helper/util"
+)
+
+// newTokenMaker creates the maker for TOKEN_TYPE from the token key ring: paseto
+// (v2.local) and jwt (HS256) use the key secrets, paseto_public (v4.public) and
+// jwt_public (EdDSA or RS256) the private keys
+func newTokenMaker(config util.Config) (token.Maker, error) {
+	ring, err := config.TokenKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	public := config.TokenType == "paseto_public" || config.TokenType == "jwt_public"
+	keys := make([]token.Key, len(ring.Keys))
+	for i, key := range ring.Keys {
+		keys[i] = token.Key{ID: key.ID}
+		if key.ExpiresAt != nil {
+			keys[i].ExpiresAt = *key.ExpiresAt
+		}
+		if !public {
+			keys[i].Secret = []byte(key.Secret)
+			continue
+		}
+		keys[i].Signer, err = token.ParsePrivateKey([]byte(key.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key %q: %w", key.ID, err)
+		}
+	}
+
+	switch config.TokenType {
+	case "", "paseto":
+		return token.NewPasetoKeyRingMaker(ring.Active, keys)
+	case "jwt":
+		return token.NewJWTKeyRingMaker(ring.Active, keys)
+	case "paseto_public":
+		return token.NewPasetoPublicKeyRingMaker(ring.Active, keys)
+	case "jwt_public":
+		return token.NewJWTPublicKeyRingMaker(ring.Active, keys)
+	default:
+		return nil, fmt.Errorf("unsupported token type %s", config.TokenType)
+	}
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/token"
+)
+
+type transferRequest struct {
+	FromWalletID int64 `json:"from_wallet_id" binding:"required,min=1"`
+	ToWalletID   int64 `json:"to_wallet_id" binding:"required,min=1,nefield=FromWalletID"`
+	Amount       int64 `json:"amount" binding:"required,gt=0"`
+}
+
+func (server *Server) createTransfer(ctx *gin.Context) {
+	var req transferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	fromWallet, valid := server.validWallet(ctx, req.FromWalletID, authPayLoad.Username)
+	if !valid {
+		return
+	}
+
+	toWallet, valid := server.validWallet(ctx, req.ToWalletID, authPayLoad.Username)
+	if !valid {
+		return
+	}
+
+	if fromWallet.Currency != toWallet.Currency {
+		err := fmt.Errorf("wallet [%d] currency mismatch: %s vs %s", toWallet.ID, toWallet.Currency, fromWallet.Currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.TransferTxParams{
+		FromWalletID: req.FromWalletID,
+		ToWalletID:   req.ToWalletID,
+		Amount:       req.Amount,
+	}
+
+	result, err := server.store.TransferTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// validWallet loads the wallet and checks that it belongs to owner,
+// writing an error response when it does not
+func (server *Server) validWallet(ctx *gin.Context, walletID int64, owner string) (db.Wallet, bool) {
+	wallet, err := server.store.GetWallet(ctx, walletID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return wallet, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return wallet, false
+	}
+
+	if wallet.Owner != owner {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("unauthorized")))
+		return wallet, false
+	}
+
+	return wallet, true
+}
+
+type listTransfersURI struct {
+	WalletID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listTransfers(ctx *gin.Context) {
+	var uri listTransfersURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req pageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	pageSize, err := server.pageSize(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	wallet, valid := server.validWallet(ctx, uri.WalletID, authPayLoad.Username)
+	if !valid {
+		return
+	}
+
+	arg := db.ListTransfersParams{
+		WalletID:        wallet.ID,
+		CursorID:        cursor.id(),
+		CursorCreatedAt: cursor.createdAt(),
+		Limit:           pageSize + 1,
+	}
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newListResponse(transfers, pageSize, func(transfer db.Transfer) pageCursor {
+		return pageCursor{CreatedAt: transfer.CreatedAt, ID: transfer.ID}
+	}))
+}
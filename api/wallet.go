@@ -12,8 +12,9 @@ import (
 )
 
 type createWalletRequest struct {
-	Name     string `json:"name" binding:"required"`
-	Currency string `json:"currency" binding:"required,oneof=RUB USD EUR"`
+	Name           string `json:"name" binding:"required"`
+	Currency       string `json:"currency" binding:"required,oneof=RUB USD EUR"`
+	OpeningBalance int64  `json:"opening_balance"`
 }
 
 func (server *Server) createWallet(ctx *gin.Context) {
@@ -28,6 +29,7 @@ func (server *Server) createWallet(ctx *gin.Context) {
 		Owner:    authPayLoad.Username,
 		Name:     req.Name,
 		Currency: req.Currency,
+		Balance:  req.OpeningBalance,
 	}
 
 	wallet, err := server.store.CreateWallet(ctx, arg)
@@ -81,31 +83,39 @@ func (server *Server) getWallet(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, wallet)
 }
 
-type listWalletsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
-}
-
 func (server *Server) listWallets(ctx *gin.Context) {
-	var req listWalletsRequest
+	var req pageRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	pageSize, err := server.pageSize(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
 	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
 	arg := db.ListWalletsParams{
-		Owner:  authPayLoad.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Owner:           authPayLoad.Username,
+		CursorID:        cursor.id(),
+		CursorCreatedAt: cursor.createdAt(),
+		Limit:           pageSize + 1,
 	}
 	wallets, err := server.store.ListWallets(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, wallets)
+	ctx.JSON(http.StatusOK, newListResponse(wallets, pageSize, func(wallet db.Wallet) pageCursor {
+		return pageCursor{CreatedAt: wallet.CreatedAt, ID: wallet.ID}
+	}))
 }
 
 type deleteWalletRequest struct {
@@ -148,3 +158,43 @@ func (server *Server) deleteWallet(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, nil)
 }
+
+type updateWalletURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateWalletRequest struct {
+	Name *string `json:"name" binding:"omitempty,min=1"`
+}
+
+func (server *Server) updateWallet(ctx *gin.Context) {
+	var uri updateWalletURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateWalletRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayLoad := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, valid := server.validWallet(ctx, uri.ID, authPayLoad.Username); !valid {
+		return
+	}
+
+	arg := db.UpdateWalletParams{
+		ID: uri.ID,
+	}
+	if req.Name != nil {
+		arg.Name = sql.NullString{String: *req.Name, Valid: true}
+	}
+
+	wallet, err := server.store.UpdateWallet(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, wallet)
+}
@@ -302,14 +302,15 @@ func TestListWalletsAPI(t *testing.T) {
 	user, _ := randomUser(t)
 	n := 5
 
-	wallets := make([]db.Wallet, n)
+	wallets := make([]db.Wallet, n+1)
 
-	for i := 0; i < n; i++ {
+	for i := range wallets {
 		wallets[i] = RandomWallet(user.Username)
 	}
+	cursor := pageCursor{CreatedAt: wallets[n-1].CreatedAt, ID: wallets[n-1].ID}
 
 	type Query struct {
-		pageID   int
+		cursor   string
 		pageSize int
 	}
 
@@ -323,7 +324,6 @@ func TestListWalletsAPI(t *testing.T) {
 		{
 			name: "OK",
 			query: Query{
-				pageID:   1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -331,9 +331,35 @@ func TestListWalletsAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				arg := db.ListWalletsParams{
-					Owner:  user.Username,
-					Limit:  int32(n),
-					Offset: 0,
+					Owner: user.Username,
+					Limit: int32(n + 1),
+				}
+
+				store.EXPECT().
+					ListWallets(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(wallets[:n], nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccounts(t, recorder.Body, wallets[:n], "")
+			},
+		},
+		{
+			name: "NextPage",
+			query: Query{
+				cursor:   encodeCursor(cursor),
+				pageSize: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListWalletsParams{
+					Owner:           user.Username,
+					CursorID:        sql.NullInt64{Int64: cursor.ID, Valid: true},
+					CursorCreatedAt: cursor.CreatedAt,
+					Limit:           int32(n + 1),
 				}
 
 				store.EXPECT().
@@ -343,13 +369,12 @@ func TestListWalletsAPI(t *testing.T) {
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchAccounts(t, recorder.Body, wallets)
+				requireBodyMatchAccounts(t, recorder.Body, wallets[:n], encodeCursor(cursor))
 			},
 		},
 		{
 			name: "NoAuthorization",
 			query: Query{
-				pageID:   1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -366,7 +391,6 @@ func TestListWalletsAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			query: Query{
-				pageID:   1,
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -384,9 +408,9 @@ func TestListWalletsAPI(t *testing.T) {
 			},
 		},
 		{
-			name: "InvalidPageID",
+			name: "InvalidCursor",
 			query: Query{
-				pageID:   -1,
+				cursor:   "not-a-cursor",
 				pageSize: n,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -404,7 +428,6 @@ func TestListWalletsAPI(t *testing.T) {
 		{
 			name: "InvalidPageSize",
 			query: Query{
-				pageID:   1,
 				pageSize: 100000,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -439,7 +462,9 @@ func TestListWalletsAPI(t *testing.T) {
 			require.NoError(t, err)
 
 			q := request.URL.Query()
-			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
+			if tc.query.cursor != "" {
+				q.Add("cursor", tc.query.cursor)
+			}
 			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
 			request.URL.RawQuery = q.Encode()
 
@@ -584,14 +609,144 @@ func TestDeleteWalletAPI(t *testing.T) {
 	}
 }
 
-func requireBodyMatchAccounts(t *testing.T, body *bytes.Buffer, accounts []db.Wallet) {
+func TestUpdateWalletAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	wallet := RandomWallet(user.Username)
+
+	updated := wallet
+	updated.Name = util.RandomString(6)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name": updated.Name,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+
+				arg := db.UpdateWalletParams{
+					ID:   wallet.ID,
+					Name: sql.NullString{String: updated.Name, Valid: true},
+				}
+				store.EXPECT().
+					UpdateWallet(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccount(t, recorder.Body, updated)
+			},
+		},
+		{
+			name: "EmptyName",
+			body: gin.H{
+				"name": "",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Unauthorized",
+			body: gin.H{
+				"name": updated.Name,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(wallet, nil)
+				store.EXPECT().
+					UpdateWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{
+				"name": updated.Name,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWallet(gomock.Any(), gomock.Eq(wallet.ID)).
+					Times(1).
+					Return(db.Wallet{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdateWallet(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/wallets/%d", wallet.ID)
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func requireBodyMatchAccounts(t *testing.T, body *bytes.Buffer, accounts []db.Wallet, nextCursor string) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotWallets []db.Wallet
+	var gotWallets listResponse[db.Wallet]
 	err = json.Unmarshal(data, &gotWallets)
 	require.NoError(t, err)
-	require.Equal(t, accounts, gotWallets)
+	require.Equal(t, accounts, gotWallets.Items)
+	require.Equal(t, nextCursor, gotWallets.NextCursor)
 }
 
 func requireBodyMatchAccount(t *testing.T, body *bytes.Buffer, account db.Wallet) {
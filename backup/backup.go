@@ -0,0 +1,229 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	db "github.com/symyzi/financial-helper/db/gen"
+)
+
+// Version is the archive schema version written by Create. Version 2 added
+// expense splits, version 3 tags.
+const Version = 3
+
+// MinVersion is the oldest archive version accepted by Read; older versions
+// simply lack the rows added since
+const MinVersion = 1
+
+var ErrUnsupportedVersion = errors.New("unsupported backup version")
+
+// Profile is the part of the user record kept in an archive; passwords are never exported
+type Profile struct {
+	Username  string    `json:"username"`
+	FullName  string    `json:"full_name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Archive is a complete, self-contained copy of a user's data
+type Archive struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Profile   Profile   `json:"profile"`
+	db.OwnerData
+}
+
+// Store is the subset of db.Store used to back up and restore a user
+type Store interface {
+	GetUser(ctx context.Context, username string) (db.User, error)
+	BackupTx(ctx context.Context, owner string) (db.OwnerData, error)
+	RestoreTx(ctx context.Context, arg db.RestoreTxParams) (db.RestoreTxResult, error)
+}
+
+// Create builds an archive of everything the user owns
+func Create(ctx context.Context, store Store, username string) (Archive, error) {
+	user, err := store.GetUser(ctx, username)
+	if err != nil {
+		return Archive{}, err
+	}
+	data, err := store.BackupTx(ctx, username)
+	if err != nil {
+		return Archive{}, fmt.Errorf("cannot read user data: %w", err)
+	}
+
+	return Archive{
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Profile: Profile{
+			Username:  user.Username,
+			FullName:  user.FullName,
+			Email:     user.Email,
+			CreatedAt: user.CreatedAt,
+		},
+		OwnerData: data,
+	}, nil
+}
+
+// Write encodes the archive as JSON
+func Write(w io.Writer, archive Archive) error {
+	return json.NewEncoder(w).Encode(archive)
+}
+
+// Read decodes and validates an archive. The version is checked before the
+// rest of the document, so archives from newer releases are rejected cleanly.
+func Read(r io.Reader) (Archive, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return Archive{}, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	if err := checkVersion(header.Version); err != nil {
+		return Archive{}, err
+	}
+
+	var archive Archive
+	if err := json.Unmarshal(raw, &archive); err != nil {
+		return Archive{}, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	if err := archive.Validate(); err != nil {
+		return Archive{}, err
+	}
+	return archive, nil
+}
+
+func checkVersion(version int) error {
+	if version < MinVersion || version > Version {
+		return fmt.Errorf("%w %d, expected %d to %d", ErrUnsupportedVersion, version, MinVersion, Version)
+	}
+	return nil
+}
+
+// Validate checks the version and that every row refers to a wallet, category,
+// expense or tag of the archive
+func (archive Archive) Validate() error {
+	if err := checkVersion(archive.Version); err != nil {
+		return err
+	}
+
+	wallets := make(map[int64]bool, len(archive.Wallets))
+	for _, wallet := range archive.Wallets {
+		if wallets[wallet.ID] {
+			return fmt.Errorf("duplicate wallet %d", wallet.ID)
+		}
+		if wallet.Name == "" || wallet.Currency == "" {
+			return fmt.Errorf("wallet %d has no name or currency", wallet.ID)
+		}
+		wallets[wallet.ID] = true
+	}
+	categories := make(map[int64]bool, len(archive.Categories))
+	names := make(map[string]bool, len(archive.Categories))
+	for _, category := range archive.Categories {
+		if categories[category.ID] {
+			return fmt.Errorf("duplicate category %d", category.ID)
+		}
+		if category.Name == "" {
+			return fmt.Errorf("category %d has no name", category.ID)
+		}
+		if names[category.Name] {
+			return fmt.Errorf("duplicate category name %q", category.Name)
+		}
+		categories[category.ID] = true
+		names[category.Name] = true
+	}
+
+	check := func(kind string, id, walletID, categoryID int64) error {
+		if !wallets[walletID] {
+			return fmt.Errorf("%s %d references unknown wallet %d", kind, id, walletID)
+		}
+		if !categories[categoryID] {
+			return fmt.Errorf("%s %d references unknown category %d", kind, id, categoryID)
+		}
+		return nil
+	}
+	expenses := make(map[int64]int64, len(archive.Expenses))
+	for _, expense := range archive.Expenses {
+		if err := check("expense", expense.ID, expense.WalletID, expense.CategoryID); err != nil {
+			return err
+		}
+		expenses[expense.ID] = expense.Amount
+	}
+	splitTotals := make(map[int64]int64)
+	for _, split := range archive.ExpenseSplits {
+		if _, ok := expenses[split.ExpenseID]; !ok {
+			return fmt.Errorf("expense split %d references unknown expense %d", split.ID, split.ExpenseID)
+		}
+		if !categories[split.CategoryID] {
+			return fmt.Errorf("expense split %d references unknown category %d", split.ID, split.CategoryID)
+		}
+		splitTotals[split.ExpenseID] += split.Amount
+	}
+	for expenseID, total := range splitTotals {
+		if total != expenses[expenseID] {
+			return fmt.Errorf("splits of expense %d add up to %d instead of %d", expenseID, total, expenses[expenseID])
+		}
+	}
+	tags := make(map[int64]bool, len(archive.Tags))
+	tagNames := make(map[string]bool, len(archive.Tags))
+	for _, tag := range archive.Tags {
+		if tags[tag.ID] {
+			return fmt.Errorf("duplicate tag %d", tag.ID)
+		}
+		if tag.Name == "" {
+			return fmt.Errorf("tag %d has no name", tag.ID)
+		}
+		if tagNames[tag.Name] {
+			return fmt.Errorf("duplicate tag name %q", tag.Name)
+		}
+		tags[tag.ID] = true
+		tagNames[tag.Name] = true
+	}
+	for _, expenseTag := range archive.ExpenseTags {
+		if _, ok := expenses[expenseTag.ExpenseID]; !ok {
+			return fmt.Errorf("expense tag references unknown expense %d", expenseTag.ExpenseID)
+		}
+		if !tags[expenseTag.TagID] {
+			return fmt.Errorf("expense tag references unknown tag %d", expenseTag.TagID)
+		}
+	}
+	for _, income := range archive.Incomes {
+		if err := check("income", income.ID, income.WalletID, income.CategoryID); err != nil {
+			return err
+		}
+	}
+	for _, budget := range archive.Budgets {
+		if err := check("budget", budget.ID, budget.WalletID, budget.CategoryID); err != nil {
+			return err
+		}
+	}
+	for _, transfer := range archive.Transfers {
+		if !wallets[transfer.FromWalletID] || !wallets[transfer.ToWalletID] {
+			return fmt.Errorf("transfer %d references unknown wallet", transfer.ID)
+		}
+	}
+	return nil
+}
+
+// Restore recreates the archive under the given user in a single transaction.
+// The user must already exist; its profile is left as it is.
+func Restore(ctx context.Context, store Store, archive Archive, username string) (db.RestoreTxResult, error) {
+	if err := archive.Validate(); err != nil {
+		return db.RestoreTxResult{}, err
+	}
+	if _, err := store.GetUser(ctx, username); err != nil {
+		return db.RestoreTxResult{}, err
+	}
+	return store.RestoreTx(ctx, db.RestoreTxParams{
+		Owner: username,
+		Data:  archive.OwnerData,
+	})
+}
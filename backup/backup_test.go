@@ -0,0 +1,195 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+)
+
+func testData() db.OwnerData {
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	return db.OwnerData{
+		Wallets: []db.Wallet{
+			{ID: 1, Owner: "alice", Name: "Cash", Currency: "USD", Balance: 9500, CreatedAt: day},
+			{ID: 2, Owner: "alice", Name: "Card", Currency: "USD", Balance: 500, CreatedAt: day},
+		},
+		Categories: []db.Category{{ID: 3, Owner: "alice", Name: "Food", CreatedAt: day}},
+		Expenses:   []db.Expense{{ID: 4, WalletID: 1, CategoryID: 3, Amount: 500, ExpenseDescription: "Coffee", CreatedAt: day}},
+		ExpenseSplits: []db.ExpenseSplit{
+			{ID: 8, ExpenseID: 4, CategoryID: 3, Amount: 300, CreatedAt: day},
+			{ID: 9, ExpenseID: 4, CategoryID: 3, Amount: 200, CreatedAt: day},
+		},
+		Tags:        []db.Tag{{ID: 10, Owner: "alice", Name: "vacation", CreatedAt: day}},
+		ExpenseTags: []db.ExpenseTag{{ExpenseID: 4, TagID: 10, CreatedAt: day}},
+		Incomes:     []db.Income{{ID: 5, WalletID: 1, CategoryID: 3, Amount: 10500, IncomeDescription: "Refund", CreatedAt: day}},
+		Budgets:     []db.Budget{{ID: 6, WalletID: 1, CategoryID: 3, Amount: 1000, Period: "monthly", StartDate: day, Recurring: true, CreatedAt: day}},
+		Transfers:   []db.Transfer{{ID: 7, FromWalletID: 1, ToWalletID: 2, Amount: 500, CreatedAt: day}},
+	}
+}
+
+func TestCreateAndRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := db.User{Username: "alice", FullName: "Alice", Email: "alice@example.com", HashedPassword: "secret-hash"}
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().BackupTx(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(testData(), nil)
+
+	archive, err := Create(context.Background(), store, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, Version, archive.Version)
+	require.Equal(t, user.Email, archive.Profile.Email)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, archive))
+	require.NotContains(t, buf.String(), user.HashedPassword)
+
+	read, err := Read(&buf)
+	require.NoError(t, err)
+	require.Equal(t, testData(), read.OwnerData)
+	require.Equal(t, archive.Profile, read.Profile)
+}
+
+func TestReadVersion1(t *testing.T) {
+	data := testData()
+	data.ExpenseSplits = nil
+	data.Tags = nil
+	data.ExpenseTags = nil
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, Archive{Version: 1, OwnerData: data}))
+
+	// version 1 archives have no expense splits or tags
+	raw := buf.String()
+	for _, field := range []string{"expense_splits", "tags", "expense_tags"} {
+		raw = strings.Replace(raw, fmt.Sprintf(`"%s":null,`, field), "", 1)
+	}
+	require.NotContains(t, raw, "expense_splits")
+
+	read, err := Read(strings.NewReader(raw))
+	require.NoError(t, err)
+	require.Equal(t, 1, read.Version)
+	require.Equal(t, data, read.OwnerData)
+}
+
+func TestReadUnsupportedVersion(t *testing.T) {
+	_, err := Read(strings.NewReader(fmt.Sprintf(`{"version": %d, "wallets": "changed"}`, Version+1)))
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = Read(strings.NewReader(`{"wallets": []}`))
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = Read(strings.NewReader(`not json`))
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(data *db.OwnerData)
+		err    string
+	}{
+		{
+			name:   "OK",
+			modify: func(data *db.OwnerData) {},
+		},
+		{
+			name:   "DuplicateWallet",
+			modify: func(data *db.OwnerData) { data.Wallets[1].ID = 1 },
+			err:    "duplicate wallet 1",
+		},
+		{
+			name: "DuplicateCategoryName",
+			modify: func(data *db.OwnerData) {
+				data.Categories = append(data.Categories, db.Category{ID: 8, Name: "Food"})
+			},
+			err: `duplicate category name "Food"`,
+		},
+		{
+			name:   "ExpenseWallet",
+			modify: func(data *db.OwnerData) { data.Expenses[0].WalletID = 9 },
+			err:    "expense 4 references unknown wallet 9",
+		},
+		{
+			name:   "SplitExpense",
+			modify: func(data *db.OwnerData) { data.ExpenseSplits[0].ExpenseID = 9 },
+			err:    "expense split 8 references unknown expense 9",
+		},
+		{
+			name:   "SplitTotal",
+			modify: func(data *db.OwnerData) { data.ExpenseSplits[1].Amount = 100 },
+			err:    "splits of expense 4 add up to 400 instead of 500",
+		},
+		{
+			name:   "ExpenseTag",
+			modify: func(data *db.OwnerData) { data.ExpenseTags[0].TagID = 9 },
+			err:    "expense tag references unknown tag 9",
+		},
+		{
+			name:   "BudgetCategory",
+			modify: func(data *db.OwnerData) { data.Budgets[0].CategoryID = 9 },
+			err:    "budget 6 references unknown category 9",
+		},
+		{
+			name:   "TransferWallet",
+			modify: func(data *db.OwnerData) { data.Transfers[0].ToWalletID = 9 },
+			err:    "transfer 7 references unknown wallet",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			archive := Archive{Version: Version, OwnerData: testData()}
+			tc.modify(&archive.OwnerData)
+
+			err := archive.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	archive := Archive{Version: Version, OwnerData: testData()}
+	result := db.RestoreTxResult{WalletIDs: map[int64]int64{1: 11, 2: 12}, Expenses: 1}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq("bob")).Times(1).Return(db.User{Username: "bob"}, nil)
+	store.EXPECT().
+		RestoreTx(gomock.Any(), gomock.Eq(db.RestoreTxParams{Owner: "bob", Data: archive.OwnerData})).
+		Times(1).
+		Return(result, nil)
+
+	restored, err := Restore(context.Background(), store, archive, "bob")
+	require.NoError(t, err)
+	require.Equal(t, result, restored)
+}
+
+func TestRestoreUnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrNoRows)
+	store.EXPECT().RestoreTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := Restore(context.Background(), store, Archive{Version: Version, OwnerData: testData()}, "nobody")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,224 @@
+package categorizer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	db "github.com/symyzi/financial-helper/db/gen"
+)
+
+// Rule match types
+const (
+	MatchSubstring = "substring"
+	MatchRegex     = "regex"
+)
+
+// UncategorizedName is the category that expenses no rule matches end up in
+const UncategorizedName = "Uncategorized"
+
+// Store is the subset of db.Store the categorizer reads rules from
+type Store interface {
+	ListCategorizationRules(ctx context.Context, owner string) ([]db.CategorizationRule, error)
+	GetOrCreateCategory(ctx context.Context, arg db.GetOrCreateCategoryParams) (db.Category, error)
+}
+
+// Expense holds the fields a rule is matched against
+type Expense struct {
+	WalletID    int64
+	Amount      int64
+	Description string
+}
+
+type compiledRule struct {
+	db.CategorizationRule
+	pattern string
+	regex   *regexp.Regexp
+}
+
+func (rule compiledRule) matches(expense Expense) bool {
+	if rule.WalletID != nil && *rule.WalletID != expense.WalletID {
+		return false
+	}
+	if rule.MinAmount != nil && expense.Amount < *rule.MinAmount {
+		return false
+	}
+	if rule.MaxAmount != nil && expense.Amount > *rule.MaxAmount {
+		return false
+	}
+	switch {
+	case rule.regex != nil:
+		return rule.regex.MatchString(expense.Description)
+	case rule.pattern != "":
+		return strings.Contains(strings.ToLower(expense.Description), rule.pattern)
+	}
+	return true
+}
+
+// Categorizer picks the category of an expense from the first matching rule
+type Categorizer struct {
+	rules []compiledRule
+}
+
+// New compiles the rules, which must already be sorted by descending priority
+func New(rules []db.CategorizationRule) (*Categorizer, error) {
+	categorizer := &Categorizer{rules: make([]compiledRule, 0, len(rules))}
+	for _, rule := range rules {
+		compiled := compiledRule{CategorizationRule: rule}
+		if rule.Pattern != nil {
+			switch rule.MatchType {
+			case MatchRegex:
+				regex, err := CompilePattern(*rule.Pattern)
+				if err != nil {
+					return nil, fmt.Errorf("rule %d: %w", rule.ID, err)
+				}
+				compiled.regex = regex
+			default:
+				compiled.pattern = strings.ToLower(*rule.Pattern)
+			}
+		}
+		categorizer.rules = append(categorizer.rules, compiled)
+	}
+	return categorizer, nil
+}
+
+// Load compiles the rules of the owner
+func Load(ctx context.Context, store Store, owner string) (*Categorizer, error) {
+	rules, err := store.ListCategorizationRules(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load categorization rules: %w", err)
+	}
+	return New(rules)
+}
+
+// Match returns the first rule matching the expense
+func (categorizer *Categorizer) Match(expense Expense) (db.CategorizationRule, bool) {
+	for _, rule := range categorizer.rules {
+		if rule.matches(expense) {
+			return rule.CategorizationRule, true
+		}
+	}
+	return db.CategorizationRule{}, false
+}
+
+// CompilePattern compiles a regex rule pattern; matching ignores case
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// Uncategorized returns the owner's fallback category, creating it when needed
+func Uncategorized(ctx context.Context, store Store, owner string) (db.Category, error) {
+	return store.GetOrCreateCategory(ctx, db.GetOrCreateCategoryParams{
+		Name:  UncategorizedName,
+		Owner: owner,
+	})
+}
+
+// Categorize returns the category of the first matching rule or the owner's
+// Uncategorized category when no rule matches
+func Categorize(ctx context.Context, store Store, owner string, expense Expense) (int64, error) {
+	categorizer, err := Load(ctx, store, owner)
+	if err != nil {
+		return 0, err
+	}
+	if rule, ok := categorizer.Match(expense); ok {
+		return rule.CategoryID, nil
+	}
+	category, err := Uncategorized(ctx, store, owner)
+	if err != nil {
+		return 0, err
+	}
+	return category.ID, nil
+}
+
+// ReapplyStore is the subset of db.Store used to re-categorize existing expenses
+type ReapplyStore interface {
+	Store
+	GetAllCategories(ctx context.Context, owner string) ([]db.Category, error)
+	ListCategoryExpenses(ctx context.Context, arg db.ListCategoryExpensesParams) ([]db.Expense, error)
+	SetExpenseCategories(ctx context.Context, arg db.SetExpenseCategoriesParams) (int64, error)
+}
+
+// Change is an uncategorized expense a rule assigns a category to
+type Change struct {
+	ExpenseID   int64  `json:"expense_id"`
+	WalletID    int64  `json:"wallet_id"`
+	Amount      int64  `json:"amount"`
+	Description string `json:"expense_description"`
+	CategoryID  int64  `json:"category_id"`
+	RuleID      int64  `json:"rule_id"`
+}
+
+// ReapplyResult lists the changes found by Reapply and how many were written
+type ReapplyResult struct {
+	DryRun  bool     `json:"dry_run"`
+	Changes []Change `json:"changes"`
+	Updated int64    `json:"updated"`
+}
+
+// Reapply runs the owner's rules over the expenses in the Uncategorized category,
+// optionally limited to one wallet. With dryRun nothing is written.
+func Reapply(ctx context.Context, store ReapplyStore, owner string, walletID int64, dryRun bool) (ReapplyResult, error) {
+	result := ReapplyResult{DryRun: dryRun, Changes: []Change{}}
+
+	categories, err := store.GetAllCategories(ctx, owner)
+	if err != nil {
+		return result, fmt.Errorf("cannot load categories: %w", err)
+	}
+	var uncategorized *db.Category
+	for i := range categories {
+		if categories[i].Name == UncategorizedName {
+			uncategorized = &categories[i]
+			break
+		}
+	}
+	if uncategorized == nil {
+		return result, nil
+	}
+
+	categorizer, err := Load(ctx, store, owner)
+	if err != nil {
+		return result, err
+	}
+	arg := db.ListCategoryExpensesParams{CategoryID: uncategorized.ID}
+	if walletID != 0 {
+		arg.WalletID = sql.NullInt64{Int64: walletID, Valid: true}
+	}
+	expenses, err := store.ListCategoryExpenses(ctx, arg)
+	if err != nil {
+		return result, fmt.Errorf("cannot load uncategorized expenses: %w", err)
+	}
+
+	update := db.SetExpenseCategoriesParams{FromCategoryID: uncategorized.ID}
+	for _, expense := range expenses {
+		rule, ok := categorizer.Match(Expense{
+			WalletID:    expense.WalletID,
+			Amount:      expense.Amount,
+			Description: expense.ExpenseDescription,
+		})
+		if !ok || rule.CategoryID == uncategorized.ID {
+			continue
+		}
+		result.Changes = append(result.Changes, Change{
+			ExpenseID:   expense.ID,
+			WalletID:    expense.WalletID,
+			Amount:      expense.Amount,
+			Description: expense.ExpenseDescription,
+			CategoryID:  rule.CategoryID,
+			RuleID:      rule.ID,
+		})
+		update.Ids = append(update.Ids, expense.ID)
+		update.CategoryIds = append(update.CategoryIds, rule.CategoryID)
+	}
+
+	if dryRun || len(update.Ids) == 0 {
+		return result, nil
+	}
+	result.Updated, err = store.SetExpenseCategories(ctx, update)
+	if err != nil {
+		return result, fmt.Errorf("cannot update expenses: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,155 @@
+package categorizer
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testRules() []db.CategorizationRule {
+	return []db.CategorizationRule{
+		{ID: 1, CategoryID: 10, Priority: 10, MatchType: MatchRegex, Pattern: ptr(`^uber\b`)},
+		{ID: 2, CategoryID: 20, Priority: 5, MatchType: MatchSubstring, Pattern: ptr("Coffee"), MaxAmount: ptr(int64(1000))},
+		{ID: 3, CategoryID: 30, Priority: 0, MatchType: MatchSubstring, MinAmount: ptr(int64(100000))},
+		{ID: 4, CategoryID: 40, Priority: 0, MatchType: MatchSubstring, WalletID: ptr(int64(7))},
+	}
+}
+
+func TestMatch(t *testing.T) {
+	categorizer, err := New(testRules())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		expense Expense
+		ruleID  int64
+	}{
+		{name: "Regex", expense: Expense{Description: "UBER trip", Amount: 200000}, ruleID: 1},
+		{name: "RegexAnchored", expense: Expense{Description: "Paid uber", Amount: 500}},
+		{name: "SubstringIgnoresCase", expense: Expense{Description: "morning coffee", Amount: 500}, ruleID: 2},
+		{name: "AboveMaxAmount", expense: Expense{Description: "coffee beans", Amount: 5000}},
+		{name: "MinAmount", expense: Expense{Description: "coffee machine", Amount: 150000}, ruleID: 3},
+		{name: "Wallet", expense: Expense{WalletID: 7, Description: "rent"}, ruleID: 4},
+		{name: "NoMatch", expense: Expense{WalletID: 8, Description: "rent"}},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			rule, ok := categorizer.Match(tc.expense)
+			require.Equal(t, tc.ruleID != 0, ok)
+			require.Equal(t, tc.ruleID, rule.ID)
+		})
+	}
+}
+
+func TestNewInvalidRegex(t *testing.T) {
+	_, err := New([]db.CategorizationRule{{ID: 5, MatchType: MatchRegex, Pattern: ptr("(")}})
+	require.ErrorContains(t, err, "rule 5")
+}
+
+func TestCategorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListCategorizationRules(gomock.Any(), gomock.Eq("owner")).
+		Times(2).
+		Return(testRules(), nil)
+	store.EXPECT().
+		GetOrCreateCategory(gomock.Any(), gomock.Eq(db.GetOrCreateCategoryParams{Name: UncategorizedName, Owner: "owner"})).
+		Times(1).
+		Return(db.Category{ID: 99}, nil)
+
+	categoryID, err := Categorize(context.Background(), store, "owner", Expense{Description: "Coffee", Amount: 300})
+	require.NoError(t, err)
+	require.Equal(t, int64(20), categoryID)
+
+	categoryID, err = Categorize(context.Background(), store, "owner", Expense{Description: "Books", Amount: 300})
+	require.NoError(t, err)
+	require.Equal(t, int64(99), categoryID)
+}
+
+func TestReapply(t *testing.T) {
+	uncategorized := db.Category{ID: 99, Name: UncategorizedName, Owner: "owner"}
+	expenses := []db.Expense{
+		{ID: 1, WalletID: 7, Amount: 300, ExpenseDescription: "Coffee", CategoryID: 99},
+		{ID: 2, WalletID: 8, Amount: 300, ExpenseDescription: "Books", CategoryID: 99},
+		{ID: 3, WalletID: 7, Amount: 300, ExpenseDescription: "Rent", CategoryID: 99},
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		ctrl := gomock.NewController(t)
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetAllCategories(gomock.Any(), gomock.Eq("owner")).
+			Times(1).
+			Return([]db.Category{{ID: 10, Name: "Food"}, uncategorized}, nil)
+		store.EXPECT().
+			ListCategorizationRules(gomock.Any(), gomock.Eq("owner")).
+			Times(1).
+			Return(testRules(), nil)
+		store.EXPECT().
+			ListCategoryExpenses(gomock.Any(), gomock.Eq(db.ListCategoryExpensesParams{
+				CategoryID: uncategorized.ID,
+				WalletID:   sql.NullInt64{Int64: 7, Valid: true},
+			})).
+			Times(1).
+			Return(expenses, nil)
+
+		update := db.SetExpenseCategoriesParams{
+			Ids:            []int64{1, 3},
+			CategoryIds:    []int64{20, 40},
+			FromCategoryID: uncategorized.ID,
+		}
+		times := 1
+		if dryRun {
+			times = 0
+		}
+		store.EXPECT().
+			SetExpenseCategories(gomock.Any(), gomock.Eq(update)).
+			Times(times).
+			Return(int64(2), nil)
+
+		result, err := Reapply(context.Background(), store, "owner", 7, dryRun)
+		require.NoError(t, err)
+		require.Equal(t, dryRun, result.DryRun)
+		require.Len(t, result.Changes, 2)
+		require.Equal(t, Change{ExpenseID: 3, WalletID: 7, Amount: 300, Description: "Rent", CategoryID: 40, RuleID: 4}, result.Changes[1])
+		if dryRun {
+			require.Zero(t, result.Updated)
+		} else {
+			require.Equal(t, int64(2), result.Updated)
+		}
+		ctrl.Finish()
+	}
+}
+
+func TestReapplyWithoutUncategorized(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAllCategories(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Category{{ID: 10, Name: "Food"}}, nil)
+	store.EXPECT().
+		ListCategoryExpenses(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	result, err := Reapply(context.Background(), store, "owner", 0, false)
+	require.NoError(t, err)
+	require.Empty(t, result.Changes)
+}
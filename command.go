@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/symyzi/financial-helper/backup"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/exchange"
+	"github.com/symyzi/financial-helper/token"
+	"github.com/symyzi/financial-helper/util"
+)
+
+// runCommand executes an administrative command instead of starting the server
+func runCommand(config util.Config, store db.Store, args []string) error {
+	switch args[0] {
+	case "set-role":
+		return setUserRole(store, args[1:])
+	case "rotate-keys":
+		return rotateKeys(config, args[1:])
+	case "import-rates":
+		return importRates(store, args[1:])
+	case "backup":
+		return backupUser(store, args[1:])
+	case "restore":
+		return restoreUser(store, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func importRates(store db.Store, args []string) error {
+	flags := flag.NewFlagSet("import-rates", flag.ContinueOnError)
+	format := flags.String("format", "", "file format: cbr, ecb or csv (guessed from the file name when empty)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: import-rates [-format cbr|ecb|csv] FILE...")
+	}
+
+	for _, path := range flags.Args() {
+		rates, err := exchange.ParseFile(path, *format)
+		if err != nil {
+			return fmt.Errorf("cannot parse %s: %w", path, err)
+		}
+
+		n, err := exchange.Import(context.Background(), store, rates)
+		if err != nil {
+			return fmt.Errorf("cannot import %s: %w", path, err)
+		}
+		log.Printf("imported %d exchange rates from %s", n, path)
+	}
+	return nil
+}
+
+func backupUser(store db.Store, args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	username := flags.String("username", "", "user to back up")
+	output := flags.String("o", "", "archive file (standard output when empty)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" {
+		return fmt.Errorf("usage: backup -username NAME [-o FILE]")
+	}
+
+	archive, err := backup.Create(context.Background(), store, *username)
+	if err != nil {
+		return fmt.Errorf("cannot back up %s: %w", *username, err)
+	}
+
+	w := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := backup.Write(w, archive); err != nil {
+		return err
+	}
+	log.Printf("backed up %d wallets and %d expenses of %s", len(archive.Wallets), len(archive.Expenses), *username)
+	return nil
+}
+
+func restoreUser(store db.Store, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	username := flags.String("username", "", "existing user to restore the archive under")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" || flags.NArg() != 1 {
+		return fmt.Errorf("usage: restore -username NAME FILE")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	archive, err := backup.Read(file)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", flags.Arg(0), err)
+	}
+	result, err := backup.Restore(context.Background(), store, archive, *username)
+	if err != nil {
+		return fmt.Errorf("cannot restore %s: %w", flags.Arg(0), err)
+	}
+	log.Printf("restored %d wallets and %d expenses under %s", len(result.WalletIDs), result.Expenses, *username)
+	return nil
+}
+
+// setUserRole grants or revokes a role, e.g. to create the first administrator.
+// The user has to log in again for the change to apply.
+func setUserRole(store db.Store, args []string) error {
+	flags := flag.NewFlagSet("set-role", flag.ContinueOnError)
+	username := flags.String("username", "", "user to change")
+	role := flags.String("role", "", "new role: user or admin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" || !util.IsSupportedRole(*role) {
+		return fmt.Errorf("usage: set-role -username NAME -role user|admin")
+	}
+
+	user, err := store.SetUserRole(context.Background(), db.SetUserRoleParams{
+		Username:  *username,
+		Role:      *role,
+		RevokedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot set role of %s: %w", *username, err)
+	}
+	log.Printf("%s now has the %s role", user.Username, user.Role)
+	return nil
+}
+
+// rotateKeys activates a new token signing key in the key ring file. Tokens signed
+// with the previous key stay valid until it retires, by default once every refresh
+// token it signed has expired. Servers pick up the new ring when restarted.
+func rotateKeys(config util.Config, args []string) error {
+	flags := flag.NewFlagSet("rotate-keys", flag.ContinueOnError)
+	keyID := flags.String("id", "", "ID of the new key (derived from the current time when empty)")
+	retireAfter := flags.Duration("retire-after", config.RefreshTokenDuration, "how long the previous key keeps verifying tokens")
+	useRSA := flags.Bool("rsa", false, "generate an RSA key instead of an Ed25519 key (jwt_public only)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if config.TokenKeyRingFile == "" {
+		return fmt.Errorf("TOKEN_KEY_RING_FILE is not set")
+	}
+
+	ring, err := config.TokenKeys()
+	if err != nil {
+		return err
+	}
+	previous := ring.Active
+
+	now := time.Now()
+	if *keyID == "" {
+		*keyID = now.UTC().Format("20060102T150405Z")
+	}
+	key, err := generateTokenKey(config.TokenType, *keyID, *useRSA)
+	if err != nil {
+		return err
+	}
+	if err := ring.Rotate(key, now.Add(*retireAfter), now); err != nil {
+		return err
+	}
+	if err := ring.Save(config.TokenKeyRingFile); err != nil {
+		return err
+	}
+	log.Printf("activated token key %q, key %q verifies tokens until %s", key.ID, previous, now.Add(*retireAfter).Format(time.RFC3339))
+	return nil
+}
+
+func generateTokenKey(tokenType, id string, useRSA bool) (util.TokenKey, error) {
+	key := util.TokenKey{ID: id}
+
+	var signer crypto.Signer
+	var err error
+	switch tokenType {
+	case "", "paseto", "jwt":
+		// 24 random bytes encode to the 32 characters a v2.local key needs
+		secret := make([]byte, 24)
+		if _, err := rand.Read(secret); err != nil {
+			return key, err
+		}
+		key.Secret = base64.RawURLEncoding.EncodeToString(secret)
+		return key, nil
+	case "jwt_public":
+		if useRSA {
+			signer, err = rsa.GenerateKey(rand.Reader, 3072)
+			break
+		}
+		fallthrough
+	case "paseto_public":
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return key, fmt.Errorf("unsupported token type %s", tokenType)
+	}
+	if err != nil {
+		return key, err
+	}
+
+	data, err := token.MarshalPrivateKey(signer)
+	if err != nil {
+		return key, err
+	}
+	key.PrivateKey = string(data)
+	return key, nil
+}
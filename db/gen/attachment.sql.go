@@ -0,0 +1,148 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: attachment.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createAttachment = `-- name: CreateAttachment :one
+INSERT INTO attachments (
+  expense_id,
+  blob_key,
+  file_name,
+  content_type,
+  size
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING id, expense_id, blob_key, file_name, content_type, size, created_at
+`
+
+type CreateAttachmentParams struct {
+	ExpenseID   int64  `json:"expense_id"`
+	BlobKey     string `json:"blob_key"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (Attachment, error) {
+	row := q.queryRow(ctx, q.createAttachmentStmt, createAttachment,
+		arg.ExpenseID,
+		arg.BlobKey,
+		arg.FileName,
+		arg.ContentType,
+		arg.Size,
+	)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.ExpenseID,
+		&i.BlobKey,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAttachment = `-- name: DeleteAttachment :exec
+DELETE FROM attachments
+WHERE id = $1
+`
+
+func (q *Queries) DeleteAttachment(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteAttachmentStmt, deleteAttachment, id)
+	return err
+}
+
+const deleteExpenseAttachments = `-- name: DeleteExpenseAttachments :many
+DELETE FROM attachments
+WHERE expense_id = $1
+RETURNING blob_key
+`
+
+func (q *Queries) DeleteExpenseAttachments(ctx context.Context, expenseID int64) ([]string, error) {
+	rows, err := q.query(ctx, q.deleteExpenseAttachmentsStmt, deleteExpenseAttachments, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var blob_key string
+		if err := rows.Scan(&blob_key); err != nil {
+			return nil, err
+		}
+		items = append(items, blob_key)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttachment = `-- name: GetAttachment :one
+SELECT id, expense_id, blob_key, file_name, content_type, size, created_at FROM attachments
+WHERE id = $1
+`
+
+func (q *Queries) GetAttachment(ctx context.Context, id int64) (Attachment, error) {
+	row := q.queryRow(ctx, q.getAttachmentStmt, getAttachment, id)
+	var i Attachment
+	err := row.Scan(
+		&i.ID,
+		&i.ExpenseID,
+		&i.BlobKey,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listExpenseAttachments = `-- name: ListExpenseAttachments :many
+SELECT id, expense_id, blob_key, file_name, content_type, size, created_at FROM attachments
+WHERE expense_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListExpenseAttachments(ctx context.Context, expenseID int64) ([]Attachment, error) {
+	rows, err := q.query(ctx, q.listExpenseAttachmentsStmt, listExpenseAttachments, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Attachment{}
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ExpenseID,
+			&i.BlobKey,
+			&i.FileName,
+			&i.ContentType,
+			&i.Size,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
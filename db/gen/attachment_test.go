@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func TestAttachments(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	expense := CreateRandomExpense(t, wallet, category)
+
+	arg := CreateAttachmentParams{
+		ExpenseID:   expense.ID,
+		BlobKey:     "expenses/" + uuid.NewString(),
+		FileName:    util.RandomString(8) + ".pdf",
+		ContentType: "application/pdf",
+		Size:        util.RandomInt(1, 1000),
+	}
+	attachment, err := testQueries.CreateAttachment(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.BlobKey, attachment.BlobKey)
+	require.Equal(t, arg.Size, attachment.Size)
+
+	attachments, err := testQueries.ListExpenseAttachments(context.Background(), expense.ID)
+	require.NoError(t, err)
+	require.Equal(t, []Attachment{attachment}, attachments)
+
+	// attachment rows are removed with their expense
+	result, err := testStore.DeleteExpenseTx(context.Background(), expense.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{arg.BlobKey}, result.BlobKeys)
+	attachments, err = testQueries.ListExpenseAttachments(context.Background(), expense.ID)
+	require.NoError(t, err)
+	require.Empty(t, attachments)
+}
@@ -0,0 +1,676 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: backup.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const listOwnerBudgets = `-- name: ListOwnerBudgets :many
+SELECT b.id, b.wallet_id, b.amount, b.category_id, b.created_at, b.period, b.start_date, b.end_date, b.recurring FROM budgets b
+JOIN wallets w ON w.id = b.wallet_id
+WHERE w.owner = $1
+ORDER BY b.id
+`
+
+func (q *Queries) ListOwnerBudgets(ctx context.Context, owner string) ([]Budget, error) {
+	rows, err := q.query(ctx, q.listOwnerBudgetsStmt, listOwnerBudgets, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Budget{}
+	for rows.Next() {
+		var i Budget
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.CategoryID,
+			&i.CreatedAt,
+			&i.Period,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Recurring,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnerCategories = `-- name: ListOwnerCategories :many
+SELECT id, name, owner, created_at FROM categories
+WHERE owner = $1
+ORDER BY id
+`
+
+func (q *Queries) ListOwnerCategories(ctx context.Context, owner string) ([]Category, error) {
+	rows, err := q.query(ctx, q.listOwnerCategoriesStmt, listOwnerCategories, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Category{}
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Owner,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnerExpenseSplits = `-- name: ListOwnerExpenseSplits :many
+SELECT s.id, s.expense_id, s.category_id, s.amount, s.created_at FROM expense_splits s
+JOIN expenses e ON e.id = s.expense_id
+JOIN wallets w ON w.id = e.wallet_id
+WHERE w.owner = $1
+ORDER BY s.id
+`
+
+func (q *Queries) ListOwnerExpenseSplits(ctx context.Context, owner string) ([]ExpenseSplit, error) {
+	rows, err := q.query(ctx, q.listOwnerExpenseSplitsStmt, listOwnerExpenseSplits, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExpenseSplit{}
+	for rows.Next() {
+		var i ExpenseSplit
+		if err := rows.Scan(
+			&i.ID,
+			&i.ExpenseID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnerExpenseTags = `-- name: ListOwnerExpenseTags :many
+SELECT et.expense_id, et.tag_id, et.created_at FROM expense_tags et
+JOIN tags t ON t.id = et.tag_id
+WHERE t.owner = $1
+ORDER BY et.expense_id, et.tag_id
+`
+
+func (q *Queries) ListOwnerExpenseTags(ctx context.Context, owner string) ([]ExpenseTag, error) {
+	rows, err := q.query(ctx, q.listOwnerExpenseTagsStmt, listOwnerExpenseTags, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExpenseTag{}
+	for rows.Next() {
+		var i ExpenseTag
+		if err := rows.Scan(&i.ExpenseID, &i.TagID, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnerExpenses = `-- name: ListOwnerExpenses :many
+SELECT e.id, e.wallet_id, e.amount, e.expense_description, e.category_id, e.created_at, e.recurring_expense_id, e.occurrence_date, e.external_id FROM expenses e
+JOIN wallets w ON w.id = e.wallet_id
+WHERE w.owner = $1
+ORDER BY e.id
+`
+
+func (q *Queries) ListOwnerExpenses(ctx context.Context, owner string) ([]Expense, error) {
+	rows, err := q.query(ctx, q.listOwnerExpensesStmt, listOwnerExpenses, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Expense{}
+	for rows.Next() {
+		var i Expense
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.ExpenseDescription,
+			&i.CategoryID,
+			&i.CreatedAt,
+			&i.RecurringExpenseID,
+			&i.OccurrenceDate,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnerIncomes = `-- name: ListOwnerIncomes :many
+SELECT i.id, i.wallet_id, i.amount, i.income_description, i.category_id, i.created_at FROM incomes i
+JOIN wallets w ON w.id = i.wallet_id
+WHERE w.owner = $1
+ORDER BY i.id
+`
+
+func (q *Queries) ListOwnerIncomes(ctx context.Context, owner string) ([]Income, error) {
+	rows, err := q.query(ctx, q.listOwnerIncomesStmt, listOwnerIncomes, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Income{}
+	for rows.Next() {
+		var i Income
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.IncomeDescription,
+			&i.CategoryID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnerTags = `-- name: ListOwnerTags :many
+SELECT id, owner, name, created_at FROM tags
+WHERE owner = $1
+ORDER BY id
+`
+
+func (q *Queries) ListOwnerTags(ctx context.Context, owner string) ([]Tag, error) {
+	rows, err := q.query(ctx, q.listOwnerTagsStmt, listOwnerTags, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Name,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnerTransfers = `-- name: ListOwnerTransfers :many
+SELECT t.id, t.from_wallet_id, t.to_wallet_id, t.amount, t.created_at FROM transfers t
+JOIN wallets f ON f.id = t.from_wallet_id
+JOIN wallets w ON w.id = t.to_wallet_id
+WHERE f.owner = $1 AND w.owner = $1
+ORDER BY t.id
+`
+
+func (q *Queries) ListOwnerTransfers(ctx context.Context, owner string) ([]Transfer, error) {
+	rows, err := q.query(ctx, q.listOwnerTransfersStmt, listOwnerTransfers, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromWalletID,
+			&i.ToWalletID,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOwnerWallets = `-- name: ListOwnerWallets :many
+SELECT name, id, owner, currency, created_at, balance FROM wallets
+WHERE owner = $1
+ORDER BY id
+`
+
+func (q *Queries) ListOwnerWallets(ctx context.Context, owner string) ([]Wallet, error) {
+	rows, err := q.query(ctx, q.listOwnerWalletsStmt, listOwnerWallets, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Wallet{}
+	for rows.Next() {
+		var i Wallet
+		if err := rows.Scan(
+			&i.Name,
+			&i.ID,
+			&i.Owner,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Balance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreBudget = `-- name: RestoreBudget :one
+INSERT INTO budgets (
+    wallet_id,
+    amount,
+    category_id,
+    period,
+    start_date,
+    end_date,
+    recurring,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, wallet_id, amount, category_id, created_at, period, start_date, end_date, recurring
+`
+
+type RestoreBudgetParams struct {
+	WalletID   int64      `json:"wallet_id"`
+	Amount     int64      `json:"amount"`
+	CategoryID int64      `json:"category_id"`
+	Period     string     `json:"period"`
+	StartDate  time.Time  `json:"start_date"`
+	EndDate    *time.Time `json:"end_date"`
+	Recurring  bool       `json:"recurring"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (q *Queries) RestoreBudget(ctx context.Context, arg RestoreBudgetParams) (Budget, error) {
+	row := q.queryRow(ctx, q.restoreBudgetStmt, restoreBudget,
+		arg.WalletID,
+		arg.Amount,
+		arg.CategoryID,
+		arg.Period,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Recurring,
+		arg.CreatedAt,
+	)
+	var i Budget
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.CategoryID,
+		&i.CreatedAt,
+		&i.Period,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Recurring,
+	)
+	return i, err
+}
+
+const restoreCategory = `-- name: RestoreCategory :one
+INSERT INTO categories (
+    owner,
+    name,
+    created_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (owner, name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id, name, owner, created_at
+`
+
+type RestoreCategoryParams struct {
+	Owner     string    `json:"owner"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// An existing category with the same name is reused
+func (q *Queries) RestoreCategory(ctx context.Context, arg RestoreCategoryParams) (Category, error) {
+	row := q.queryRow(ctx, q.restoreCategoryStmt, restoreCategory, arg.Owner, arg.Name, arg.CreatedAt)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Owner,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const restoreExpense = `-- name: RestoreExpense :one
+INSERT INTO expenses (
+    wallet_id,
+    amount,
+    expense_description,
+    category_id,
+    created_at,
+    external_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id
+`
+
+type RestoreExpenseParams struct {
+	WalletID           int64     `json:"wallet_id"`
+	Amount             int64     `json:"amount"`
+	ExpenseDescription string    `json:"expense_description"`
+	CategoryID         int64     `json:"category_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	ExternalID         *string   `json:"external_id"`
+}
+
+func (q *Queries) RestoreExpense(ctx context.Context, arg RestoreExpenseParams) (Expense, error) {
+	row := q.queryRow(ctx, q.restoreExpenseStmt, restoreExpense,
+		arg.WalletID,
+		arg.Amount,
+		arg.ExpenseDescription,
+		arg.CategoryID,
+		arg.CreatedAt,
+		arg.ExternalID,
+	)
+	var i Expense
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.ExpenseDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+		&i.RecurringExpenseID,
+		&i.OccurrenceDate,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const restoreExpenseSplit = `-- name: RestoreExpenseSplit :one
+INSERT INTO expense_splits (
+    expense_id,
+    category_id,
+    amount,
+    created_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, expense_id, category_id, amount, created_at
+`
+
+type RestoreExpenseSplitParams struct {
+	ExpenseID  int64     `json:"expense_id"`
+	CategoryID int64     `json:"category_id"`
+	Amount     int64     `json:"amount"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (q *Queries) RestoreExpenseSplit(ctx context.Context, arg RestoreExpenseSplitParams) (ExpenseSplit, error) {
+	row := q.queryRow(ctx, q.restoreExpenseSplitStmt, restoreExpenseSplit,
+		arg.ExpenseID,
+		arg.CategoryID,
+		arg.Amount,
+		arg.CreatedAt,
+	)
+	var i ExpenseSplit
+	err := row.Scan(
+		&i.ID,
+		&i.ExpenseID,
+		&i.CategoryID,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const restoreExpenseTag = `-- name: RestoreExpenseTag :exec
+INSERT INTO expense_tags (
+    expense_id,
+    tag_id,
+    created_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT DO NOTHING
+`
+
+type RestoreExpenseTagParams struct {
+	ExpenseID int64     `json:"expense_id"`
+	TagID     int64     `json:"tag_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) RestoreExpenseTag(ctx context.Context, arg RestoreExpenseTagParams) error {
+	_, err := q.exec(ctx, q.restoreExpenseTagStmt, restoreExpenseTag, arg.ExpenseID, arg.TagID, arg.CreatedAt)
+	return err
+}
+
+const restoreIncome = `-- name: RestoreIncome :one
+INSERT INTO incomes (
+    wallet_id,
+    amount,
+    income_description,
+    category_id,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING id, wallet_id, amount, income_description, category_id, created_at
+`
+
+type RestoreIncomeParams struct {
+	WalletID          int64     `json:"wallet_id"`
+	Amount            int64     `json:"amount"`
+	IncomeDescription string    `json:"income_description"`
+	CategoryID        int64     `json:"category_id"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func (q *Queries) RestoreIncome(ctx context.Context, arg RestoreIncomeParams) (Income, error) {
+	row := q.queryRow(ctx, q.restoreIncomeStmt, restoreIncome,
+		arg.WalletID,
+		arg.Amount,
+		arg.IncomeDescription,
+		arg.CategoryID,
+		arg.CreatedAt,
+	)
+	var i Income
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.IncomeDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const restoreTag = `-- name: RestoreTag :one
+INSERT INTO tags (
+    owner,
+    name,
+    created_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (owner, name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id, owner, name, created_at
+`
+
+type RestoreTagParams struct {
+	Owner     string    `json:"owner"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// An existing tag with the same name is reused
+func (q *Queries) RestoreTag(ctx context.Context, arg RestoreTagParams) (Tag, error) {
+	row := q.queryRow(ctx, q.restoreTagStmt, restoreTag, arg.Owner, arg.Name, arg.CreatedAt)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const restoreTransfer = `-- name: RestoreTransfer :one
+INSERT INTO transfers (
+    from_wallet_id,
+    to_wallet_id,
+    amount,
+    created_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, from_wallet_id, to_wallet_id, amount, created_at
+`
+
+type RestoreTransferParams struct {
+	FromWalletID int64     `json:"from_wallet_id"`
+	ToWalletID   int64     `json:"to_wallet_id"`
+	Amount       int64     `json:"amount"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (q *Queries) RestoreTransfer(ctx context.Context, arg RestoreTransferParams) (Transfer, error) {
+	row := q.queryRow(ctx, q.restoreTransferStmt, restoreTransfer,
+		arg.FromWalletID,
+		arg.ToWalletID,
+		arg.Amount,
+		arg.CreatedAt,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromWalletID,
+		&i.ToWalletID,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const restoreWallet = `-- name: RestoreWallet :one
+INSERT INTO wallets (
+    owner,
+    name,
+    currency,
+    balance,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING name, id, owner, currency, created_at, balance
+`
+
+type RestoreWalletParams struct {
+	Owner     string    `json:"owner"`
+	Name      string    `json:"name"`
+	Currency  string    `json:"currency"`
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) RestoreWallet(ctx context.Context, arg RestoreWalletParams) (Wallet, error) {
+	row := q.queryRow(ctx, q.restoreWalletStmt, restoreWallet,
+		arg.Owner,
+		arg.Name,
+		arg.Currency,
+		arg.Balance,
+		arg.CreatedAt,
+	)
+	var i Wallet
+	err := row.Scan(
+		&i.Name,
+		&i.ID,
+		&i.Owner,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Balance,
+	)
+	return i, err
+}
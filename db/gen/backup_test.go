@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupAndRestoreTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet1 := CreateRandomWallet(t, user)
+	wallet2 := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	expense := CreateRandomExpense(t, wallet1, category)
+	CreateRandomIncome(t, wallet2, category)
+	CreateRandomBudget(t, wallet1, category)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromWalletID: wallet1.ID,
+		ToWalletID:   wallet2.ID,
+		Amount:       10,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.SetExpenseSplitsTx(context.Background(), SetExpenseSplitsTxParams{
+		ExpenseID: expense.ID,
+		Splits: []ExpenseSplitParams{
+			{CategoryID: category.ID, Amount: 1},
+			{CategoryID: category.ID, Amount: expense.Amount - 1},
+		},
+	})
+	require.NoError(t, err)
+
+	tag := CreateRandomTag(t, user)
+	err = testQueries.AddExpenseTag(context.Background(), AddExpenseTagParams{ExpenseID: expense.ID, TagID: tag.ID})
+	require.NoError(t, err)
+
+	data, err := testStore.BackupTx(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, data.Wallets, 2)
+	require.Len(t, data.Categories, 1)
+	require.Len(t, data.Expenses, 1)
+	require.Len(t, data.ExpenseSplits, 2)
+	require.Len(t, data.Tags, 1)
+	require.Len(t, data.ExpenseTags, 1)
+	require.Len(t, data.Incomes, 1)
+	require.Len(t, data.Budgets, 1)
+	require.Len(t, data.Transfers, 1)
+
+	target := CreateRandomUser(t)
+	result, err := testStore.RestoreTx(context.Background(), RestoreTxParams{Owner: target.Username, Data: data})
+	require.NoError(t, err)
+	require.Len(t, result.WalletIDs, 2)
+	require.Equal(t, 1, result.Expenses)
+	require.Equal(t, 2, result.ExpenseSplits)
+	require.Equal(t, 1, result.ExpenseTags)
+	require.Equal(t, 1, result.Incomes)
+	require.Equal(t, 1, result.Budgets)
+	require.Equal(t, 1, result.Transfers)
+
+	restored, err := testStore.BackupTx(context.Background(), target.Username)
+	require.NoError(t, err)
+	require.Len(t, restored.Wallets, 2)
+	for i, wallet := range restored.Wallets {
+		require.Equal(t, result.WalletIDs[data.Wallets[i].ID], wallet.ID)
+		require.Equal(t, data.Wallets[i].Balance, wallet.Balance)
+		require.Equal(t, target.Username, wallet.Owner)
+	}
+	require.Equal(t, category.Name, restored.Categories[0].Name)
+	require.Equal(t, result.CategoryIDs[category.ID], restored.Expenses[0].CategoryID)
+	require.Equal(t, restored.Expenses[0].ID, restored.ExpenseSplits[0].ExpenseID)
+	require.Equal(t, result.TagIDs[tag.ID], restored.ExpenseTags[0].TagID)
+	require.Equal(t, result.WalletIDs[wallet1.ID], restored.Transfers[0].FromWalletID)
+
+	// restoring again adds new wallets but reuses the category
+	again, err := testStore.RestoreTx(context.Background(), RestoreTxParams{Owner: target.Username, Data: data})
+	require.NoError(t, err)
+	require.NotEqual(t, result.WalletIDs[wallet1.ID], again.WalletIDs[wallet1.ID])
+	require.Equal(t, result.CategoryIDs[category.ID], again.CategoryIDs[category.ID])
+}
+
+func TestRestoreTxRollback(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	CreateRandomExpense(t, wallet, category)
+
+	data, err := testStore.BackupTx(context.Background(), user.Username)
+	require.NoError(t, err)
+	data.Expenses[0].CategoryID = category.ID + 1000
+
+	target := CreateRandomUser(t)
+	_, err = testStore.RestoreTx(context.Background(), RestoreTxParams{Owner: target.Username, Data: data})
+	require.Error(t, err)
+
+	wallets, err := testQueries.ListOwnerWallets(context.Background(), target.Username)
+	require.NoError(t, err)
+	require.Empty(t, wallets)
+}
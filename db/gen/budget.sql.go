@@ -7,27 +7,45 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createBudget = `-- name: CreateBudget :one
 INSERT INTO budgets (
   wallet_id,
   category_id,
-  amount
+  amount,
+  period,
+  start_date,
+  end_date,
+  recurring
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, wallet_id, amount, category_id, created_at
+RETURNING id, wallet_id, amount, category_id, created_at, period, start_date, end_date, recurring
 `
 
 type CreateBudgetParams struct {
-	WalletID   int64 `json:"wallet_id"`
-	CategoryID int64 `json:"category_id"`
-	Amount     int64 `json:"amount"`
+	WalletID   int64      `json:"wallet_id"`
+	CategoryID int64      `json:"category_id"`
+	Amount     int64      `json:"amount"`
+	Period     string     `json:"period"`
+	StartDate  time.Time  `json:"start_date"`
+	EndDate    *time.Time `json:"end_date"`
+	Recurring  bool       `json:"recurring"`
 }
 
 func (q *Queries) CreateBudget(ctx context.Context, arg CreateBudgetParams) (Budget, error) {
-	row := q.queryRow(ctx, q.createBudgetStmt, createBudget, arg.WalletID, arg.CategoryID, arg.Amount)
+	row := q.queryRow(ctx, q.createBudgetStmt, createBudget,
+		arg.WalletID,
+		arg.CategoryID,
+		arg.Amount,
+		arg.Period,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Recurring,
+	)
 	var i Budget
 	err := row.Scan(
 		&i.ID,
@@ -35,6 +53,10 @@ func (q *Queries) CreateBudget(ctx context.Context, arg CreateBudgetParams) (Bud
 		&i.Amount,
 		&i.CategoryID,
 		&i.CreatedAt,
+		&i.Period,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Recurring,
 	)
 	return i, err
 }
@@ -50,7 +72,7 @@ func (q *Queries) DeleteBudget(ctx context.Context, id int64) error {
 }
 
 const getBudgetByID = `-- name: GetBudgetByID :one
-SELECT id, wallet_id, amount, category_id, created_at FROM budgets 
+SELECT id, wallet_id, amount, category_id, created_at, period, start_date, end_date, recurring FROM budgets 
 WHERE id = $1
 `
 
@@ -63,23 +85,133 @@ func (q *Queries) GetBudgetByID(ctx context.Context, id int64) (Budget, error) {
 		&i.Amount,
 		&i.CategoryID,
 		&i.CreatedAt,
+		&i.Period,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Recurring,
+	)
+	return i, err
+}
+
+const getBudgetProgress = `-- name: GetBudgetProgress :one
+WITH spent AS (
+  SELECT COALESCE(SUM(e.amount), 0)::bigint AS amount
+  FROM budgets b
+  JOIN expense_allocations e ON e.wallet_id = b.wallet_id AND e.category_id = b.category_id
+  WHERE b.id = $4
+    AND e.created_at >= $2::timestamptz
+    AND e.created_at < $1::timestamptz
+)
+SELECT
+  b.amount AS planned,
+  s.amount AS spent,
+  (b.amount - s.amount)::bigint AS remaining,
+  (CASE WHEN b.amount = 0 THEN 0
+        ELSE round(s.amount * 100.0 / b.amount, 2)
+   END)::float8 AS percent_used,
+  round(
+    s.amount * extract(epoch FROM $1::timestamptz - $2::timestamptz)
+    / greatest(extract(epoch FROM least($3::timestamptz, $1::timestamptz) - $2::timestamptz), 1)
+  )::bigint AS projected
+FROM budgets b, spent s
+WHERE b.id = $4
+`
+
+type GetBudgetProgressParams struct {
+	PeriodEnd   time.Time `json:"period_end"`
+	PeriodStart time.Time `json:"period_start"`
+	At          time.Time `json:"at"`
+	ID          int64     `json:"id"`
+}
+
+type GetBudgetProgressRow struct {
+	Planned     int64   `json:"planned"`
+	Spent       int64   `json:"spent"`
+	Remaining   int64   `json:"remaining"`
+	PercentUsed float64 `json:"percent_used"`
+	Projected   int64   `json:"projected"`
+}
+
+func (q *Queries) GetBudgetProgress(ctx context.Context, arg GetBudgetProgressParams) (GetBudgetProgressRow, error) {
+	row := q.queryRow(ctx, q.getBudgetProgressStmt, getBudgetProgress,
+		arg.PeriodEnd,
+		arg.PeriodStart,
+		arg.At,
+		arg.ID,
+	)
+	var i GetBudgetProgressRow
+	err := row.Scan(
+		&i.Planned,
+		&i.Spent,
+		&i.Remaining,
+		&i.PercentUsed,
+		&i.Projected,
 	)
 	return i, err
 }
 
 const listBudgets = `-- name: ListBudgets :many
-SELECT id, wallet_id, amount, category_id, created_at FROM budgets
-LIMIT $1
-OFFSET $2
+SELECT id, wallet_id, amount, category_id, created_at, period, start_date, end_date, recurring FROM budgets
+WHERE wallet_id = $1
+  AND ($2::bigint IS NULL
+    OR (created_at, id) > ($3::timestamptz, $2))
+ORDER BY created_at, id
+LIMIT $4
 `
 
 type ListBudgetsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	WalletID        int64         `json:"wallet_id"`
+	CursorID        sql.NullInt64 `json:"cursor_id"`
+	CursorCreatedAt time.Time     `json:"cursor_created_at"`
+	Limit           int32         `json:"limit"`
 }
 
 func (q *Queries) ListBudgets(ctx context.Context, arg ListBudgetsParams) ([]Budget, error) {
-	rows, err := q.query(ctx, q.listBudgetsStmt, listBudgets, arg.Limit, arg.Offset)
+	rows, err := q.query(ctx, q.listBudgetsStmt, listBudgets,
+		arg.WalletID,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Budget{}
+	for rows.Next() {
+		var i Budget
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.CategoryID,
+			&i.CreatedAt,
+			&i.Period,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Recurring,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBudgetsByWallet = `-- name: ListBudgetsByWallet :many
+SELECT id, wallet_id, amount, category_id, created_at, period, start_date, end_date, recurring FROM budgets
+WHERE wallet_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListBudgetsByWallet(ctx context.Context, walletID int64) ([]Budget, error) {
+	rows, err := q.query(ctx, q.listBudgetsByWalletStmt, listBudgetsByWallet, walletID)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +225,10 @@ func (q *Queries) ListBudgets(ctx context.Context, arg ListBudgetsParams) ([]Bud
 			&i.Amount,
 			&i.CategoryID,
 			&i.CreatedAt,
+			&i.Period,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Recurring,
 		); err != nil {
 			return nil, err
 		}
@@ -108,20 +244,41 @@ func (q *Queries) ListBudgets(ctx context.Context, arg ListBudgetsParams) ([]Bud
 }
 
 const updateBudget = `-- name: UpdateBudget :one
-UPDATE budgets 
-SET amount = $2, category_id = $3 
-WHERE id = $1
-RETURNING id, wallet_id, amount, category_id, created_at
+UPDATE budgets
+SET
+    amount = COALESCE($1, amount),
+    category_id = COALESCE($2, category_id),
+    period = COALESCE($3, period),
+    start_date = COALESCE($4, start_date),
+    end_date = CASE WHEN $5::boolean THEN NULL ELSE COALESCE($6, end_date) END,
+    recurring = COALESCE($7, recurring)
+WHERE
+    id = $8
+RETURNING id, wallet_id, amount, category_id, created_at, period, start_date, end_date, recurring
 `
 
 type UpdateBudgetParams struct {
-	ID         int64 `json:"id"`
-	Amount     int64 `json:"amount"`
-	CategoryID int64 `json:"category_id"`
+	Amount       sql.NullInt64  `json:"amount"`
+	CategoryID   sql.NullInt64  `json:"category_id"`
+	Period       sql.NullString `json:"period"`
+	StartDate    *time.Time     `json:"start_date"`
+	ClearEndDate bool           `json:"clear_end_date"`
+	EndDate      *time.Time     `json:"end_date"`
+	Recurring    sql.NullBool   `json:"recurring"`
+	ID           int64          `json:"id"`
 }
 
 func (q *Queries) UpdateBudget(ctx context.Context, arg UpdateBudgetParams) (Budget, error) {
-	row := q.queryRow(ctx, q.updateBudgetStmt, updateBudget, arg.ID, arg.Amount, arg.CategoryID)
+	row := q.queryRow(ctx, q.updateBudgetStmt, updateBudget,
+		arg.Amount,
+		arg.CategoryID,
+		arg.Period,
+		arg.StartDate,
+		arg.ClearEndDate,
+		arg.EndDate,
+		arg.Recurring,
+		arg.ID,
+	)
 	var i Budget
 	err := row.Scan(
 		&i.ID,
@@ -129,6 +286,10 @@ func (q *Queries) UpdateBudget(ctx context.Context, arg UpdateBudgetParams) (Bud
 		&i.Amount,
 		&i.CategoryID,
 		&i.CreatedAt,
+		&i.Period,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Recurring,
 	)
 	return i, err
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -14,6 +15,9 @@ func CreateRandomBudget(t *testing.T, wallet Wallet, category Category) Budget {
 		WalletID:   wallet.ID,
 		CategoryID: category.ID,
 		Amount:     util.RandomAmount(),
+		Period:     util.PeriodMonthly,
+		StartDate:  util.Day(time.Now()),
+		Recurring:  true,
 	}
 	budget, err := testQueries.CreateBudget(context.Background(), arg)
 	require.NoError(t, err)
@@ -21,6 +25,10 @@ func CreateRandomBudget(t *testing.T, wallet Wallet, category Category) Budget {
 	require.Equal(t, arg.WalletID, budget.WalletID)
 	require.Equal(t, arg.CategoryID, budget.CategoryID)
 	require.Equal(t, arg.Amount, budget.Amount)
+	require.Equal(t, arg.Period, budget.Period)
+	require.WithinDuration(t, arg.StartDate, budget.StartDate, time.Second)
+	require.Nil(t, budget.EndDate)
+	require.Equal(t, arg.Recurring, budget.Recurring)
 	require.NotZero(t, budget.ID)
 	require.NotZero(t, budget.CreatedAt)
 	return budget
@@ -56,14 +64,23 @@ func TestListBudgets(t *testing.T) {
 		CreateRandomBudget(t, wallet, category)
 	}
 	arg := ListBudgetsParams{
-		Limit:  5,
-		Offset: 5,
+		WalletID: wallet.ID,
+		Limit:    5,
 	}
 	budgets, err := testQueries.ListBudgets(context.Background(), arg)
 	require.NoError(t, err)
 	require.Len(t, budgets, 5)
-	for _, budget := range budgets {
+
+	last := budgets[len(budgets)-1]
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+	arg.CursorCreatedAt = last.CreatedAt
+	nextBudgets, err := testQueries.ListBudgets(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, nextBudgets, 5)
+	for _, budget := range nextBudgets {
 		require.NotEmpty(t, budget)
+		require.Equal(t, wallet.ID, budget.WalletID)
+		require.Greater(t, budget.ID, last.ID)
 	}
 }
 
@@ -73,9 +90,8 @@ func TestUpdateBudget(t *testing.T) {
 	category := CreateRandomCategory(t, user)
 	budget1 := CreateRandomBudget(t, wallet, category)
 	arg := UpdateBudgetParams{
-		ID:         budget1.ID,
-		Amount:     util.RandomAmount(),
-		CategoryID: category.ID,
+		ID:     budget1.ID,
+		Amount: sql.NullInt64{Int64: budget1.Amount + 1, Valid: true},
 	}
 	budget2, err := testQueries.UpdateBudget(context.Background(), arg)
 	require.NoError(t, err)
@@ -83,8 +99,19 @@ func TestUpdateBudget(t *testing.T) {
 	require.Equal(t, budget1.ID, budget2.ID)
 	require.Equal(t, budget1.WalletID, budget2.WalletID)
 	require.Equal(t, budget1.CategoryID, budget2.CategoryID)
-	require.Equal(t, arg.Amount, budget2.Amount)
-	require.NotEqual(t, budget1.Amount, budget2.Amount)
+	require.Equal(t, arg.Amount.Int64, budget2.Amount)
+	require.Equal(t, budget1.Period, budget2.Period)
+	require.Equal(t, budget1.Recurring, budget2.Recurring)
+	require.WithinDuration(t, budget1.StartDate, budget2.StartDate, time.Second)
+
+	endDate := budget1.StartDate.AddDate(0, 1, 0)
+	budget3, err := testQueries.UpdateBudget(context.Background(), UpdateBudgetParams{ID: budget1.ID, EndDate: &endDate})
+	require.NoError(t, err)
+	require.NotNil(t, budget3.EndDate)
+
+	budget4, err := testQueries.UpdateBudget(context.Background(), UpdateBudgetParams{ID: budget1.ID, ClearEndDate: true})
+	require.NoError(t, err)
+	require.Nil(t, budget4.EndDate)
 }
 
 func TestDeleteBudget(t *testing.T) {
@@ -98,3 +125,40 @@ func TestDeleteBudget(t *testing.T) {
 	require.Error(t, err)
 	require.Empty(t, budget2)
 }
+
+func TestGetBudgetProgress(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	otherCategory := CreateRandomCategory(t, user)
+	budget := CreateRandomBudget(t, wallet, category)
+
+	var spent int64
+	for i := 0; i < 3; i++ {
+		expense := CreateRandomExpense(t, wallet, category)
+		spent += expense.Amount
+	}
+	CreateRandomExpense(t, wallet, otherCategory)
+
+	now := time.Now()
+	arg := GetBudgetProgressParams{
+		ID:          budget.ID,
+		PeriodStart: now.Add(-time.Hour),
+		PeriodEnd:   now.Add(time.Hour),
+		At:          now,
+	}
+	progress, err := testQueries.GetBudgetProgress(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, budget.Amount, progress.Planned)
+	require.Equal(t, spent, progress.Spent)
+	require.Equal(t, budget.Amount-spent, progress.Remaining)
+	require.InDelta(t, float64(spent)*100/float64(budget.Amount), progress.PercentUsed, 0.01)
+	require.InDelta(t, spent*2, progress.Projected, float64(spent)/100)
+
+	arg.PeriodStart = now.Add(time.Hour)
+	arg.PeriodEnd = now.Add(2 * time.Hour)
+	progress, err = testQueries.GetBudgetProgress(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, progress.Spent)
+	require.Equal(t, budget.Amount, progress.Remaining)
+}
@@ -0,0 +1,137 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: categorization_rule.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createCategorizationRule = `-- name: CreateCategorizationRule :one
+INSERT INTO categorization_rules (
+  owner,
+  category_id,
+  priority,
+  match_type,
+  pattern,
+  min_amount,
+  max_amount,
+  wallet_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, owner, category_id, priority, match_type, pattern, min_amount, max_amount, wallet_id, created_at
+`
+
+type CreateCategorizationRuleParams struct {
+	Owner      string  `json:"owner"`
+	CategoryID int64   `json:"category_id"`
+	Priority   int32   `json:"priority"`
+	MatchType  string  `json:"match_type"`
+	Pattern    *string `json:"pattern"`
+	MinAmount  *int64  `json:"min_amount"`
+	MaxAmount  *int64  `json:"max_amount"`
+	WalletID   *int64  `json:"wallet_id"`
+}
+
+func (q *Queries) CreateCategorizationRule(ctx context.Context, arg CreateCategorizationRuleParams) (CategorizationRule, error) {
+	row := q.queryRow(ctx, q.createCategorizationRuleStmt, createCategorizationRule,
+		arg.Owner,
+		arg.CategoryID,
+		arg.Priority,
+		arg.MatchType,
+		arg.Pattern,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.WalletID,
+	)
+	var i CategorizationRule
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.CategoryID,
+		&i.Priority,
+		&i.MatchType,
+		&i.Pattern,
+		&i.MinAmount,
+		&i.MaxAmount,
+		&i.WalletID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteCategorizationRule = `-- name: DeleteCategorizationRule :exec
+DELETE FROM categorization_rules
+WHERE id = $1
+`
+
+func (q *Queries) DeleteCategorizationRule(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteCategorizationRuleStmt, deleteCategorizationRule, id)
+	return err
+}
+
+const getCategorizationRule = `-- name: GetCategorizationRule :one
+SELECT id, owner, category_id, priority, match_type, pattern, min_amount, max_amount, wallet_id, created_at FROM categorization_rules
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetCategorizationRule(ctx context.Context, id int64) (CategorizationRule, error) {
+	row := q.queryRow(ctx, q.getCategorizationRuleStmt, getCategorizationRule, id)
+	var i CategorizationRule
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.CategoryID,
+		&i.Priority,
+		&i.MatchType,
+		&i.Pattern,
+		&i.MinAmount,
+		&i.MaxAmount,
+		&i.WalletID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listCategorizationRules = `-- name: ListCategorizationRules :many
+SELECT id, owner, category_id, priority, match_type, pattern, min_amount, max_amount, wallet_id, created_at FROM categorization_rules
+WHERE owner = $1
+ORDER BY priority DESC, id
+`
+
+func (q *Queries) ListCategorizationRules(ctx context.Context, owner string) ([]CategorizationRule, error) {
+	rows, err := q.query(ctx, q.listCategorizationRulesStmt, listCategorizationRules, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CategorizationRule{}
+	for rows.Next() {
+		var i CategorizationRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.CategoryID,
+			&i.Priority,
+			&i.MatchType,
+			&i.Pattern,
+			&i.MinAmount,
+			&i.MaxAmount,
+			&i.WalletID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func CreateRandomCategorizationRule(t *testing.T, user User, category Category, priority int32) CategorizationRule {
+	pattern := util.RandomString(6)
+	arg := CreateCategorizationRuleParams{
+		Owner:      user.Username,
+		CategoryID: category.ID,
+		Priority:   priority,
+		MatchType:  "substring",
+		Pattern:    &pattern,
+	}
+
+	rule, err := testQueries.CreateCategorizationRule(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, rule.ID)
+	require.Equal(t, arg.Owner, rule.Owner)
+	require.Equal(t, arg.CategoryID, rule.CategoryID)
+	require.Equal(t, arg.Priority, rule.Priority)
+	require.Equal(t, arg.Pattern, rule.Pattern)
+	require.Nil(t, rule.WalletID)
+	return rule
+}
+
+func TestListCategorizationRules(t *testing.T) {
+	user := CreateRandomUser(t)
+	category := CreateRandomCategory(t, user)
+	low := CreateRandomCategorizationRule(t, user, category, 0)
+	high := CreateRandomCategorizationRule(t, user, category, 10)
+
+	rules, err := testQueries.ListCategorizationRules(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, high.ID, rules[0].ID)
+	require.Equal(t, low.ID, rules[1].ID)
+
+	err = testQueries.DeleteCategorizationRule(context.Background(), high.ID)
+	require.NoError(t, err)
+	_, err = testQueries.GetCategorizationRule(context.Background(), high.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCategorizationRuleNeedsCondition(t *testing.T) {
+	user := CreateRandomUser(t)
+	category := CreateRandomCategory(t, user)
+
+	_, err := testQueries.CreateCategorizationRule(context.Background(), CreateCategorizationRuleParams{
+		Owner:      user.Username,
+		CategoryID: category.ID,
+		MatchType:  "substring",
+	})
+	require.Error(t, err)
+}
+
+func TestGetOrCreateCategory(t *testing.T) {
+	user := CreateRandomUser(t)
+	arg := GetOrCreateCategoryParams{Name: "Uncategorized", Owner: user.Username}
+
+	created, err := testQueries.GetOrCreateCategory(context.Background(), arg)
+	require.NoError(t, err)
+	existing, err := testQueries.GetOrCreateCategory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, existing.ID)
+}
+
+func TestSetExpenseCategories(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	uncategorized := CreateRandomCategory(t, user)
+	food := CreateRandomCategory(t, user)
+	travel := CreateRandomCategory(t, user)
+
+	expense1 := CreateRandomExpense(t, wallet, uncategorized)
+	expense2 := CreateRandomExpense(t, wallet, uncategorized)
+	// already moved by the user, must not be touched
+	expense3 := CreateRandomExpense(t, wallet, travel)
+
+	expenses, err := testQueries.ListCategoryExpenses(context.Background(), ListCategoryExpensesParams{
+		CategoryID: uncategorized.ID,
+		WalletID:   sql.NullInt64{Int64: wallet.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, expenses, 2)
+
+	n, err := testQueries.SetExpenseCategories(context.Background(), SetExpenseCategoriesParams{
+		Ids:            []int64{expense1.ID, expense2.ID, expense3.ID},
+		CategoryIds:    []int64{food.ID, travel.ID, food.ID},
+		FromCategoryID: uncategorized.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	for id, categoryID := range map[int64]int64{expense1.ID: food.ID, expense2.ID: travel.ID, expense3.ID: travel.ID} {
+		expense, err := testQueries.GetExpense(context.Background(), id)
+		require.NoError(t, err)
+		require.Equal(t, categoryID, expense.CategoryID)
+	}
+}
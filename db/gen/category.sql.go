@@ -7,6 +7,8 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createCategory = `-- name: CreateCategory :one
@@ -96,20 +98,99 @@ func (q *Queries) GetCategoryByID(ctx context.Context, id int64) (Category, erro
 	return i, err
 }
 
+const getOrCreateCategory = `-- name: GetOrCreateCategory :one
+INSERT INTO categories (
+  name,
+  owner
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (owner, name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id, name, owner, created_at
+`
+
+type GetOrCreateCategoryParams struct {
+	Name  string `json:"name"`
+	Owner string `json:"owner"`
+}
+
+func (q *Queries) GetOrCreateCategory(ctx context.Context, arg GetOrCreateCategoryParams) (Category, error) {
+	row := q.queryRow(ctx, q.getOrCreateCategoryStmt, getOrCreateCategory, arg.Name, arg.Owner)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Owner,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listCategories = `-- name: ListCategories :many
+SELECT id, name, owner, created_at FROM categories
+WHERE owner = $1
+  AND ($2::bigint IS NULL
+    OR (created_at, id) > ($3::timestamptz, $2))
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListCategoriesParams struct {
+	Owner           string        `json:"owner"`
+	CursorID        sql.NullInt64 `json:"cursor_id"`
+	CursorCreatedAt time.Time     `json:"cursor_created_at"`
+	Limit           int32         `json:"limit"`
+}
+
+func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error) {
+	rows, err := q.query(ctx, q.listCategoriesStmt, listCategories,
+		arg.Owner,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Category{}
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Owner,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateCategory = `-- name: UpdateCategory :one
-UPDATE categories 
-SET name = $2 
-WHERE id = $1
+UPDATE categories
+SET
+    name = COALESCE($1, name)
+WHERE
+    id = $2
 RETURNING id, name, owner, created_at
 `
 
 type UpdateCategoryParams struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	Name sql.NullString `json:"name"`
+	ID   int64          `json:"id"`
 }
 
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
-	row := q.queryRow(ctx, q.updateCategoryStmt, updateCategory, arg.ID, arg.Name)
+	row := q.queryRow(ctx, q.updateCategoryStmt, updateCategory, arg.Name, arg.ID)
 	var i Category
 	err := row.Scan(
 		&i.ID,
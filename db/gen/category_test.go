@@ -56,17 +56,44 @@ func TestGetAllCategories(t *testing.T) {
 	}
 }
 
+func TestListCategories(t *testing.T) {
+	user := CreateRandomUser(t)
+	for i := 0; i < 6; i++ {
+		CreateRandomCategory(t, user)
+	}
+
+	arg := ListCategoriesParams{
+		Owner: user.Username,
+		Limit: 4,
+	}
+	categories, err := testQueries.ListCategories(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, categories, 4)
+
+	last := categories[len(categories)-1]
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+	arg.CursorCreatedAt = last.CreatedAt
+	nextCategories, err := testQueries.ListCategories(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, nextCategories, 2)
+	for _, category := range nextCategories {
+		require.Equal(t, user.Username, category.Owner)
+		require.Greater(t, category.ID, last.ID)
+	}
+}
+
 func TestUpdateCategory(t *testing.T) {
 	category1 := CreateRandomCategory(t, CreateRandomUser(t))
 	arg := UpdateCategoryParams{
 		ID:   category1.ID,
-		Name: util.RandomString(6),
+		Name: sql.NullString{String: util.RandomString(6), Valid: true},
 	}
 	category2, err := testQueries.UpdateCategory(context.Background(), arg)
 	require.NoError(t, err)
 	require.NotEmpty(t, category2)
 	require.Equal(t, category1.ID, category2.ID)
-	require.Equal(t, arg.Name, category2.Name)
+	require.Equal(t, arg.Name.String, category2.Name)
+	require.Equal(t, category1.Owner, category2.Owner)
 	require.WithinDuration(t, category1.CreatedAt, category2.CreatedAt, time.Second)
 }
 
@@ -98,4 +125,12 @@ func TestCreateCategoryUniqueness(t *testing.T) {
 	category2, err := testQueries.CreateCategory(context.Background(), arg2)
 	require.Error(t, err) // Expecting an error due to unique constraint violation
 	require.Empty(t, category2)
+
+	// names are only unique per owner
+	category3, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		Name:  categoryName,
+		Owner: CreateRandomUser(t).Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, categoryName, category3.Name)
 }
@@ -24,60 +24,315 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addExpenseTagStmt, err = db.PrepareContext(ctx, addExpenseTag); err != nil {
+		return nil, fmt.Errorf("error preparing query AddExpenseTag: %w", err)
+	}
+	if q.addWalletBalanceStmt, err = db.PrepareContext(ctx, addWalletBalance); err != nil {
+		return nil, fmt.Errorf("error preparing query AddWalletBalance: %w", err)
+	}
+	if q.blockSessionStmt, err = db.PrepareContext(ctx, blockSession); err != nil {
+		return nil, fmt.Errorf("error preparing query BlockSession: %w", err)
+	}
+	if q.blockUserSessionsStmt, err = db.PrepareContext(ctx, blockUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query BlockUserSessions: %w", err)
+	}
+	if q.createAttachmentStmt, err = db.PrepareContext(ctx, createAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAttachment: %w", err)
+	}
 	if q.createBudgetStmt, err = db.PrepareContext(ctx, createBudget); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateBudget: %w", err)
 	}
+	if q.createCategorizationRuleStmt, err = db.PrepareContext(ctx, createCategorizationRule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCategorizationRule: %w", err)
+	}
 	if q.createCategoryStmt, err = db.PrepareContext(ctx, createCategory); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCategory: %w", err)
 	}
 	if q.createExpenseStmt, err = db.PrepareContext(ctx, createExpense); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateExpense: %w", err)
 	}
+	if q.createExpenseOccurrenceStmt, err = db.PrepareContext(ctx, createExpenseOccurrence); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateExpenseOccurrence: %w", err)
+	}
+	if q.createExpenseSplitStmt, err = db.PrepareContext(ctx, createExpenseSplit); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateExpenseSplit: %w", err)
+	}
+	if q.createImportedExpenseStmt, err = db.PrepareContext(ctx, createImportedExpense); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateImportedExpense: %w", err)
+	}
+	if q.createIncomeStmt, err = db.PrepareContext(ctx, createIncome); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateIncome: %w", err)
+	}
+	if q.createRecurringExpenseStmt, err = db.PrepareContext(ctx, createRecurringExpense); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRecurringExpense: %w", err)
+	}
+	if q.createSessionStmt, err = db.PrepareContext(ctx, createSession); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSession: %w", err)
+	}
+	if q.createTagStmt, err = db.PrepareContext(ctx, createTag); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTag: %w", err)
+	}
+	if q.createTransferStmt, err = db.PrepareContext(ctx, createTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTransfer: %w", err)
+	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
 	if q.createWalletStmt, err = db.PrepareContext(ctx, createWallet); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateWallet: %w", err)
 	}
+	if q.deleteAttachmentStmt, err = db.PrepareContext(ctx, deleteAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAttachment: %w", err)
+	}
 	if q.deleteBudgetStmt, err = db.PrepareContext(ctx, deleteBudget); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteBudget: %w", err)
 	}
+	if q.deleteCategorizationRuleStmt, err = db.PrepareContext(ctx, deleteCategorizationRule); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCategorizationRule: %w", err)
+	}
 	if q.deleteCategoryStmt, err = db.PrepareContext(ctx, deleteCategory); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCategory: %w", err)
 	}
 	if q.deleteExpenseStmt, err = db.PrepareContext(ctx, deleteExpense); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteExpense: %w", err)
 	}
+	if q.deleteExpenseAttachmentsStmt, err = db.PrepareContext(ctx, deleteExpenseAttachments); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpenseAttachments: %w", err)
+	}
+	if q.deleteExpenseSplitsStmt, err = db.PrepareContext(ctx, deleteExpenseSplits); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpenseSplits: %w", err)
+	}
+	if q.deleteExpiredRevokedTokensStmt, err = db.PrepareContext(ctx, deleteExpiredRevokedTokens); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredRevokedTokens: %w", err)
+	}
+	if q.deleteIncomeStmt, err = db.PrepareContext(ctx, deleteIncome); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIncome: %w", err)
+	}
+	if q.deleteRecurringExpenseStmt, err = db.PrepareContext(ctx, deleteRecurringExpense); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRecurringExpense: %w", err)
+	}
+	if q.deleteTagStmt, err = db.PrepareContext(ctx, deleteTag); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTag: %w", err)
+	}
 	if q.deleteWalletStmt, err = db.PrepareContext(ctx, deleteWallet); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteWallet: %w", err)
 	}
+	if q.exportTransactionsStmt, err = db.PrepareContext(ctx, exportTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query ExportTransactions: %w", err)
+	}
 	if q.getAllCategoriesStmt, err = db.PrepareContext(ctx, getAllCategories); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllCategories: %w", err)
 	}
+	if q.getAttachmentStmt, err = db.PrepareContext(ctx, getAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAttachment: %w", err)
+	}
 	if q.getBudgetByIDStmt, err = db.PrepareContext(ctx, getBudgetByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetBudgetByID: %w", err)
 	}
+	if q.getBudgetProgressStmt, err = db.PrepareContext(ctx, getBudgetProgress); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBudgetProgress: %w", err)
+	}
+	if q.getCategorizationRuleStmt, err = db.PrepareContext(ctx, getCategorizationRule); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCategorizationRule: %w", err)
+	}
 	if q.getCategoryByIDStmt, err = db.PrepareContext(ctx, getCategoryByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCategoryByID: %w", err)
 	}
+	if q.getDueRecurringExpenseForUpdateStmt, err = db.PrepareContext(ctx, getDueRecurringExpenseForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDueRecurringExpenseForUpdate: %w", err)
+	}
+	if q.getExchangeRateStmt, err = db.PrepareContext(ctx, getExchangeRate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetExchangeRate: %w", err)
+	}
 	if q.getExpenseStmt, err = db.PrepareContext(ctx, getExpense); err != nil {
 		return nil, fmt.Errorf("error preparing query GetExpense: %w", err)
 	}
+	if q.getExpenseForDeleteStmt, err = db.PrepareContext(ctx, getExpenseForDelete); err != nil {
+		return nil, fmt.Errorf("error preparing query GetExpenseForDelete: %w", err)
+	}
+	if q.getExpenseForUpdateStmt, err = db.PrepareContext(ctx, getExpenseForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetExpenseForUpdate: %w", err)
+	}
+	if q.getIncomeStmt, err = db.PrepareContext(ctx, getIncome); err != nil {
+		return nil, fmt.Errorf("error preparing query GetIncome: %w", err)
+	}
+	if q.getIncomeForUpdateStmt, err = db.PrepareContext(ctx, getIncomeForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetIncomeForUpdate: %w", err)
+	}
+	if q.getMonthlyCategoryReportStmt, err = db.PrepareContext(ctx, getMonthlyCategoryReport); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMonthlyCategoryReport: %w", err)
+	}
+	if q.getOrCreateCategoryStmt, err = db.PrepareContext(ctx, getOrCreateCategory); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrCreateCategory: %w", err)
+	}
+	if q.getRecurringExpenseStmt, err = db.PrepareContext(ctx, getRecurringExpense); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRecurringExpense: %w", err)
+	}
+	if q.getSessionStmt, err = db.PrepareContext(ctx, getSession); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSession: %w", err)
+	}
+	if q.getSystemStatsStmt, err = db.PrepareContext(ctx, getSystemStats); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSystemStats: %w", err)
+	}
+	if q.getTagStmt, err = db.PrepareContext(ctx, getTag); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTag: %w", err)
+	}
+	if q.getTimeSeriesStmt, err = db.PrepareContext(ctx, getTimeSeries); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTimeSeries: %w", err)
+	}
+	if q.getTokenStatusStmt, err = db.PrepareContext(ctx, getTokenStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTokenStatus: %w", err)
+	}
+	if q.getTransferStmt, err = db.PrepareContext(ctx, getTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransfer: %w", err)
+	}
 	if q.getUserStmt, err = db.PrepareContext(ctx, getUser); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUser: %w", err)
 	}
 	if q.getWalletStmt, err = db.PrepareContext(ctx, getWallet); err != nil {
 		return nil, fmt.Errorf("error preparing query GetWallet: %w", err)
 	}
+	if q.getWalletForUpdateStmt, err = db.PrepareContext(ctx, getWalletForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWalletForUpdate: %w", err)
+	}
+	if q.listActiveSessionsStmt, err = db.PrepareContext(ctx, listActiveSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveSessions: %w", err)
+	}
 	if q.listBudgetsStmt, err = db.PrepareContext(ctx, listBudgets); err != nil {
 		return nil, fmt.Errorf("error preparing query ListBudgets: %w", err)
 	}
-	if q.listExpensesStmt, err = db.PrepareContext(ctx, listExpenses); err != nil {
-		return nil, fmt.Errorf("error preparing query ListExpenses: %w", err)
+	if q.listBudgetsByWalletStmt, err = db.PrepareContext(ctx, listBudgetsByWallet); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBudgetsByWallet: %w", err)
+	}
+	if q.listCategoriesStmt, err = db.PrepareContext(ctx, listCategories); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCategories: %w", err)
+	}
+	if q.listCategorizationRulesStmt, err = db.PrepareContext(ctx, listCategorizationRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCategorizationRules: %w", err)
+	}
+	if q.listCategoryExpensesStmt, err = db.PrepareContext(ctx, listCategoryExpenses); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCategoryExpenses: %w", err)
+	}
+	if q.listExchangeRatesForCurrenciesStmt, err = db.PrepareContext(ctx, listExchangeRatesForCurrencies); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExchangeRatesForCurrencies: %w", err)
+	}
+	if q.listExpenseAttachmentsStmt, err = db.PrepareContext(ctx, listExpenseAttachments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExpenseAttachments: %w", err)
+	}
+	if q.listExpenseSplitsStmt, err = db.PrepareContext(ctx, listExpenseSplits); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExpenseSplits: %w", err)
+	}
+	if q.listExpenseTagsStmt, err = db.PrepareContext(ctx, listExpenseTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExpenseTags: %w", err)
+	}
+	if q.listExpensesByAmountAscStmt, err = db.PrepareContext(ctx, listExpensesByAmountAsc); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExpensesByAmountAsc: %w", err)
+	}
+	if q.listExpensesByAmountDescStmt, err = db.PrepareContext(ctx, listExpensesByAmountDesc); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExpensesByAmountDesc: %w", err)
+	}
+	if q.listExpensesByDateAscStmt, err = db.PrepareContext(ctx, listExpensesByDateAsc); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExpensesByDateAsc: %w", err)
+	}
+	if q.listExpensesByDateDescStmt, err = db.PrepareContext(ctx, listExpensesByDateDesc); err != nil {
+		return nil, fmt.Errorf("error preparing query ListExpensesByDateDesc: %w", err)
+	}
+	if q.listIncomesStmt, err = db.PrepareContext(ctx, listIncomes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListIncomes: %w", err)
+	}
+	if q.listOwnerBudgetsStmt, err = db.PrepareContext(ctx, listOwnerBudgets); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnerBudgets: %w", err)
+	}
+	if q.listOwnerCategoriesStmt, err = db.PrepareContext(ctx, listOwnerCategories); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnerCategories: %w", err)
+	}
+	if q.listOwnerExpenseSplitsStmt, err = db.PrepareContext(ctx, listOwnerExpenseSplits); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnerExpenseSplits: %w", err)
+	}
+	if q.listOwnerExpenseTagsStmt, err = db.PrepareContext(ctx, listOwnerExpenseTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnerExpenseTags: %w", err)
+	}
+	if q.listOwnerExpensesStmt, err = db.PrepareContext(ctx, listOwnerExpenses); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnerExpenses: %w", err)
+	}
+	if q.listOwnerIncomesStmt, err = db.PrepareContext(ctx, listOwnerIncomes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnerIncomes: %w", err)
+	}
+	if q.listOwnerTagsStmt, err = db.PrepareContext(ctx, listOwnerTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnerTags: %w", err)
+	}
+	if q.listOwnerTransfersStmt, err = db.PrepareContext(ctx, listOwnerTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnerTransfers: %w", err)
+	}
+	if q.listOwnerWalletsStmt, err = db.PrepareContext(ctx, listOwnerWallets); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnerWallets: %w", err)
+	}
+	if q.listRecurringExpensesStmt, err = db.PrepareContext(ctx, listRecurringExpenses); err != nil {
+		return nil, fmt.Errorf("error preparing query ListRecurringExpenses: %w", err)
+	}
+	if q.listTagsStmt, err = db.PrepareContext(ctx, listTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTags: %w", err)
+	}
+	if q.listTransfersStmt, err = db.PrepareContext(ctx, listTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTransfers: %w", err)
+	}
+	if q.listUsersStmt, err = db.PrepareContext(ctx, listUsers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUsers: %w", err)
+	}
+	if q.listWalletExpenseExternalIDsStmt, err = db.PrepareContext(ctx, listWalletExpenseExternalIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWalletExpenseExternalIDs: %w", err)
+	}
+	if q.listWalletExpensesBetweenStmt, err = db.PrepareContext(ctx, listWalletExpensesBetween); err != nil {
+		return nil, fmt.Errorf("error preparing query ListWalletExpensesBetween: %w", err)
 	}
 	if q.listWalletsStmt, err = db.PrepareContext(ctx, listWallets); err != nil {
 		return nil, fmt.Errorf("error preparing query ListWallets: %w", err)
 	}
+	if q.removeExpenseTagStmt, err = db.PrepareContext(ctx, removeExpenseTag); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveExpenseTag: %w", err)
+	}
+	if q.restoreBudgetStmt, err = db.PrepareContext(ctx, restoreBudget); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreBudget: %w", err)
+	}
+	if q.restoreCategoryStmt, err = db.PrepareContext(ctx, restoreCategory); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreCategory: %w", err)
+	}
+	if q.restoreExpenseStmt, err = db.PrepareContext(ctx, restoreExpense); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreExpense: %w", err)
+	}
+	if q.restoreExpenseSplitStmt, err = db.PrepareContext(ctx, restoreExpenseSplit); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreExpenseSplit: %w", err)
+	}
+	if q.restoreExpenseTagStmt, err = db.PrepareContext(ctx, restoreExpenseTag); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreExpenseTag: %w", err)
+	}
+	if q.restoreIncomeStmt, err = db.PrepareContext(ctx, restoreIncome); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreIncome: %w", err)
+	}
+	if q.restoreTagStmt, err = db.PrepareContext(ctx, restoreTag); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreTag: %w", err)
+	}
+	if q.restoreTransferStmt, err = db.PrepareContext(ctx, restoreTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreTransfer: %w", err)
+	}
+	if q.restoreWalletStmt, err = db.PrepareContext(ctx, restoreWallet); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreWallet: %w", err)
+	}
+	if q.revokeTokenStmt, err = db.PrepareContext(ctx, revokeToken); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeToken: %w", err)
+	}
+	if q.revokeUserTokensStmt, err = db.PrepareContext(ctx, revokeUserTokens); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeUserTokens: %w", err)
+	}
+	if q.setExpenseCategoriesStmt, err = db.PrepareContext(ctx, setExpenseCategories); err != nil {
+		return nil, fmt.Errorf("error preparing query SetExpenseCategories: %w", err)
+	}
+	if q.setUserDisabledStmt, err = db.PrepareContext(ctx, setUserDisabled); err != nil {
+		return nil, fmt.Errorf("error preparing query SetUserDisabled: %w", err)
+	}
+	if q.setUserRoleStmt, err = db.PrepareContext(ctx, setUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query SetUserRole: %w", err)
+	}
 	if q.updateBudgetStmt, err = db.PrepareContext(ctx, updateBudget); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateBudget: %w", err)
 	}
@@ -87,19 +342,64 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateExpenseStmt, err = db.PrepareContext(ctx, updateExpense); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateExpense: %w", err)
 	}
+	if q.updateIncomeStmt, err = db.PrepareContext(ctx, updateIncome); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateIncome: %w", err)
+	}
+	if q.updateRecurringExpenseScheduleStmt, err = db.PrepareContext(ctx, updateRecurringExpenseSchedule); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateRecurringExpenseSchedule: %w", err)
+	}
+	if q.updateTagStmt, err = db.PrepareContext(ctx, updateTag); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTag: %w", err)
+	}
 	if q.updateUserStmt, err = db.PrepareContext(ctx, updateUser); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUser: %w", err)
 	}
+	if q.updateWalletStmt, err = db.PrepareContext(ctx, updateWallet); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateWallet: %w", err)
+	}
+	if q.upsertExchangeRateStmt, err = db.PrepareContext(ctx, upsertExchangeRate); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertExchangeRate: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addExpenseTagStmt != nil {
+		if cerr := q.addExpenseTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addExpenseTagStmt: %w", cerr)
+		}
+	}
+	if q.addWalletBalanceStmt != nil {
+		if cerr := q.addWalletBalanceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addWalletBalanceStmt: %w", cerr)
+		}
+	}
+	if q.blockSessionStmt != nil {
+		if cerr := q.blockSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing blockSessionStmt: %w", cerr)
+		}
+	}
+	if q.blockUserSessionsStmt != nil {
+		if cerr := q.blockUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing blockUserSessionsStmt: %w", cerr)
+		}
+	}
+	if q.createAttachmentStmt != nil {
+		if cerr := q.createAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAttachmentStmt: %w", cerr)
+		}
+	}
 	if q.createBudgetStmt != nil {
 		if cerr := q.createBudgetStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createBudgetStmt: %w", cerr)
 		}
 	}
+	if q.createCategorizationRuleStmt != nil {
+		if cerr := q.createCategorizationRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCategorizationRuleStmt: %w", cerr)
+		}
+	}
 	if q.createCategoryStmt != nil {
 		if cerr := q.createCategoryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createCategoryStmt: %w", cerr)
@@ -110,6 +410,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createExpenseStmt: %w", cerr)
 		}
 	}
+	if q.createExpenseOccurrenceStmt != nil {
+		if cerr := q.createExpenseOccurrenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createExpenseOccurrenceStmt: %w", cerr)
+		}
+	}
+	if q.createExpenseSplitStmt != nil {
+		if cerr := q.createExpenseSplitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createExpenseSplitStmt: %w", cerr)
+		}
+	}
+	if q.createImportedExpenseStmt != nil {
+		if cerr := q.createImportedExpenseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createImportedExpenseStmt: %w", cerr)
+		}
+	}
+	if q.createIncomeStmt != nil {
+		if cerr := q.createIncomeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createIncomeStmt: %w", cerr)
+		}
+	}
+	if q.createRecurringExpenseStmt != nil {
+		if cerr := q.createRecurringExpenseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRecurringExpenseStmt: %w", cerr)
+		}
+	}
+	if q.createSessionStmt != nil {
+		if cerr := q.createSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSessionStmt: %w", cerr)
+		}
+	}
+	if q.createTagStmt != nil {
+		if cerr := q.createTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTagStmt: %w", cerr)
+		}
+	}
+	if q.createTransferStmt != nil {
+		if cerr := q.createTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTransferStmt: %w", cerr)
+		}
+	}
 	if q.createUserStmt != nil {
 		if cerr := q.createUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
@@ -120,11 +460,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createWalletStmt: %w", cerr)
 		}
 	}
+	if q.deleteAttachmentStmt != nil {
+		if cerr := q.deleteAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAttachmentStmt: %w", cerr)
+		}
+	}
 	if q.deleteBudgetStmt != nil {
 		if cerr := q.deleteBudgetStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteBudgetStmt: %w", cerr)
 		}
 	}
+	if q.deleteCategorizationRuleStmt != nil {
+		if cerr := q.deleteCategorizationRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCategorizationRuleStmt: %w", cerr)
+		}
+	}
 	if q.deleteCategoryStmt != nil {
 		if cerr := q.deleteCategoryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteCategoryStmt: %w", cerr)
@@ -135,31 +485,156 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteExpenseStmt: %w", cerr)
 		}
 	}
+	if q.deleteExpenseAttachmentsStmt != nil {
+		if cerr := q.deleteExpenseAttachmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpenseAttachmentsStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpenseSplitsStmt != nil {
+		if cerr := q.deleteExpenseSplitsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpenseSplitsStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredRevokedTokensStmt != nil {
+		if cerr := q.deleteExpiredRevokedTokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredRevokedTokensStmt: %w", cerr)
+		}
+	}
+	if q.deleteIncomeStmt != nil {
+		if cerr := q.deleteIncomeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIncomeStmt: %w", cerr)
+		}
+	}
+	if q.deleteRecurringExpenseStmt != nil {
+		if cerr := q.deleteRecurringExpenseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRecurringExpenseStmt: %w", cerr)
+		}
+	}
+	if q.deleteTagStmt != nil {
+		if cerr := q.deleteTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTagStmt: %w", cerr)
+		}
+	}
 	if q.deleteWalletStmt != nil {
 		if cerr := q.deleteWalletStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteWalletStmt: %w", cerr)
 		}
 	}
+	if q.exportTransactionsStmt != nil {
+		if cerr := q.exportTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing exportTransactionsStmt: %w", cerr)
+		}
+	}
 	if q.getAllCategoriesStmt != nil {
 		if cerr := q.getAllCategoriesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAllCategoriesStmt: %w", cerr)
 		}
 	}
+	if q.getAttachmentStmt != nil {
+		if cerr := q.getAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAttachmentStmt: %w", cerr)
+		}
+	}
 	if q.getBudgetByIDStmt != nil {
 		if cerr := q.getBudgetByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getBudgetByIDStmt: %w", cerr)
 		}
 	}
+	if q.getBudgetProgressStmt != nil {
+		if cerr := q.getBudgetProgressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBudgetProgressStmt: %w", cerr)
+		}
+	}
+	if q.getCategorizationRuleStmt != nil {
+		if cerr := q.getCategorizationRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCategorizationRuleStmt: %w", cerr)
+		}
+	}
 	if q.getCategoryByIDStmt != nil {
 		if cerr := q.getCategoryByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getCategoryByIDStmt: %w", cerr)
 		}
 	}
+	if q.getDueRecurringExpenseForUpdateStmt != nil {
+		if cerr := q.getDueRecurringExpenseForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDueRecurringExpenseForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getExchangeRateStmt != nil {
+		if cerr := q.getExchangeRateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getExchangeRateStmt: %w", cerr)
+		}
+	}
 	if q.getExpenseStmt != nil {
 		if cerr := q.getExpenseStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getExpenseStmt: %w", cerr)
 		}
 	}
+	if q.getExpenseForDeleteStmt != nil {
+		if cerr := q.getExpenseForDeleteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getExpenseForDeleteStmt: %w", cerr)
+		}
+	}
+	if q.getExpenseForUpdateStmt != nil {
+		if cerr := q.getExpenseForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getExpenseForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getIncomeStmt != nil {
+		if cerr := q.getIncomeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getIncomeStmt: %w", cerr)
+		}
+	}
+	if q.getIncomeForUpdateStmt != nil {
+		if cerr := q.getIncomeForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getIncomeForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getMonthlyCategoryReportStmt != nil {
+		if cerr := q.getMonthlyCategoryReportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMonthlyCategoryReportStmt: %w", cerr)
+		}
+	}
+	if q.getOrCreateCategoryStmt != nil {
+		if cerr := q.getOrCreateCategoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrCreateCategoryStmt: %w", cerr)
+		}
+	}
+	if q.getRecurringExpenseStmt != nil {
+		if cerr := q.getRecurringExpenseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRecurringExpenseStmt: %w", cerr)
+		}
+	}
+	if q.getSessionStmt != nil {
+		if cerr := q.getSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSessionStmt: %w", cerr)
+		}
+	}
+	if q.getSystemStatsStmt != nil {
+		if cerr := q.getSystemStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSystemStatsStmt: %w", cerr)
+		}
+	}
+	if q.getTagStmt != nil {
+		if cerr := q.getTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTagStmt: %w", cerr)
+		}
+	}
+	if q.getTimeSeriesStmt != nil {
+		if cerr := q.getTimeSeriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTimeSeriesStmt: %w", cerr)
+		}
+	}
+	if q.getTokenStatusStmt != nil {
+		if cerr := q.getTokenStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTokenStatusStmt: %w", cerr)
+		}
+	}
+	if q.getTransferStmt != nil {
+		if cerr := q.getTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransferStmt: %w", cerr)
+		}
+	}
 	if q.getUserStmt != nil {
 		if cerr := q.getUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserStmt: %w", cerr)
@@ -170,14 +645,159 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getWalletStmt: %w", cerr)
 		}
 	}
+	if q.getWalletForUpdateStmt != nil {
+		if cerr := q.getWalletForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWalletForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.listActiveSessionsStmt != nil {
+		if cerr := q.listActiveSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveSessionsStmt: %w", cerr)
+		}
+	}
 	if q.listBudgetsStmt != nil {
 		if cerr := q.listBudgetsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listBudgetsStmt: %w", cerr)
 		}
 	}
-	if q.listExpensesStmt != nil {
-		if cerr := q.listExpensesStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing listExpensesStmt: %w", cerr)
+	if q.listBudgetsByWalletStmt != nil {
+		if cerr := q.listBudgetsByWalletStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBudgetsByWalletStmt: %w", cerr)
+		}
+	}
+	if q.listCategoriesStmt != nil {
+		if cerr := q.listCategoriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCategoriesStmt: %w", cerr)
+		}
+	}
+	if q.listCategorizationRulesStmt != nil {
+		if cerr := q.listCategorizationRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCategorizationRulesStmt: %w", cerr)
+		}
+	}
+	if q.listCategoryExpensesStmt != nil {
+		if cerr := q.listCategoryExpensesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCategoryExpensesStmt: %w", cerr)
+		}
+	}
+	if q.listExchangeRatesForCurrenciesStmt != nil {
+		if cerr := q.listExchangeRatesForCurrenciesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExchangeRatesForCurrenciesStmt: %w", cerr)
+		}
+	}
+	if q.listExpenseAttachmentsStmt != nil {
+		if cerr := q.listExpenseAttachmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExpenseAttachmentsStmt: %w", cerr)
+		}
+	}
+	if q.listExpenseSplitsStmt != nil {
+		if cerr := q.listExpenseSplitsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExpenseSplitsStmt: %w", cerr)
+		}
+	}
+	if q.listExpenseTagsStmt != nil {
+		if cerr := q.listExpenseTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExpenseTagsStmt: %w", cerr)
+		}
+	}
+	if q.listExpensesByAmountAscStmt != nil {
+		if cerr := q.listExpensesByAmountAscStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExpensesByAmountAscStmt: %w", cerr)
+		}
+	}
+	if q.listExpensesByAmountDescStmt != nil {
+		if cerr := q.listExpensesByAmountDescStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExpensesByAmountDescStmt: %w", cerr)
+		}
+	}
+	if q.listExpensesByDateAscStmt != nil {
+		if cerr := q.listExpensesByDateAscStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExpensesByDateAscStmt: %w", cerr)
+		}
+	}
+	if q.listExpensesByDateDescStmt != nil {
+		if cerr := q.listExpensesByDateDescStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listExpensesByDateDescStmt: %w", cerr)
+		}
+	}
+	if q.listIncomesStmt != nil {
+		if cerr := q.listIncomesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listIncomesStmt: %w", cerr)
+		}
+	}
+	if q.listOwnerBudgetsStmt != nil {
+		if cerr := q.listOwnerBudgetsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnerBudgetsStmt: %w", cerr)
+		}
+	}
+	if q.listOwnerCategoriesStmt != nil {
+		if cerr := q.listOwnerCategoriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnerCategoriesStmt: %w", cerr)
+		}
+	}
+	if q.listOwnerExpenseSplitsStmt != nil {
+		if cerr := q.listOwnerExpenseSplitsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnerExpenseSplitsStmt: %w", cerr)
+		}
+	}
+	if q.listOwnerExpenseTagsStmt != nil {
+		if cerr := q.listOwnerExpenseTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnerExpenseTagsStmt: %w", cerr)
+		}
+	}
+	if q.listOwnerExpensesStmt != nil {
+		if cerr := q.listOwnerExpensesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnerExpensesStmt: %w", cerr)
+		}
+	}
+	if q.listOwnerIncomesStmt != nil {
+		if cerr := q.listOwnerIncomesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnerIncomesStmt: %w", cerr)
+		}
+	}
+	if q.listOwnerTagsStmt != nil {
+		if cerr := q.listOwnerTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnerTagsStmt: %w", cerr)
+		}
+	}
+	if q.listOwnerTransfersStmt != nil {
+		if cerr := q.listOwnerTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnerTransfersStmt: %w", cerr)
+		}
+	}
+	if q.listOwnerWalletsStmt != nil {
+		if cerr := q.listOwnerWalletsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnerWalletsStmt: %w", cerr)
+		}
+	}
+	if q.listRecurringExpensesStmt != nil {
+		if cerr := q.listRecurringExpensesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listRecurringExpensesStmt: %w", cerr)
+		}
+	}
+	if q.listTagsStmt != nil {
+		if cerr := q.listTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTagsStmt: %w", cerr)
+		}
+	}
+	if q.listTransfersStmt != nil {
+		if cerr := q.listTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTransfersStmt: %w", cerr)
+		}
+	}
+	if q.listUsersStmt != nil {
+		if cerr := q.listUsersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUsersStmt: %w", cerr)
+		}
+	}
+	if q.listWalletExpenseExternalIDsStmt != nil {
+		if cerr := q.listWalletExpenseExternalIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWalletExpenseExternalIDsStmt: %w", cerr)
+		}
+	}
+	if q.listWalletExpensesBetweenStmt != nil {
+		if cerr := q.listWalletExpensesBetweenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listWalletExpensesBetweenStmt: %w", cerr)
 		}
 	}
 	if q.listWalletsStmt != nil {
@@ -185,6 +805,81 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listWalletsStmt: %w", cerr)
 		}
 	}
+	if q.removeExpenseTagStmt != nil {
+		if cerr := q.removeExpenseTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeExpenseTagStmt: %w", cerr)
+		}
+	}
+	if q.restoreBudgetStmt != nil {
+		if cerr := q.restoreBudgetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreBudgetStmt: %w", cerr)
+		}
+	}
+	if q.restoreCategoryStmt != nil {
+		if cerr := q.restoreCategoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreCategoryStmt: %w", cerr)
+		}
+	}
+	if q.restoreExpenseStmt != nil {
+		if cerr := q.restoreExpenseStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreExpenseStmt: %w", cerr)
+		}
+	}
+	if q.restoreExpenseSplitStmt != nil {
+		if cerr := q.restoreExpenseSplitStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreExpenseSplitStmt: %w", cerr)
+		}
+	}
+	if q.restoreExpenseTagStmt != nil {
+		if cerr := q.restoreExpenseTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreExpenseTagStmt: %w", cerr)
+		}
+	}
+	if q.restoreIncomeStmt != nil {
+		if cerr := q.restoreIncomeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreIncomeStmt: %w", cerr)
+		}
+	}
+	if q.restoreTagStmt != nil {
+		if cerr := q.restoreTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreTagStmt: %w", cerr)
+		}
+	}
+	if q.restoreTransferStmt != nil {
+		if cerr := q.restoreTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreTransferStmt: %w", cerr)
+		}
+	}
+	if q.restoreWalletStmt != nil {
+		if cerr := q.restoreWalletStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreWalletStmt: %w", cerr)
+		}
+	}
+	if q.revokeTokenStmt != nil {
+		if cerr := q.revokeTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeTokenStmt: %w", cerr)
+		}
+	}
+	if q.revokeUserTokensStmt != nil {
+		if cerr := q.revokeUserTokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeUserTokensStmt: %w", cerr)
+		}
+	}
+	if q.setExpenseCategoriesStmt != nil {
+		if cerr := q.setExpenseCategoriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setExpenseCategoriesStmt: %w", cerr)
+		}
+	}
+	if q.setUserDisabledStmt != nil {
+		if cerr := q.setUserDisabledStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setUserDisabledStmt: %w", cerr)
+		}
+	}
+	if q.setUserRoleStmt != nil {
+		if cerr := q.setUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setUserRoleStmt: %w", cerr)
+		}
+	}
 	if q.updateBudgetStmt != nil {
 		if cerr := q.updateBudgetStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateBudgetStmt: %w", cerr)
@@ -200,11 +895,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateExpenseStmt: %w", cerr)
 		}
 	}
+	if q.updateIncomeStmt != nil {
+		if cerr := q.updateIncomeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateIncomeStmt: %w", cerr)
+		}
+	}
+	if q.updateRecurringExpenseScheduleStmt != nil {
+		if cerr := q.updateRecurringExpenseScheduleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateRecurringExpenseScheduleStmt: %w", cerr)
+		}
+	}
+	if q.updateTagStmt != nil {
+		if cerr := q.updateTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTagStmt: %w", cerr)
+		}
+	}
 	if q.updateUserStmt != nil {
 		if cerr := q.updateUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserStmt: %w", cerr)
 		}
 	}
+	if q.updateWalletStmt != nil {
+		if cerr := q.updateWalletStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateWalletStmt: %w", cerr)
+		}
+	}
+	if q.upsertExchangeRateStmt != nil {
+		if cerr := q.upsertExchangeRateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertExchangeRateStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -242,57 +962,237 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                   DBTX
-	tx                   *sql.Tx
-	createBudgetStmt     *sql.Stmt
-	createCategoryStmt   *sql.Stmt
-	createExpenseStmt    *sql.Stmt
-	createUserStmt       *sql.Stmt
-	createWalletStmt     *sql.Stmt
-	deleteBudgetStmt     *sql.Stmt
-	deleteCategoryStmt   *sql.Stmt
-	deleteExpenseStmt    *sql.Stmt
-	deleteWalletStmt     *sql.Stmt
-	getAllCategoriesStmt *sql.Stmt
-	getBudgetByIDStmt    *sql.Stmt
-	getCategoryByIDStmt  *sql.Stmt
-	getExpenseStmt       *sql.Stmt
-	getUserStmt          *sql.Stmt
-	getWalletStmt        *sql.Stmt
-	listBudgetsStmt      *sql.Stmt
-	listExpensesStmt     *sql.Stmt
-	listWalletsStmt      *sql.Stmt
-	updateBudgetStmt     *sql.Stmt
-	updateCategoryStmt   *sql.Stmt
-	updateExpenseStmt    *sql.Stmt
-	updateUserStmt       *sql.Stmt
+	db                                  DBTX
+	tx                                  *sql.Tx
+	addExpenseTagStmt                   *sql.Stmt
+	addWalletBalanceStmt                *sql.Stmt
+	blockSessionStmt                    *sql.Stmt
+	blockUserSessionsStmt               *sql.Stmt
+	createAttachmentStmt                *sql.Stmt
+	createBudgetStmt                    *sql.Stmt
+	createCategorizationRuleStmt        *sql.Stmt
+	createCategoryStmt                  *sql.Stmt
+	createExpenseStmt                   *sql.Stmt
+	createExpenseOccurrenceStmt         *sql.Stmt
+	createExpenseSplitStmt              *sql.Stmt
+	createImportedExpenseStmt           *sql.Stmt
+	createIncomeStmt                    *sql.Stmt
+	createRecurringExpenseStmt          *sql.Stmt
+	createSessionStmt                   *sql.Stmt
+	createTagStmt                       *sql.Stmt
+	createTransferStmt                  *sql.Stmt
+	createUserStmt                      *sql.Stmt
+	createWalletStmt                    *sql.Stmt
+	deleteAttachmentStmt                *sql.Stmt
+	deleteBudgetStmt                    *sql.Stmt
+	deleteCategorizationRuleStmt        *sql.Stmt
+	deleteCategoryStmt                  *sql.Stmt
+	deleteExpenseStmt                   *sql.Stmt
+	deleteExpenseAttachmentsStmt        *sql.Stmt
+	deleteExpenseSplitsStmt             *sql.Stmt
+	deleteExpiredRevokedTokensStmt      *sql.Stmt
+	deleteIncomeStmt                    *sql.Stmt
+	deleteRecurringExpenseStmt          *sql.Stmt
+	deleteTagStmt                       *sql.Stmt
+	deleteWalletStmt                    *sql.Stmt
+	exportTransactionsStmt              *sql.Stmt
+	getAllCategoriesStmt                *sql.Stmt
+	getAttachmentStmt                   *sql.Stmt
+	getBudgetByIDStmt                   *sql.Stmt
+	getBudgetProgressStmt               *sql.Stmt
+	getCategorizationRuleStmt           *sql.Stmt
+	getCategoryByIDStmt                 *sql.Stmt
+	getDueRecurringExpenseForUpdateStmt *sql.Stmt
+	getExchangeRateStmt                 *sql.Stmt
+	getExpenseStmt                      *sql.Stmt
+	getExpenseForDeleteStmt             *sql.Stmt
+	getExpenseForUpdateStmt             *sql.Stmt
+	getIncomeStmt                       *sql.Stmt
+	getIncomeForUpdateStmt              *sql.Stmt
+	getMonthlyCategoryReportStmt        *sql.Stmt
+	getOrCreateCategoryStmt             *sql.Stmt
+	getRecurringExpenseStmt             *sql.Stmt
+	getSessionStmt                      *sql.Stmt
+	getSystemStatsStmt                  *sql.Stmt
+	getTagStmt                          *sql.Stmt
+	getTimeSeriesStmt                   *sql.Stmt
+	getTokenStatusStmt                  *sql.Stmt
+	getTransferStmt                     *sql.Stmt
+	getUserStmt                         *sql.Stmt
+	getWalletStmt                       *sql.Stmt
+	getWalletForUpdateStmt              *sql.Stmt
+	listActiveSessionsStmt              *sql.Stmt
+	listBudgetsStmt                     *sql.Stmt
+	listBudgetsByWalletStmt             *sql.Stmt
+	listCategoriesStmt                  *sql.Stmt
+	listCategorizationRulesStmt         *sql.Stmt
+	listCategoryExpensesStmt            *sql.Stmt
+	listExchangeRatesForCurrenciesStmt  *sql.Stmt
+	listExpenseAttachmentsStmt          *sql.Stmt
+	listExpenseSplitsStmt               *sql.Stmt
+	listExpenseTagsStmt                 *sql.Stmt
+	listExpensesByAmountAscStmt         *sql.Stmt
+	listExpensesByAmountDescStmt        *sql.Stmt
+	listExpensesByDateAscStmt           *sql.Stmt
+	listExpensesByDateDescStmt          *sql.Stmt
+	listIncomesStmt                     *sql.Stmt
+	listOwnerBudgetsStmt                *sql.Stmt
+	listOwnerCategoriesStmt             *sql.Stmt
+	listOwnerExpenseSplitsStmt          *sql.Stmt
+	listOwnerExpenseTagsStmt            *sql.Stmt
+	listOwnerExpensesStmt               *sql.Stmt
+	listOwnerIncomesStmt                *sql.Stmt
+	listOwnerTagsStmt                   *sql.Stmt
+	listOwnerTransfersStmt              *sql.Stmt
+	listOwnerWalletsStmt                *sql.Stmt
+	listRecurringExpensesStmt           *sql.Stmt
+	listTagsStmt                        *sql.Stmt
+	listTransfersStmt                   *sql.Stmt
+	listUsersStmt                       *sql.Stmt
+	listWalletExpenseExternalIDsStmt    *sql.Stmt
+	listWalletExpensesBetweenStmt       *sql.Stmt
+	listWalletsStmt                     *sql.Stmt
+	removeExpenseTagStmt                *sql.Stmt
+	restoreBudgetStmt                   *sql.Stmt
+	restoreCategoryStmt                 *sql.Stmt
+	restoreExpenseStmt                  *sql.Stmt
+	restoreExpenseSplitStmt             *sql.Stmt
+	restoreExpenseTagStmt               *sql.Stmt
+	restoreIncomeStmt                   *sql.Stmt
+	restoreTagStmt                      *sql.Stmt
+	restoreTransferStmt                 *sql.Stmt
+	restoreWalletStmt                   *sql.Stmt
+	revokeTokenStmt                     *sql.Stmt
+	revokeUserTokensStmt                *sql.Stmt
+	setExpenseCategoriesStmt            *sql.Stmt
+	setUserDisabledStmt                 *sql.Stmt
+	setUserRoleStmt                     *sql.Stmt
+	updateBudgetStmt                    *sql.Stmt
+	updateCategoryStmt                  *sql.Stmt
+	updateExpenseStmt                   *sql.Stmt
+	updateIncomeStmt                    *sql.Stmt
+	updateRecurringExpenseScheduleStmt  *sql.Stmt
+	updateTagStmt                       *sql.Stmt
+	updateUserStmt                      *sql.Stmt
+	updateWalletStmt                    *sql.Stmt
+	upsertExchangeRateStmt              *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                   tx,
-		tx:                   tx,
-		createBudgetStmt:     q.createBudgetStmt,
-		createCategoryStmt:   q.createCategoryStmt,
-		createExpenseStmt:    q.createExpenseStmt,
-		createUserStmt:       q.createUserStmt,
-		createWalletStmt:     q.createWalletStmt,
-		deleteBudgetStmt:     q.deleteBudgetStmt,
-		deleteCategoryStmt:   q.deleteCategoryStmt,
-		deleteExpenseStmt:    q.deleteExpenseStmt,
-		deleteWalletStmt:     q.deleteWalletStmt,
-		getAllCategoriesStmt: q.getAllCategoriesStmt,
-		getBudgetByIDStmt:    q.getBudgetByIDStmt,
-		getCategoryByIDStmt:  q.getCategoryByIDStmt,
-		getExpenseStmt:       q.getExpenseStmt,
-		getUserStmt:          q.getUserStmt,
-		getWalletStmt:        q.getWalletStmt,
-		listBudgetsStmt:      q.listBudgetsStmt,
-		listExpensesStmt:     q.listExpensesStmt,
-		listWalletsStmt:      q.listWalletsStmt,
-		updateBudgetStmt:     q.updateBudgetStmt,
-		updateCategoryStmt:   q.updateCategoryStmt,
-		updateExpenseStmt:    q.updateExpenseStmt,
-		updateUserStmt:       q.updateUserStmt,
+		db:                                  tx,
+		tx:                                  tx,
+		addExpenseTagStmt:                   q.addExpenseTagStmt,
+		addWalletBalanceStmt:                q.addWalletBalanceStmt,
+		blockSessionStmt:                    q.blockSessionStmt,
+		blockUserSessionsStmt:               q.blockUserSessionsStmt,
+		createAttachmentStmt:                q.createAttachmentStmt,
+		createBudgetStmt:                    q.createBudgetStmt,
+		createCategorizationRuleStmt:        q.createCategorizationRuleStmt,
+		createCategoryStmt:                  q.createCategoryStmt,
+		createExpenseStmt:                   q.createExpenseStmt,
+		createExpenseOccurrenceStmt:         q.createExpenseOccurrenceStmt,
+		createExpenseSplitStmt:              q.createExpenseSplitStmt,
+		createImportedExpenseStmt:           q.createImportedExpenseStmt,
+		createIncomeStmt:                    q.createIncomeStmt,
+		createRecurringExpenseStmt:          q.createRecurringExpenseStmt,
+		createSessionStmt:                   q.createSessionStmt,
+		createTagStmt:                       q.createTagStmt,
+		createTransferStmt:                  q.createTransferStmt,
+		createUserStmt:                      q.createUserStmt,
+		createWalletStmt:                    q.createWalletStmt,
+		deleteAttachmentStmt:                q.deleteAttachmentStmt,
+		deleteBudgetStmt:                    q.deleteBudgetStmt,
+		deleteCategorizationRuleStmt:        q.deleteCategorizationRuleStmt,
+		deleteCategoryStmt:                  q.deleteCategoryStmt,
+		deleteExpenseStmt:                   q.deleteExpenseStmt,
+		deleteExpenseAttachmentsStmt:        q.deleteExpenseAttachmentsStmt,
+		deleteExpenseSplitsStmt:             q.deleteExpenseSplitsStmt,
+		deleteExpiredRevokedTokensStmt:      q.deleteExpiredRevokedTokensStmt,
+		deleteIncomeStmt:                    q.deleteIncomeStmt,
+		deleteRecurringExpenseStmt:          q.deleteRecurringExpenseStmt,
+		deleteTagStmt:                       q.deleteTagStmt,
+		deleteWalletStmt:                    q.deleteWalletStmt,
+		exportTransactionsStmt:              q.exportTransactionsStmt,
+		getAllCategoriesStmt:                q.getAllCategoriesStmt,
+		getAttachmentStmt:                   q.getAttachmentStmt,
+		getBudgetByIDStmt:                   q.getBudgetByIDStmt,
+		getBudgetProgressStmt:               q.getBudgetProgressStmt,
+		getCategorizationRuleStmt:           q.getCategorizationRuleStmt,
+		getCategoryByIDStmt:                 q.getCategoryByIDStmt,
+		getDueRecurringExpenseForUpdateStmt: q.getDueRecurringExpenseForUpdateStmt,
+		getExchangeRateStmt:                 q.getExchangeRateStmt,
+		getExpenseStmt:                      q.getExpenseStmt,
+		getExpenseForDeleteStmt:             q.getExpenseForDeleteStmt,
+		getExpenseForUpdateStmt:             q.getExpenseForUpdateStmt,
+		getIncomeStmt:                       q.getIncomeStmt,
+		getIncomeForUpdateStmt:              q.getIncomeForUpdateStmt,
+		getMonthlyCategoryReportStmt:        q.getMonthlyCategoryReportStmt,
+		getOrCreateCategoryStmt:             q.getOrCreateCategoryStmt,
+		getRecurringExpenseStmt:             q.getRecurringExpenseStmt,
+		getSessionStmt:                      q.getSessionStmt,
+		getSystemStatsStmt:                  q.getSystemStatsStmt,
+		getTagStmt:                          q.getTagStmt,
+		getTimeSeriesStmt:                   q.getTimeSeriesStmt,
+		getTokenStatusStmt:                  q.getTokenStatusStmt,
+		getTransferStmt:                     q.getTransferStmt,
+		getUserStmt:                         q.getUserStmt,
+		getWalletStmt:                       q.getWalletStmt,
+		getWalletForUpdateStmt:              q.getWalletForUpdateStmt,
+		listActiveSessionsStmt:              q.listActiveSessionsStmt,
+		listBudgetsStmt:                     q.listBudgetsStmt,
+		listBudgetsByWalletStmt:             q.listBudgetsByWalletStmt,
+		listCategoriesStmt:                  q.listCategoriesStmt,
+		listCategorizationRulesStmt:         q.listCategorizationRulesStmt,
+		listCategoryExpensesStmt:            q.listCategoryExpensesStmt,
+		listExchangeRatesForCurrenciesStmt:  q.listExchangeRatesForCurrenciesStmt,
+		listExpenseAttachmentsStmt:          q.listExpenseAttachmentsStmt,
+		listExpenseSplitsStmt:               q.listExpenseSplitsStmt,
+		listExpenseTagsStmt:                 q.listExpenseTagsStmt,
+		listExpensesByAmountAscStmt:         q.listExpensesByAmountAscStmt,
+		listExpensesByAmountDescStmt:        q.listExpensesByAmountDescStmt,
+		listExpensesByDateAscStmt:           q.listExpensesByDateAscStmt,
+		listExpensesByDateDescStmt:          q.listExpensesByDateDescStmt,
+		listIncomesStmt:                     q.listIncomesStmt,
+		listOwnerBudgetsStmt:                q.listOwnerBudgetsStmt,
+		listOwnerCategoriesStmt:             q.listOwnerCategoriesStmt,
+		listOwnerExpenseSplitsStmt:          q.listOwnerExpenseSplitsStmt,
+		listOwnerExpenseTagsStmt:            q.listOwnerExpenseTagsStmt,
+		listOwnerExpensesStmt:               q.listOwnerExpensesStmt,
+		listOwnerIncomesStmt:                q.listOwnerIncomesStmt,
+		listOwnerTagsStmt:                   q.listOwnerTagsStmt,
+		listOwnerTransfersStmt:              q.listOwnerTransfersStmt,
+		listOwnerWalletsStmt:                q.listOwnerWalletsStmt,
+		listRecurringExpensesStmt:           q.listRecurringExpensesStmt,
+		listTagsStmt:                        q.listTagsStmt,
+		listTransfersStmt:                   q.listTransfersStmt,
+		listUsersStmt:                       q.listUsersStmt,
+		listWalletExpenseExternalIDsStmt:    q.listWalletExpenseExternalIDsStmt,
+		listWalletExpensesBetweenStmt:       q.listWalletExpensesBetweenStmt,
+		listWalletsStmt:                     q.listWalletsStmt,
+		removeExpenseTagStmt:                q.removeExpenseTagStmt,
+		restoreBudgetStmt:                   q.restoreBudgetStmt,
+		restoreCategoryStmt:                 q.restoreCategoryStmt,
+		restoreExpenseStmt:                  q.restoreExpenseStmt,
+		restoreExpenseSplitStmt:             q.restoreExpenseSplitStmt,
+		restoreExpenseTagStmt:               q.restoreExpenseTagStmt,
+		restoreIncomeStmt:                   q.restoreIncomeStmt,
+		restoreTagStmt:                      q.restoreTagStmt,
+		restoreTransferStmt:                 q.restoreTransferStmt,
+		restoreWalletStmt:                   q.restoreWalletStmt,
+		revokeTokenStmt:                     q.revokeTokenStmt,
+		revokeUserTokensStmt:                q.revokeUserTokensStmt,
+		setExpenseCategoriesStmt:            q.setExpenseCategoriesStmt,
+		setUserDisabledStmt:                 q.setUserDisabledStmt,
+		setUserRoleStmt:                     q.setUserRoleStmt,
+		updateBudgetStmt:                    q.updateBudgetStmt,
+		updateCategoryStmt:                  q.updateCategoryStmt,
+		updateExpenseStmt:                   q.updateExpenseStmt,
+		updateIncomeStmt:                    q.updateIncomeStmt,
+		updateRecurringExpenseScheduleStmt:  q.updateRecurringExpenseScheduleStmt,
+		updateTagStmt:                       q.updateTagStmt,
+		updateUserStmt:                      q.updateUserStmt,
+		updateWalletStmt:                    q.updateWalletStmt,
+		upsertExchangeRateStmt:              q.upsertExchangeRateStmt,
 	}
 }
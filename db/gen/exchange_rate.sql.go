@@ -0,0 +1,126 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: exchange_rate.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const getExchangeRate = `-- name: GetExchangeRate :one
+SELECT base_currency, quote_currency, rate_date, rate, source, created_at FROM exchange_rates
+WHERE base_currency = $1 AND quote_currency = $2 AND rate_date = $3
+LIMIT 1
+`
+
+type GetExchangeRateParams struct {
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	RateDate      time.Time `json:"rate_date"`
+}
+
+func (q *Queries) GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (ExchangeRate, error) {
+	row := q.queryRow(ctx, q.getExchangeRateStmt, getExchangeRate, arg.BaseCurrency, arg.QuoteCurrency, arg.RateDate)
+	var i ExchangeRate
+	err := row.Scan(
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.RateDate,
+		&i.Rate,
+		&i.Source,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listExchangeRatesForCurrencies = `-- name: ListExchangeRatesForCurrencies :many
+SELECT base_currency, quote_currency, rate_date, rate, source, created_at FROM exchange_rates
+WHERE (base_currency = ANY($1::varchar[])
+    OR quote_currency = ANY($1::varchar[]))
+  AND rate_date BETWEEN $2 AND $3
+ORDER BY rate_date DESC
+`
+
+type ListExchangeRatesForCurrenciesParams struct {
+	Currencies []string  `json:"currencies"`
+	FromDate   time.Time `json:"from_date"`
+	ToDate     time.Time `json:"to_date"`
+}
+
+func (q *Queries) ListExchangeRatesForCurrencies(ctx context.Context, arg ListExchangeRatesForCurrenciesParams) ([]ExchangeRate, error) {
+	rows, err := q.query(ctx, q.listExchangeRatesForCurrenciesStmt, listExchangeRatesForCurrencies, pq.Array(arg.Currencies), arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExchangeRate{}
+	for rows.Next() {
+		var i ExchangeRate
+		if err := rows.Scan(
+			&i.BaseCurrency,
+			&i.QuoteCurrency,
+			&i.RateDate,
+			&i.Rate,
+			&i.Source,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertExchangeRate = `-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (
+    base_currency,
+    quote_currency,
+    rate_date,
+    rate,
+    source
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (base_currency, quote_currency, rate_date)
+DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source
+RETURNING base_currency, quote_currency, rate_date, rate, source, created_at
+`
+
+type UpsertExchangeRateParams struct {
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	RateDate      time.Time `json:"rate_date"`
+	Rate          string    `json:"rate"`
+	Source        string    `json:"source"`
+}
+
+func (q *Queries) UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error) {
+	row := q.queryRow(ctx, q.upsertExchangeRateStmt, upsertExchangeRate,
+		arg.BaseCurrency,
+		arg.QuoteCurrency,
+		arg.RateDate,
+		arg.Rate,
+		arg.Source,
+	)
+	var i ExchangeRate
+	err := row.Scan(
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.RateDate,
+		&i.Rate,
+		&i.Source,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func CreateRandomExchangeRate(t *testing.T, date time.Time) ExchangeRate {
+	arg := UpsertExchangeRateParams{
+		BaseCurrency:  util.RandomString(3),
+		QuoteCurrency: util.RandomString(3),
+		RateDate:      date,
+		Rate:          "91.601200000000",
+		Source:        "csv",
+	}
+
+	rate, err := testQueries.UpsertExchangeRate(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.BaseCurrency, rate.BaseCurrency)
+	require.Equal(t, arg.QuoteCurrency, rate.QuoteCurrency)
+	require.Equal(t, arg.Rate, rate.Rate)
+	require.Equal(t, arg.Source, rate.Source)
+	require.NotZero(t, rate.CreatedAt)
+	return rate
+}
+
+func TestUpsertExchangeRate(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	rate1 := CreateRandomExchangeRate(t, date)
+
+	rate2, err := testQueries.UpsertExchangeRate(context.Background(), UpsertExchangeRateParams{
+		BaseCurrency:  rate1.BaseCurrency,
+		QuoteCurrency: rate1.QuoteCurrency,
+		RateDate:      date,
+		Rate:          "92.000000000000",
+		Source:        "cbr",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "92.000000000000", rate2.Rate)
+	require.Equal(t, "cbr", rate2.Source)
+
+	rate3, err := testQueries.GetExchangeRate(context.Background(), GetExchangeRateParams{
+		BaseCurrency:  rate1.BaseCurrency,
+		QuoteCurrency: rate1.QuoteCurrency,
+		RateDate:      date,
+	})
+	require.NoError(t, err)
+	require.Equal(t, rate2.Rate, rate3.Rate)
+}
+
+func TestListExchangeRatesForCurrencies(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	rate1 := CreateRandomExchangeRate(t, date)
+	CreateRandomExchangeRate(t, date)
+
+	rates, err := testQueries.ListExchangeRatesForCurrencies(context.Background(), ListExchangeRatesForCurrenciesParams{
+		Currencies: []string{rate1.QuoteCurrency},
+		FromDate:   date.AddDate(0, 0, -7),
+		ToDate:     date,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, rates)
+	for _, rate := range rates {
+		require.True(t, rate.BaseCurrency == rate1.QuoteCurrency || rate.QuoteCurrency == rate1.QuoteCurrency)
+	}
+}
@@ -7,6 +7,10 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 const createExpense = `-- name: CreateExpense :one
@@ -18,7 +22,7 @@ INSERT INTO expenses (
 ) VALUES (
     $1, $2, $3, $4
 )
-RETURNING id, wallet_id, amount, expense_description, category_id, created_at
+RETURNING id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id
 `
 
 type CreateExpenseParams struct {
@@ -43,6 +47,108 @@ func (q *Queries) CreateExpense(ctx context.Context, arg CreateExpenseParams) (E
 		&i.ExpenseDescription,
 		&i.CategoryID,
 		&i.CreatedAt,
+		&i.RecurringExpenseID,
+		&i.OccurrenceDate,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const createExpenseOccurrence = `-- name: CreateExpenseOccurrence :one
+INSERT INTO expenses (
+    wallet_id,
+    amount,
+    expense_description,
+    category_id,
+    created_at,
+    recurring_expense_id,
+    occurrence_date
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (recurring_expense_id, occurrence_date) DO NOTHING
+RETURNING id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id
+`
+
+type CreateExpenseOccurrenceParams struct {
+	WalletID           int64      `json:"wallet_id"`
+	Amount             int64      `json:"amount"`
+	ExpenseDescription string     `json:"expense_description"`
+	CategoryID         int64      `json:"category_id"`
+	CreatedAt          time.Time  `json:"created_at"`
+	RecurringExpenseID *int64     `json:"recurring_expense_id"`
+	OccurrenceDate     *time.Time `json:"occurrence_date"`
+}
+
+func (q *Queries) CreateExpenseOccurrence(ctx context.Context, arg CreateExpenseOccurrenceParams) (Expense, error) {
+	row := q.queryRow(ctx, q.createExpenseOccurrenceStmt, createExpenseOccurrence,
+		arg.WalletID,
+		arg.Amount,
+		arg.ExpenseDescription,
+		arg.CategoryID,
+		arg.CreatedAt,
+		arg.RecurringExpenseID,
+		arg.OccurrenceDate,
+	)
+	var i Expense
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.ExpenseDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+		&i.RecurringExpenseID,
+		&i.OccurrenceDate,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const createImportedExpense = `-- name: CreateImportedExpense :one
+INSERT INTO expenses (
+    wallet_id,
+    amount,
+    expense_description,
+    category_id,
+    created_at,
+    external_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (wallet_id, external_id) WHERE external_id IS NOT NULL DO NOTHING
+RETURNING id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id
+`
+
+type CreateImportedExpenseParams struct {
+	WalletID           int64     `json:"wallet_id"`
+	Amount             int64     `json:"amount"`
+	ExpenseDescription string    `json:"expense_description"`
+	CategoryID         int64     `json:"category_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	ExternalID         *string   `json:"external_id"`
+}
+
+func (q *Queries) CreateImportedExpense(ctx context.Context, arg CreateImportedExpenseParams) (Expense, error) {
+	row := q.queryRow(ctx, q.createImportedExpenseStmt, createImportedExpense,
+		arg.WalletID,
+		arg.Amount,
+		arg.ExpenseDescription,
+		arg.CategoryID,
+		arg.CreatedAt,
+		arg.ExternalID,
+	)
+	var i Expense
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.ExpenseDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+		&i.RecurringExpenseID,
+		&i.OccurrenceDate,
+		&i.ExternalID,
 	)
 	return i, err
 }
@@ -58,7 +164,7 @@ func (q *Queries) DeleteExpense(ctx context.Context, id int64) error {
 }
 
 const getExpense = `-- name: GetExpense :one
-SELECT id, wallet_id, amount, expense_description, category_id, created_at FROM expenses
+SELECT id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id FROM expenses
 WHERE id = $1 LIMIT 1
 `
 
@@ -72,23 +178,150 @@ func (q *Queries) GetExpense(ctx context.Context, id int64) (Expense, error) {
 		&i.ExpenseDescription,
 		&i.CategoryID,
 		&i.CreatedAt,
+		&i.RecurringExpenseID,
+		&i.OccurrenceDate,
+		&i.ExternalID,
 	)
 	return i, err
 }
 
-const listExpenses = `-- name: ListExpenses :many
-SELECT id, wallet_id, amount, expense_description, category_id, created_at FROM expenses
-LIMIT $1
-OFFSET $2
+const getExpenseForDelete = `-- name: GetExpenseForDelete :one
+SELECT id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id FROM expenses
+WHERE id = $1 LIMIT 1
+FOR UPDATE
 `
 
-type ListExpensesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+// FOR UPDATE also waits for attachments being added to the expense
+func (q *Queries) GetExpenseForDelete(ctx context.Context, id int64) (Expense, error) {
+	row := q.queryRow(ctx, q.getExpenseForDeleteStmt, getExpenseForDelete, id)
+	var i Expense
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.ExpenseDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+		&i.RecurringExpenseID,
+		&i.OccurrenceDate,
+		&i.ExternalID,
+	)
+	return i, err
 }
 
-func (q *Queries) ListExpenses(ctx context.Context, arg ListExpensesParams) ([]Expense, error) {
-	rows, err := q.query(ctx, q.listExpensesStmt, listExpenses, arg.Limit, arg.Offset)
+const getExpenseForUpdate = `-- name: GetExpenseForUpdate :one
+SELECT id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id FROM expenses
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetExpenseForUpdate(ctx context.Context, id int64) (Expense, error) {
+	row := q.queryRow(ctx, q.getExpenseForUpdateStmt, getExpenseForUpdate, id)
+	var i Expense
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.ExpenseDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+		&i.RecurringExpenseID,
+		&i.OccurrenceDate,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const listCategoryExpenses = `-- name: ListCategoryExpenses :many
+SELECT id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id FROM expenses
+WHERE category_id = $1
+  AND ($2::bigint IS NULL OR wallet_id = $2)
+ORDER BY id
+`
+
+type ListCategoryExpensesParams struct {
+	CategoryID int64         `json:"category_id"`
+	WalletID   sql.NullInt64 `json:"wallet_id"`
+}
+
+func (q *Queries) ListCategoryExpenses(ctx context.Context, arg ListCategoryExpensesParams) ([]Expense, error) {
+	rows, err := q.query(ctx, q.listCategoryExpensesStmt, listCategoryExpenses, arg.CategoryID, arg.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Expense{}
+	for rows.Next() {
+		var i Expense
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.ExpenseDescription,
+			&i.CategoryID,
+			&i.CreatedAt,
+			&i.RecurringExpenseID,
+			&i.OccurrenceDate,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpensesByAmountAsc = `-- name: ListExpensesByAmountAsc :many
+SELECT id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id FROM expenses
+WHERE wallet_id = $1
+  AND ($2::timestamptz IS NULL OR created_at >= $2)
+  AND ($3::timestamptz IS NULL OR created_at < $3)
+  AND (COALESCE(cardinality($4::bigint[]), 0) = 0 OR category_id = ANY($4::bigint[]))
+  AND ($5::bigint IS NULL OR amount >= $5)
+  AND ($6::bigint IS NULL OR amount <= $6)
+  AND ($7::varchar IS NULL OR expense_description ILIKE '%' || $7 || '%')
+  AND (COALESCE(cardinality($8::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = expenses.id AND et.tag_id = ANY($8::bigint[])
+  ))
+  AND ($9::bigint IS NULL OR (amount, id) > ($10::bigint, $9))
+ORDER BY amount ASC, id ASC
+LIMIT $11
+`
+
+type ListExpensesByAmountAscParams struct {
+	WalletID     int64          `json:"wallet_id"`
+	FromTime     sql.NullTime   `json:"from_time"`
+	ToTime       sql.NullTime   `json:"to_time"`
+	CategoryIds  []int64        `json:"category_ids"`
+	MinAmount    sql.NullInt64  `json:"min_amount"`
+	MaxAmount    sql.NullInt64  `json:"max_amount"`
+	Description  sql.NullString `json:"description"`
+	TagIds       []int64        `json:"tag_ids"`
+	CursorID     sql.NullInt64  `json:"cursor_id"`
+	CursorAmount int64          `json:"cursor_amount"`
+	Limit        int32          `json:"limit"`
+}
+
+func (q *Queries) ListExpensesByAmountAsc(ctx context.Context, arg ListExpensesByAmountAscParams) ([]Expense, error) {
+	rows, err := q.query(ctx, q.listExpensesByAmountAscStmt, listExpensesByAmountAsc,
+		arg.WalletID,
+		arg.FromTime,
+		arg.ToTime,
+		pq.Array(arg.CategoryIds),
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Description,
+		pq.Array(arg.TagIds),
+		arg.CursorID,
+		arg.CursorAmount,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +336,9 @@ func (q *Queries) ListExpenses(ctx context.Context, arg ListExpensesParams) ([]E
 			&i.ExpenseDescription,
 			&i.CategoryID,
 			&i.CreatedAt,
+			&i.RecurringExpenseID,
+			&i.OccurrenceDate,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -117,26 +353,364 @@ func (q *Queries) ListExpenses(ctx context.Context, arg ListExpensesParams) ([]E
 	return items, nil
 }
 
+const listExpensesByAmountDesc = `-- name: ListExpensesByAmountDesc :many
+SELECT id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id FROM expenses
+WHERE wallet_id = $1
+  AND ($2::timestamptz IS NULL OR created_at >= $2)
+  AND ($3::timestamptz IS NULL OR created_at < $3)
+  AND (COALESCE(cardinality($4::bigint[]), 0) = 0 OR category_id = ANY($4::bigint[]))
+  AND ($5::bigint IS NULL OR amount >= $5)
+  AND ($6::bigint IS NULL OR amount <= $6)
+  AND ($7::varchar IS NULL OR expense_description ILIKE '%' || $7 || '%')
+  AND (COALESCE(cardinality($8::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = expenses.id AND et.tag_id = ANY($8::bigint[])
+  ))
+  AND ($9::bigint IS NULL OR (amount, id) < ($10::bigint, $9))
+ORDER BY amount DESC, id DESC
+LIMIT $11
+`
+
+type ListExpensesByAmountDescParams struct {
+	WalletID     int64          `json:"wallet_id"`
+	FromTime     sql.NullTime   `json:"from_time"`
+	ToTime       sql.NullTime   `json:"to_time"`
+	CategoryIds  []int64        `json:"category_ids"`
+	MinAmount    sql.NullInt64  `json:"min_amount"`
+	MaxAmount    sql.NullInt64  `json:"max_amount"`
+	Description  sql.NullString `json:"description"`
+	TagIds       []int64        `json:"tag_ids"`
+	CursorID     sql.NullInt64  `json:"cursor_id"`
+	CursorAmount int64          `json:"cursor_amount"`
+	Limit        int32          `json:"limit"`
+}
+
+func (q *Queries) ListExpensesByAmountDesc(ctx context.Context, arg ListExpensesByAmountDescParams) ([]Expense, error) {
+	rows, err := q.query(ctx, q.listExpensesByAmountDescStmt, listExpensesByAmountDesc,
+		arg.WalletID,
+		arg.FromTime,
+		arg.ToTime,
+		pq.Array(arg.CategoryIds),
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Description,
+		pq.Array(arg.TagIds),
+		arg.CursorID,
+		arg.CursorAmount,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Expense{}
+	for rows.Next() {
+		var i Expense
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.ExpenseDescription,
+			&i.CategoryID,
+			&i.CreatedAt,
+			&i.RecurringExpenseID,
+			&i.OccurrenceDate,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpensesByDateAsc = `-- name: ListExpensesByDateAsc :many
+SELECT id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id FROM expenses
+WHERE wallet_id = $1
+  AND ($2::timestamptz IS NULL OR created_at >= $2)
+  AND ($3::timestamptz IS NULL OR created_at < $3)
+  AND (COALESCE(cardinality($4::bigint[]), 0) = 0 OR category_id = ANY($4::bigint[]))
+  AND ($5::bigint IS NULL OR amount >= $5)
+  AND ($6::bigint IS NULL OR amount <= $6)
+  AND ($7::varchar IS NULL OR expense_description ILIKE '%' || $7 || '%')
+  AND (COALESCE(cardinality($8::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = expenses.id AND et.tag_id = ANY($8::bigint[])
+  ))
+  AND ($9::bigint IS NULL OR (created_at, id) > ($10::timestamptz, $9))
+ORDER BY created_at ASC, id ASC
+LIMIT $11
+`
+
+type ListExpensesByDateAscParams struct {
+	WalletID        int64          `json:"wallet_id"`
+	FromTime        sql.NullTime   `json:"from_time"`
+	ToTime          sql.NullTime   `json:"to_time"`
+	CategoryIds     []int64        `json:"category_ids"`
+	MinAmount       sql.NullInt64  `json:"min_amount"`
+	MaxAmount       sql.NullInt64  `json:"max_amount"`
+	Description     sql.NullString `json:"description"`
+	TagIds          []int64        `json:"tag_ids"`
+	CursorID        sql.NullInt64  `json:"cursor_id"`
+	CursorCreatedAt time.Time      `json:"cursor_created_at"`
+	Limit           int32          `json:"limit"`
+}
+
+func (q *Queries) ListExpensesByDateAsc(ctx context.Context, arg ListExpensesByDateAscParams) ([]Expense, error) {
+	rows, err := q.query(ctx, q.listExpensesByDateAscStmt, listExpensesByDateAsc,
+		arg.WalletID,
+		arg.FromTime,
+		arg.ToTime,
+		pq.Array(arg.CategoryIds),
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Description,
+		pq.Array(arg.TagIds),
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Expense{}
+	for rows.Next() {
+		var i Expense
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.ExpenseDescription,
+			&i.CategoryID,
+			&i.CreatedAt,
+			&i.RecurringExpenseID,
+			&i.OccurrenceDate,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpensesByDateDesc = `-- name: ListExpensesByDateDesc :many
+SELECT id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id FROM expenses
+WHERE wallet_id = $1
+  AND ($2::timestamptz IS NULL OR created_at >= $2)
+  AND ($3::timestamptz IS NULL OR created_at < $3)
+  AND (COALESCE(cardinality($4::bigint[]), 0) = 0 OR category_id = ANY($4::bigint[]))
+  AND ($5::bigint IS NULL OR amount >= $5)
+  AND ($6::bigint IS NULL OR amount <= $6)
+  AND ($7::varchar IS NULL OR expense_description ILIKE '%' || $7 || '%')
+  AND (COALESCE(cardinality($8::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = expenses.id AND et.tag_id = ANY($8::bigint[])
+  ))
+  AND ($9::bigint IS NULL OR (created_at, id) < ($10::timestamptz, $9))
+ORDER BY created_at DESC, id DESC
+LIMIT $11
+`
+
+type ListExpensesByDateDescParams struct {
+	WalletID        int64          `json:"wallet_id"`
+	FromTime        sql.NullTime   `json:"from_time"`
+	ToTime          sql.NullTime   `json:"to_time"`
+	CategoryIds     []int64        `json:"category_ids"`
+	MinAmount       sql.NullInt64  `json:"min_amount"`
+	MaxAmount       sql.NullInt64  `json:"max_amount"`
+	Description     sql.NullString `json:"description"`
+	TagIds          []int64        `json:"tag_ids"`
+	CursorID        sql.NullInt64  `json:"cursor_id"`
+	CursorCreatedAt time.Time      `json:"cursor_created_at"`
+	Limit           int32          `json:"limit"`
+}
+
+// Every sort order has its own ListExpenses query, so the cursor and the
+// ORDER BY can use the matching (wallet_id, ..., id) index.
+func (q *Queries) ListExpensesByDateDesc(ctx context.Context, arg ListExpensesByDateDescParams) ([]Expense, error) {
+	rows, err := q.query(ctx, q.listExpensesByDateDescStmt, listExpensesByDateDesc,
+		arg.WalletID,
+		arg.FromTime,
+		arg.ToTime,
+		pq.Array(arg.CategoryIds),
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Description,
+		pq.Array(arg.TagIds),
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Expense{}
+	for rows.Next() {
+		var i Expense
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.ExpenseDescription,
+			&i.CategoryID,
+			&i.CreatedAt,
+			&i.RecurringExpenseID,
+			&i.OccurrenceDate,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWalletExpenseExternalIDs = `-- name: ListWalletExpenseExternalIDs :many
+SELECT external_id::varchar FROM expenses
+WHERE wallet_id = $1
+  AND external_id = ANY($2::varchar[])
+`
+
+type ListWalletExpenseExternalIDsParams struct {
+	WalletID    int64    `json:"wallet_id"`
+	ExternalIds []string `json:"external_ids"`
+}
+
+func (q *Queries) ListWalletExpenseExternalIDs(ctx context.Context, arg ListWalletExpenseExternalIDsParams) ([]string, error) {
+	rows, err := q.query(ctx, q.listWalletExpenseExternalIDsStmt, listWalletExpenseExternalIDs, arg.WalletID, pq.Array(arg.ExternalIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var external_id string
+		if err := rows.Scan(&external_id); err != nil {
+			return nil, err
+		}
+		items = append(items, external_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWalletExpensesBetween = `-- name: ListWalletExpensesBetween :many
+SELECT id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id FROM expenses
+WHERE wallet_id = $1
+  AND created_at >= $2::timestamptz
+  AND created_at < $3::timestamptz
+ORDER BY created_at, id
+`
+
+type ListWalletExpensesBetweenParams struct {
+	WalletID int64     `json:"wallet_id"`
+	FromTime time.Time `json:"from_time"`
+	ToTime   time.Time `json:"to_time"`
+}
+
+func (q *Queries) ListWalletExpensesBetween(ctx context.Context, arg ListWalletExpensesBetweenParams) ([]Expense, error) {
+	rows, err := q.query(ctx, q.listWalletExpensesBetweenStmt, listWalletExpensesBetween, arg.WalletID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Expense{}
+	for rows.Next() {
+		var i Expense
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.ExpenseDescription,
+			&i.CategoryID,
+			&i.CreatedAt,
+			&i.RecurringExpenseID,
+			&i.OccurrenceDate,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setExpenseCategories = `-- name: SetExpenseCategories :execrows
+UPDATE expenses
+SET category_id = ($1::bigint[])[array_position($2::bigint[], id)]
+WHERE id = ANY($2::bigint[])
+  AND category_id = $3
+`
+
+type SetExpenseCategoriesParams struct {
+	CategoryIds    []int64 `json:"category_ids"`
+	Ids            []int64 `json:"ids"`
+	FromCategoryID int64   `json:"from_category_id"`
+}
+
+// Moves expense ids[i] to category_ids[i]; expenses that left from_category_id
+// in the meantime are not touched
+func (q *Queries) SetExpenseCategories(ctx context.Context, arg SetExpenseCategoriesParams) (int64, error) {
+	result, err := q.exec(ctx, q.setExpenseCategoriesStmt, setExpenseCategories, pq.Array(arg.CategoryIds), pq.Array(arg.Ids), arg.FromCategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateExpense = `-- name: UpdateExpense :one
 UPDATE expenses
-SET amount = $2, expense_description = $3, category_id = $4
-WHERE id = $1
-RETURNING id, wallet_id, amount, expense_description, category_id, created_at
+SET
+    amount = COALESCE($1, amount),
+    expense_description = COALESCE($2, expense_description),
+    category_id = COALESCE($3, category_id)
+WHERE
+    id = $4
+RETURNING id, wallet_id, amount, expense_description, category_id, created_at, recurring_expense_id, occurrence_date, external_id
 `
 
 type UpdateExpenseParams struct {
-	ID                 int64  `json:"id"`
-	Amount             int64  `json:"amount"`
-	ExpenseDescription string `json:"expense_description"`
-	CategoryID         int64  `json:"category_id"`
+	Amount             sql.NullInt64  `json:"amount"`
+	ExpenseDescription sql.NullString `json:"expense_description"`
+	CategoryID         sql.NullInt64  `json:"category_id"`
+	ID                 int64          `json:"id"`
 }
 
 func (q *Queries) UpdateExpense(ctx context.Context, arg UpdateExpenseParams) (Expense, error) {
 	row := q.queryRow(ctx, q.updateExpenseStmt, updateExpense,
-		arg.ID,
 		arg.Amount,
 		arg.ExpenseDescription,
 		arg.CategoryID,
+		arg.ID,
 	)
 	var i Expense
 	err := row.Scan(
@@ -146,6 +720,9 @@ func (q *Queries) UpdateExpense(ctx context.Context, arg UpdateExpenseParams) (E
 		&i.ExpenseDescription,
 		&i.CategoryID,
 		&i.CreatedAt,
+		&i.RecurringExpenseID,
+		&i.OccurrenceDate,
+		&i.ExternalID,
 	)
 	return i, err
 }
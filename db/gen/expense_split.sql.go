@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: expense_split.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createExpenseSplit = `-- name: CreateExpenseSplit :one
+INSERT INTO expense_splits (
+  expense_id,
+  category_id,
+  amount
+) VALUES (
+  $1, $2, $3
+)
+RETURNING id, expense_id, category_id, amount, created_at
+`
+
+type CreateExpenseSplitParams struct {
+	ExpenseID  int64 `json:"expense_id"`
+	CategoryID int64 `json:"category_id"`
+	Amount     int64 `json:"amount"`
+}
+
+func (q *Queries) CreateExpenseSplit(ctx context.Context, arg CreateExpenseSplitParams) (ExpenseSplit, error) {
+	row := q.queryRow(ctx, q.createExpenseSplitStmt, createExpenseSplit, arg.ExpenseID, arg.CategoryID, arg.Amount)
+	var i ExpenseSplit
+	err := row.Scan(
+		&i.ID,
+		&i.ExpenseID,
+		&i.CategoryID,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteExpenseSplits = `-- name: DeleteExpenseSplits :exec
+DELETE FROM expense_splits
+WHERE expense_id = $1
+`
+
+func (q *Queries) DeleteExpenseSplits(ctx context.Context, expenseID int64) error {
+	_, err := q.exec(ctx, q.deleteExpenseSplitsStmt, deleteExpenseSplits, expenseID)
+	return err
+}
+
+const listExpenseSplits = `-- name: ListExpenseSplits :many
+SELECT id, expense_id, category_id, amount, created_at FROM expense_splits
+WHERE expense_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListExpenseSplits(ctx context.Context, expenseID int64) ([]ExpenseSplit, error) {
+	rows, err := q.query(ctx, q.listExpenseSplitsStmt, listExpenseSplits, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExpenseSplit{}
+	for rows.Next() {
+		var i ExpenseSplit
+		if err := rows.Scan(
+			&i.ID,
+			&i.ExpenseID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetExpenseSplitsTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category1 := CreateRandomCategory(t, user)
+	category2 := CreateRandomCategory(t, user)
+	expense := CreateRandomExpense(t, wallet, category1)
+
+	arg := SetExpenseSplitsTxParams{
+		ExpenseID: expense.ID,
+		Splits: []ExpenseSplitParams{
+			{CategoryID: category1.ID, Amount: expense.Amount - 40},
+			{CategoryID: category2.ID, Amount: 40},
+		},
+	}
+	result, err := testStore.SetExpenseSplitsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, expense.ID, result.Expense.ID)
+	require.Len(t, result.Splits, 2)
+	for i, split := range result.Splits {
+		require.Equal(t, expense.ID, split.ExpenseID)
+		require.Equal(t, arg.Splits[i].CategoryID, split.CategoryID)
+		require.Equal(t, arg.Splits[i].Amount, split.Amount)
+	}
+
+	// the amount of a split expense is fixed until the splits are replaced
+	_, err = testStore.UpdateExpenseTx(context.Background(), UpdateExpenseParams{
+		ID:     expense.ID,
+		Amount: sql.NullInt64{Int64: expense.Amount + 1, Valid: true},
+	})
+	require.ErrorIs(t, err, ErrSplitTotal)
+
+	arg.Splits[1].Amount++
+	_, err = testStore.SetExpenseSplitsTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrSplitTotal)
+
+	splits, err := testQueries.ListExpenseSplits(context.Background(), expense.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Splits, splits)
+
+	result, err = testStore.SetExpenseSplitsTx(context.Background(), SetExpenseSplitsTxParams{ExpenseID: expense.ID})
+	require.NoError(t, err)
+	require.Empty(t, result.Splits)
+
+	splits, err = testQueries.ListExpenseSplits(context.Background(), expense.ID)
+	require.NoError(t, err)
+	require.Empty(t, splits)
+}
+
+func TestExpenseSplitReports(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category1 := CreateRandomCategory(t, user)
+	category2 := CreateRandomCategory(t, user)
+	budget := CreateRandomBudget(t, wallet, category2)
+	expense := CreateRandomExpense(t, wallet, category1)
+
+	_, err := testStore.SetExpenseSplitsTx(context.Background(), SetExpenseSplitsTxParams{
+		ExpenseID: expense.ID,
+		Splits: []ExpenseSplitParams{
+			{CategoryID: category1.ID, Amount: expense.Amount - 30},
+			{CategoryID: category2.ID, Amount: 30},
+		},
+	})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	rows, err := testQueries.GetMonthlyCategoryReport(context.Background(), GetMonthlyCategoryReportParams{
+		Owner:     user.Username,
+		WalletIds: []int64{},
+		FromTime:  month,
+		ToTime:    month.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	totals := map[int64]int64{}
+	for _, row := range rows {
+		totals[row.CategoryID] = row.Total
+		require.Equal(t, int64(1), row.ExpenseCount)
+		require.Equal(t, expense.Amount, row.MonthTotal)
+	}
+	require.Equal(t, expense.Amount-30, totals[category1.ID])
+	require.Equal(t, int64(30), totals[category2.ID])
+
+	progress, err := testQueries.GetBudgetProgress(context.Background(), GetBudgetProgressParams{
+		ID:          budget.ID,
+		PeriodStart: now.Add(-time.Hour),
+		PeriodEnd:   now.Add(time.Hour),
+		At:          now,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), progress.Spent)
+}
@@ -3,9 +3,11 @@ package db
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"github.com/symyzi/financial-helper/util"
 )
@@ -87,18 +89,17 @@ func TestUpdateExpense(t *testing.T) {
 	expense1 := CreateRandomExpense(t, wallet, category)
 	arg := UpdateExpenseParams{
 		ID:                 expense1.ID,
-		Amount:             util.RandomAmount(),
-		ExpenseDescription: util.RandomString(12),
-		CategoryID:         category.ID,
+		Amount:             sql.NullInt64{Int64: expense1.Amount + 1, Valid: true},
+		ExpenseDescription: sql.NullString{String: util.RandomString(12), Valid: true},
 	}
 	expense2, err := testQueries.UpdateExpense(context.Background(), arg)
 	require.NoError(t, err)
 	require.NotEmpty(t, expense2)
 	require.Equal(t, expense1.ID, expense2.ID)
 	require.Equal(t, expense1.WalletID, expense2.WalletID)
-	require.Equal(t, arg.Amount, expense2.Amount)
-	require.Equal(t, arg.ExpenseDescription, expense2.ExpenseDescription)
-	require.Equal(t, arg.CategoryID, expense2.CategoryID)
+	require.Equal(t, arg.Amount.Int64, expense2.Amount)
+	require.Equal(t, arg.ExpenseDescription.String, expense2.ExpenseDescription)
+	require.Equal(t, expense1.CategoryID, expense2.CategoryID)
 	require.NotEqual(t, expense1.Amount, expense2.Amount)
 	require.NotEqual(t, expense1.ExpenseDescription, expense2.ExpenseDescription)
 }
@@ -110,15 +111,151 @@ func TestListExpenses(t *testing.T) {
 		category := CreateRandomCategory(t, user)
 		CreateRandomExpense(t, wallet, category)
 	}
-	arg := ListExpensesParams{
-		Limit:  5,
-		Offset: 5,
+	arg := ListExpensesByDateDescParams{
+		WalletID: wallet.ID,
+		Limit:    5,
 	}
 
-	expenses, err := testQueries.ListExpenses(context.Background(), arg)
+	expenses, err := testQueries.ListExpensesByDateDesc(context.Background(), arg)
 	require.NoError(t, err)
 	require.Len(t, expenses, 5)
-	for _, expense := range expenses {
+
+	last := expenses[len(expenses)-1]
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+	arg.CursorCreatedAt = last.CreatedAt
+	nextExpenses, err := testQueries.ListExpensesByDateDesc(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, nextExpenses, 5)
+	for _, expense := range nextExpenses {
 		require.NotEmpty(t, expense)
+		require.Equal(t, wallet.ID, expense.WalletID)
+		require.Less(t, expense.ID, last.ID)
+	}
+}
+
+func TestListExpensesByAmountAsc(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	for i := 0; i < 6; i++ {
+		CreateRandomExpense(t, wallet, category)
+	}
+	arg := ListExpensesByAmountAscParams{
+		WalletID: wallet.ID,
+		Limit:    3,
+	}
+
+	first, err := testQueries.ListExpensesByAmountAsc(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, first, 3)
+
+	last := first[len(first)-1]
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+	arg.CursorAmount = last.Amount
+	next, err := testQueries.ListExpensesByAmountAsc(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, next, 3)
+	for _, expense := range next {
+		require.True(t, expense.Amount > last.Amount || (expense.Amount == last.Amount && expense.ID > last.ID))
+	}
+}
+
+// TestListExpensesPlans checks that every sort order seeks its index instead of
+// sorting all expenses of the wallet
+func TestListExpensesPlans(t *testing.T) {
+	testCases := []struct {
+		query  string
+		index  string
+		cursor interface{}
+	}{
+		{listExpensesByDateDesc, "expenses_wallet_id_created_at_id_idx", time.Now()},
+		{listExpensesByDateAsc, "expenses_wallet_id_created_at_id_idx", time.Now()},
+		{listExpensesByAmountDesc, "expenses_wallet_id_amount_id_idx", int64(100)},
+		{listExpensesByAmountAsc, "expenses_wallet_id_amount_id_idx", int64(100)},
+	}
+
+	for _, tc := range testCases {
+		tx, err := testDB.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+		_, err = tx.Exec("SET LOCAL enable_seqscan = off; SET LOCAL enable_bitmapscan = off")
+		require.NoError(t, err)
+
+		rows, err := tx.Query("EXPLAIN "+tc.query,
+			int64(1),
+			sql.NullTime{},
+			sql.NullTime{},
+			pq.Array([]int64(nil)),
+			sql.NullInt64{},
+			sql.NullInt64{},
+			sql.NullString{},
+			pq.Array([]int64(nil)),
+			int64(1),
+			tc.cursor,
+			int32(10),
+		)
+		require.NoError(t, err)
+		var plan []string
+		for rows.Next() {
+			var line string
+			require.NoError(t, rows.Scan(&line))
+			plan = append(plan, line)
+		}
+		require.NoError(t, rows.Err())
+		require.NoError(t, tx.Rollback())
+
+		text := strings.Join(plan, "\n")
+		require.Contains(t, text, tc.index)
+		require.NotContains(t, text, "Sort", text)
+	}
+}
+
+func TestListExpensesFilters(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category1 := CreateRandomCategory(t, user)
+	category2 := CreateRandomCategory(t, user)
+	category3 := CreateRandomCategory(t, user)
+	for i := 0; i < 3; i++ {
+		CreateRandomExpense(t, wallet, category1)
+		CreateRandomExpense(t, wallet, category2)
+		CreateRandomExpense(t, wallet, category3)
+	}
+
+	arg := ListExpensesByAmountDescParams{
+		WalletID:    wallet.ID,
+		CategoryIds: []int64{category1.ID, category2.ID},
+		FromTime:    sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+		ToTime:      sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true},
+		Limit:       10,
+	}
+	expenses, err := testQueries.ListExpensesByAmountDesc(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, expenses, 6)
+	for i, expense := range expenses {
+		require.NotEqual(t, category3.ID, expense.CategoryID)
+		if i > 0 {
+			require.LessOrEqual(t, expense.Amount, expenses[i-1].Amount)
+		}
 	}
+
+	filtered, err := testQueries.ListExpensesByDateAsc(context.Background(), ListExpensesByDateAscParams{
+		WalletID:  wallet.ID,
+		MinAmount: sql.NullInt64{Int64: expenses[2].Amount, Valid: true},
+		MaxAmount: sql.NullInt64{Int64: expenses[2].Amount, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, filtered)
+	for _, expense := range filtered {
+		require.Equal(t, expenses[2].Amount, expense.Amount)
+	}
+
+	filtered, err = testQueries.ListExpensesByDateDesc(context.Background(), ListExpensesByDateDescParams{
+		WalletID:    wallet.ID,
+		Description: sql.NullString{String: expenses[0].ExpenseDescription[2:8], Valid: true},
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	require.Equal(t, expenses[0].ID, filtered[0].ID)
 }
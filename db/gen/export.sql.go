@@ -0,0 +1,126 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: export.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const exportTransactions = `-- name: ExportTransactions :many
+SELECT type, id, wallet_id, wallet_name, currency, amount, description, category_id, category_name, created_at FROM (
+  SELECT
+    'expense'::varchar AS type,
+    e.id,
+    e.wallet_id,
+    w.name AS wallet_name,
+    w.currency,
+    e.amount,
+    e.expense_description AS description,
+    e.category_id,
+    c.name AS category_name,
+    e.created_at
+  FROM expenses e
+  JOIN wallets w ON w.id = e.wallet_id
+  JOIN categories c ON c.id = e.category_id
+  WHERE w.owner = $1
+    AND ($2::bigint IS NULL OR e.wallet_id = $2)
+    AND ($3::timestamptz IS NULL OR e.created_at >= $3)
+    AND ($4::timestamptz IS NULL OR e.created_at < $4)
+  UNION ALL
+  SELECT
+    'income'::varchar,
+    i.id,
+    i.wallet_id,
+    w.name,
+    w.currency,
+    i.amount,
+    i.income_description,
+    i.category_id,
+    c.name,
+    i.created_at
+  FROM incomes i
+  JOIN wallets w ON w.id = i.wallet_id
+  JOIN categories c ON c.id = i.category_id
+  WHERE w.owner = $1
+    AND ($2::bigint IS NULL OR i.wallet_id = $2)
+    AND ($3::timestamptz IS NULL OR i.created_at >= $3)
+    AND ($4::timestamptz IS NULL OR i.created_at < $4)
+) t
+WHERE $5::bigint IS NULL
+  OR (t.created_at, t.type, t.id) > ($6::timestamptz, $7::varchar, $5)
+ORDER BY t.created_at, t.type, t.id
+LIMIT $8
+`
+
+type ExportTransactionsParams struct {
+	Owner           string        `json:"owner"`
+	WalletID        sql.NullInt64 `json:"wallet_id"`
+	FromTime        sql.NullTime  `json:"from_time"`
+	ToTime          sql.NullTime  `json:"to_time"`
+	CursorID        sql.NullInt64 `json:"cursor_id"`
+	CursorCreatedAt time.Time     `json:"cursor_created_at"`
+	CursorType      string        `json:"cursor_type"`
+	Limit           int32         `json:"limit"`
+}
+
+type ExportTransactionsRow struct {
+	Type         string    `json:"type"`
+	ID           int64     `json:"id"`
+	WalletID     int64     `json:"wallet_id"`
+	WalletName   string    `json:"wallet_name"`
+	Currency     string    `json:"currency"`
+	Amount       int64     `json:"amount"`
+	Description  string    `json:"description"`
+	CategoryID   int64     `json:"category_id"`
+	CategoryName string    `json:"category_name"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Keyset paged on (created_at, type, id) so an export can be streamed in batches
+func (q *Queries) ExportTransactions(ctx context.Context, arg ExportTransactionsParams) ([]ExportTransactionsRow, error) {
+	rows, err := q.query(ctx, q.exportTransactionsStmt, exportTransactions,
+		arg.Owner,
+		arg.WalletID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.CursorType,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExportTransactionsRow{}
+	for rows.Next() {
+		var i ExportTransactionsRow
+		if err := rows.Scan(
+			&i.Type,
+			&i.ID,
+			&i.WalletID,
+			&i.WalletName,
+			&i.Currency,
+			&i.Amount,
+			&i.Description,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTransactions(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet1 := CreateRandomWallet(t, user)
+	wallet2 := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+
+	for i := 0; i < 3; i++ {
+		CreateRandomExpense(t, wallet1, category)
+		CreateRandomIncome(t, wallet2, category)
+	}
+
+	arg := ExportTransactionsParams{
+		Owner: user.Username,
+		Limit: 4,
+	}
+	first, err := testQueries.ExportTransactions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, first, 4)
+
+	last := first[len(first)-1]
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+	arg.CursorCreatedAt = last.CreatedAt
+	arg.CursorType = last.Type
+	second, err := testQueries.ExportTransactions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, second, 2)
+
+	rows := append(first, second...)
+	for i, row := range rows {
+		require.Equal(t, category.Name, row.CategoryName)
+		if i > 0 {
+			require.False(t, row.CreatedAt.Before(rows[i-1].CreatedAt))
+		}
+	}
+
+	walletRows, err := testQueries.ExportTransactions(context.Background(), ExportTransactionsParams{
+		Owner:    user.Username,
+		WalletID: sql.NullInt64{Int64: wallet1.ID, Valid: true},
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, walletRows, 3)
+	for _, row := range walletRows {
+		require.Equal(t, "expense", row.Type)
+		require.Equal(t, wallet1.Name, row.WalletName)
+	}
+}
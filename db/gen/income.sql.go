@@ -0,0 +1,187 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: income.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createIncome = `-- name: CreateIncome :one
+INSERT INTO incomes (
+    wallet_id,
+    amount,
+    income_description,
+    category_id
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, wallet_id, amount, income_description, category_id, created_at
+`
+
+type CreateIncomeParams struct {
+	WalletID          int64  `json:"wallet_id"`
+	Amount            int64  `json:"amount"`
+	IncomeDescription string `json:"income_description"`
+	CategoryID        int64  `json:"category_id"`
+}
+
+func (q *Queries) CreateIncome(ctx context.Context, arg CreateIncomeParams) (Income, error) {
+	row := q.queryRow(ctx, q.createIncomeStmt, createIncome,
+		arg.WalletID,
+		arg.Amount,
+		arg.IncomeDescription,
+		arg.CategoryID,
+	)
+	var i Income
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.IncomeDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteIncome = `-- name: DeleteIncome :exec
+DELETE FROM incomes
+WHERE id = $1
+`
+
+func (q *Queries) DeleteIncome(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteIncomeStmt, deleteIncome, id)
+	return err
+}
+
+const getIncome = `-- name: GetIncome :one
+SELECT id, wallet_id, amount, income_description, category_id, created_at FROM incomes
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetIncome(ctx context.Context, id int64) (Income, error) {
+	row := q.queryRow(ctx, q.getIncomeStmt, getIncome, id)
+	var i Income
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.IncomeDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getIncomeForUpdate = `-- name: GetIncomeForUpdate :one
+SELECT id, wallet_id, amount, income_description, category_id, created_at FROM incomes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetIncomeForUpdate(ctx context.Context, id int64) (Income, error) {
+	row := q.queryRow(ctx, q.getIncomeForUpdateStmt, getIncomeForUpdate, id)
+	var i Income
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.IncomeDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listIncomes = `-- name: ListIncomes :many
+SELECT id, wallet_id, amount, income_description, category_id, created_at FROM incomes
+WHERE wallet_id = $1
+  AND ($2::bigint IS NULL
+    OR (created_at, id) > ($3::timestamptz, $2))
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListIncomesParams struct {
+	WalletID        int64         `json:"wallet_id"`
+	CursorID        sql.NullInt64 `json:"cursor_id"`
+	CursorCreatedAt time.Time     `json:"cursor_created_at"`
+	Limit           int32         `json:"limit"`
+}
+
+func (q *Queries) ListIncomes(ctx context.Context, arg ListIncomesParams) ([]Income, error) {
+	rows, err := q.query(ctx, q.listIncomesStmt, listIncomes,
+		arg.WalletID,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Income{}
+	for rows.Next() {
+		var i Income
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.Amount,
+			&i.IncomeDescription,
+			&i.CategoryID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateIncome = `-- name: UpdateIncome :one
+UPDATE incomes
+SET
+    amount = COALESCE($1, amount),
+    income_description = COALESCE($2, income_description),
+    category_id = COALESCE($3, category_id)
+WHERE
+    id = $4
+RETURNING id, wallet_id, amount, income_description, category_id, created_at
+`
+
+type UpdateIncomeParams struct {
+	Amount            sql.NullInt64  `json:"amount"`
+	IncomeDescription sql.NullString `json:"income_description"`
+	CategoryID        sql.NullInt64  `json:"category_id"`
+	ID                int64          `json:"id"`
+}
+
+func (q *Queries) UpdateIncome(ctx context.Context, arg UpdateIncomeParams) (Income, error) {
+	row := q.queryRow(ctx, q.updateIncomeStmt, updateIncome,
+		arg.Amount,
+		arg.IncomeDescription,
+		arg.CategoryID,
+		arg.ID,
+	)
+	var i Income
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.Amount,
+		&i.IncomeDescription,
+		&i.CategoryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
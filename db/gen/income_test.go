@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func CreateRandomIncome(t *testing.T, wallet Wallet, category Category) Income {
+	arg := CreateIncomeParams{
+		WalletID:          wallet.ID,
+		Amount:            util.RandomAmount(),
+		IncomeDescription: util.RandomString(12),
+		CategoryID:        category.ID,
+	}
+	income, err := testQueries.CreateIncome(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, income)
+	require.Equal(t, arg.WalletID, income.WalletID)
+	require.Equal(t, arg.Amount, income.Amount)
+	require.Equal(t, arg.IncomeDescription, income.IncomeDescription)
+	require.Equal(t, arg.CategoryID, income.CategoryID)
+	require.NotZero(t, income.ID)
+	require.NotZero(t, income.CreatedAt)
+	return income
+}
+
+func TestCreateIncome(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	CreateRandomIncome(t, wallet, category)
+}
+
+func TestGetIncome(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	income1 := CreateRandomIncome(t, wallet, category)
+	income2, err := testQueries.GetIncome(context.Background(), income1.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, income2)
+	require.Equal(t, income1.ID, income2.ID)
+	require.Equal(t, income1.WalletID, income2.WalletID)
+	require.Equal(t, income1.Amount, income2.Amount)
+	require.Equal(t, income1.IncomeDescription, income2.IncomeDescription)
+	require.Equal(t, income1.CategoryID, income2.CategoryID)
+	require.WithinDuration(t, income1.CreatedAt, income2.CreatedAt, time.Second)
+}
+
+func TestUpdateIncomeOnlyAmount(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	income1 := CreateRandomIncome(t, wallet, category)
+
+	newAmount := util.RandomAmount() + 1000
+	income2, err := testQueries.UpdateIncome(context.Background(), UpdateIncomeParams{
+		ID:     income1.ID,
+		Amount: sql.NullInt64{Int64: newAmount, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, newAmount, income2.Amount)
+	require.Equal(t, income1.IncomeDescription, income2.IncomeDescription)
+	require.Equal(t, income1.CategoryID, income2.CategoryID)
+}
+
+func TestDeleteIncome(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	income1 := CreateRandomIncome(t, wallet, category)
+	err := testQueries.DeleteIncome(context.Background(), income1.ID)
+	require.NoError(t, err)
+	income2, err := testQueries.GetIncome(context.Background(), income1.ID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, income2)
+}
+
+func TestListIncomes(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	for i := 0; i < 10; i++ {
+		CreateRandomIncome(t, wallet, category)
+	}
+	arg := ListIncomesParams{
+		WalletID: wallet.ID,
+		Limit:    5,
+	}
+
+	incomes, err := testQueries.ListIncomes(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, incomes, 5)
+
+	last := incomes[len(incomes)-1]
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+	arg.CursorCreatedAt = last.CreatedAt
+	incomes, err = testQueries.ListIncomes(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, incomes, 5)
+	for _, income := range incomes {
+		require.NotEmpty(t, income)
+		require.Equal(t, wallet.ID, income.WalletID)
+		require.Greater(t, income.ID, last.ID)
+	}
+}
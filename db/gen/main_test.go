@@ -10,7 +10,9 @@ import (
 	"github.com/symyzi/financial-helper/util"
 )
 
+var testDB *sql.DB
 var testQueries *Queries
+var testStore Store
 
 func TestMain(m *testing.M) {
 	config, err := util.LoadConfig("../..")
@@ -22,7 +24,9 @@ func TestMain(m *testing.M) {
 		log.Fatal("cannot connect to db:", err)
 	}
 
+	testDB = conn
 	testQueries = New(conn)
+	testStore = NewStore(conn)
 
 	os.Exit(m.Run())
 }
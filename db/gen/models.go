@@ -6,8 +6,21 @@ package db
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
+type Attachment struct {
+	ID        int64 `json:"id"`
+	ExpenseID int64 `json:"expense_id"`
+	// key of the content in the blob store
+	BlobKey     string    `json:"blob_key"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 type Budget struct {
 	ID       int64 `json:"id"`
 	WalletID int64 `json:"wallet_id"`
@@ -15,6 +28,27 @@ type Budget struct {
 	Amount     int64     `json:"amount"`
 	CategoryID int64     `json:"category_id"`
 	CreatedAt  time.Time `json:"created_at"`
+	// weekly, monthly, yearly or custom
+	Period    string    `json:"period"`
+	StartDate time.Time `json:"start_date"`
+	// last day the budget applies; for custom periods the end of the first range
+	EndDate   *time.Time `json:"end_date"`
+	Recurring bool       `json:"recurring"`
+}
+
+type CategorizationRule struct {
+	ID         int64  `json:"id"`
+	Owner      string `json:"owner"`
+	CategoryID int64  `json:"category_id"`
+	// rules with a higher priority are tried first
+	Priority  int32  `json:"priority"`
+	MatchType string `json:"match_type"`
+	// matched against the expense description, case-insensitively
+	Pattern   *string   `json:"pattern"`
+	MinAmount *int64    `json:"min_amount"`
+	MaxAmount *int64    `json:"max_amount"`
+	WalletID  *int64    `json:"wallet_id"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Category struct {
@@ -24,14 +58,118 @@ type Category struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type ExchangeRate struct {
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	RateDate      time.Time `json:"rate_date"`
+	// units of quote_currency for one unit of base_currency
+	Rate      string    `json:"rate"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Expense struct {
 	ID       int64 `json:"id"`
 	WalletID int64 `json:"wallet_id"`
 	// must be positive
-	Amount             int64     `json:"amount"`
-	ExpenseDescription string    `json:"expense_description"`
-	CategoryID         int64     `json:"category_id"`
-	CreatedAt          time.Time `json:"created_at"`
+	Amount             int64      `json:"amount"`
+	ExpenseDescription string     `json:"expense_description"`
+	CategoryID         int64      `json:"category_id"`
+	CreatedAt          time.Time  `json:"created_at"`
+	RecurringExpenseID *int64     `json:"recurring_expense_id"`
+	OccurrenceDate     *time.Time `json:"occurrence_date"`
+	// transaction id from an imported bank statement
+	ExternalID *string `json:"external_id"`
+}
+
+type ExpenseAllocation struct {
+	ExpenseID  int64     `json:"expense_id"`
+	WalletID   int64     `json:"wallet_id"`
+	CategoryID int64     `json:"category_id"`
+	Amount     int64     `json:"amount"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// category allocations of an expense; they sum to the expense amount
+type ExpenseSplit struct {
+	ID         int64     `json:"id"`
+	ExpenseID  int64     `json:"expense_id"`
+	CategoryID int64     `json:"category_id"`
+	Amount     int64     `json:"amount"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ExpenseTag struct {
+	ExpenseID int64     `json:"expense_id"`
+	TagID     int64     `json:"tag_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Income struct {
+	ID       int64 `json:"id"`
+	WalletID int64 `json:"wallet_id"`
+	// must be positive
+	Amount            int64     `json:"amount"`
+	IncomeDescription string    `json:"income_description"`
+	CategoryID        int64     `json:"category_id"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type RecurringExpense struct {
+	ID         int64 `json:"id"`
+	WalletID   int64 `json:"wallet_id"`
+	CategoryID int64 `json:"category_id"`
+	// must be positive
+	Amount             int64  `json:"amount"`
+	ExpenseDescription string `json:"expense_description"`
+	Frequency          string `json:"frequency"`
+	Interval           int32  `json:"interval"`
+	// monthly and yearly rules fall on the last day of the month
+	LastDayOfMonth bool       `json:"last_day_of_month"`
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        *time.Time `json:"end_date"`
+	MaxOccurrences *int32     `json:"max_occurrences"`
+	Occurrences    int32      `json:"occurrences"`
+	// date of the next expense to materialize
+	NextOccurrence time.Time `json:"next_occurrence"`
+	Active         bool      `json:"active"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type RevokedToken struct {
+	// ID of the token payload; rows can be dropped once the token expires
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Session struct {
+	// ID of the refresh token payload
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	RefreshToken string    `json:"refresh_token"`
+	UserAgent    string    `json:"user_agent"`
+	ClientIp     string    `json:"client_ip"`
+	IsBlocked    bool      `json:"is_blocked"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Tag struct {
+	ID        int64     `json:"id"`
+	Owner     string    `json:"owner"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Transfer struct {
+	ID           int64 `json:"id"`
+	FromWalletID int64 `json:"from_wallet_id"`
+	ToWalletID   int64 `json:"to_wallet_id"`
+	// must be positive
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type User struct {
@@ -41,6 +179,11 @@ type User struct {
 	HashedPassword    string    `json:"hashed_password"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	// tokens issued before are rejected
+	TokensRevokedAt time.Time `json:"tokens_revoked_at"`
+	Role            string    `json:"role"`
+	// disabled users cannot log in and their tokens are rejected
+	DisabledAt *time.Time `json:"disabled_at"`
 }
 
 type Wallet struct {
@@ -49,4 +192,5 @@ type Wallet struct {
 	Owner     string    `json:"owner"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	Balance   int64     `json:"balance"`
 }
@@ -6,31 +6,141 @@ package db
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddExpenseTag(ctx context.Context, arg AddExpenseTagParams) error
+	AddWalletBalance(ctx context.Context, arg AddWalletBalanceParams) (Wallet, error)
+	BlockSession(ctx context.Context, id uuid.UUID) (Session, error)
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
+	CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (Attachment, error)
 	CreateBudget(ctx context.Context, arg CreateBudgetParams) (Budget, error)
+	CreateCategorizationRule(ctx context.Context, arg CreateCategorizationRuleParams) (CategorizationRule, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
 	CreateExpense(ctx context.Context, arg CreateExpenseParams) (Expense, error)
+	CreateExpenseOccurrence(ctx context.Context, arg CreateExpenseOccurrenceParams) (Expense, error)
+	CreateExpenseSplit(ctx context.Context, arg CreateExpenseSplitParams) (ExpenseSplit, error)
+	CreateImportedExpense(ctx context.Context, arg CreateImportedExpenseParams) (Expense, error)
+	CreateIncome(ctx context.Context, arg CreateIncomeParams) (Income, error)
+	CreateRecurringExpense(ctx context.Context, arg CreateRecurringExpenseParams) (RecurringExpense, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTag(ctx context.Context, arg CreateTagParams) (Tag, error)
+	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateWallet(ctx context.Context, arg CreateWalletParams) (Wallet, error)
+	DeleteAttachment(ctx context.Context, id int64) error
 	DeleteBudget(ctx context.Context, id int64) error
+	DeleteCategorizationRule(ctx context.Context, id int64) error
 	DeleteCategory(ctx context.Context, id int64) error
 	DeleteExpense(ctx context.Context, id int64) error
+	DeleteExpenseAttachments(ctx context.Context, expenseID int64) ([]string, error)
+	DeleteExpenseSplits(ctx context.Context, expenseID int64) error
+	DeleteExpiredRevokedTokens(ctx context.Context) (int64, error)
+	DeleteIncome(ctx context.Context, id int64) error
+	DeleteRecurringExpense(ctx context.Context, id int64) error
+	DeleteTag(ctx context.Context, id int64) error
 	DeleteWallet(ctx context.Context, arg DeleteWalletParams) error
+	// Keyset paged on (created_at, type, id) so an export can be streamed in batches
+	ExportTransactions(ctx context.Context, arg ExportTransactionsParams) ([]ExportTransactionsRow, error)
 	GetAllCategories(ctx context.Context, owner string) ([]Category, error)
+	GetAttachment(ctx context.Context, id int64) (Attachment, error)
 	GetBudgetByID(ctx context.Context, id int64) (Budget, error)
+	GetBudgetProgress(ctx context.Context, arg GetBudgetProgressParams) (GetBudgetProgressRow, error)
+	GetCategorizationRule(ctx context.Context, id int64) (CategorizationRule, error)
 	GetCategoryByID(ctx context.Context, id int64) (Category, error)
+	GetDueRecurringExpenseForUpdate(ctx context.Context, today time.Time) (RecurringExpense, error)
+	GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (ExchangeRate, error)
 	GetExpense(ctx context.Context, id int64) (Expense, error)
+	// FOR UPDATE also waits for attachments being added to the expense
+	GetExpenseForDelete(ctx context.Context, id int64) (Expense, error)
+	GetExpenseForUpdate(ctx context.Context, id int64) (Expense, error)
+	GetIncome(ctx context.Context, id int64) (Income, error)
+	GetIncomeForUpdate(ctx context.Context, id int64) (Income, error)
+	// Split expenses count towards each category they are allocated to. With tag_ids
+	// only expenses carrying at least one of the tags are counted.
+	GetMonthlyCategoryReport(ctx context.Context, arg GetMonthlyCategoryReportParams) ([]GetMonthlyCategoryReportRow, error)
+	GetOrCreateCategory(ctx context.Context, arg GetOrCreateCategoryParams) (Category, error)
+	GetRecurringExpense(ctx context.Context, id int64) (RecurringExpense, error)
+	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetSystemStats(ctx context.Context) (GetSystemStatsRow, error)
+	GetTag(ctx context.Context, id int64) (Tag, error)
+	// Filtering by tag_ids leaves out incomes, which cannot be tagged
+	GetTimeSeries(ctx context.Context, arg GetTimeSeriesParams) ([]GetTimeSeriesRow, error)
+	// Tokens of the user issued before valid_after are no longer accepted;
+	// disabled users have no row
+	GetTokenStatus(ctx context.Context, arg GetTokenStatusParams) (GetTokenStatusRow, error)
+	GetTransfer(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
 	GetWallet(ctx context.Context, id int64) (Wallet, error)
+	GetWalletForUpdate(ctx context.Context, id int64) (Wallet, error)
+	ListActiveSessions(ctx context.Context, username string) ([]Session, error)
 	ListBudgets(ctx context.Context, arg ListBudgetsParams) ([]Budget, error)
-	ListExpenses(ctx context.Context, arg ListExpensesParams) ([]Expense, error)
+	ListBudgetsByWallet(ctx context.Context, walletID int64) ([]Budget, error)
+	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
+	ListCategorizationRules(ctx context.Context, owner string) ([]CategorizationRule, error)
+	ListCategoryExpenses(ctx context.Context, arg ListCategoryExpensesParams) ([]Expense, error)
+	ListExchangeRatesForCurrencies(ctx context.Context, arg ListExchangeRatesForCurrenciesParams) ([]ExchangeRate, error)
+	ListExpenseAttachments(ctx context.Context, expenseID int64) ([]Attachment, error)
+	ListExpenseSplits(ctx context.Context, expenseID int64) ([]ExpenseSplit, error)
+	ListExpenseTags(ctx context.Context, expenseID int64) ([]Tag, error)
+	ListExpensesByAmountAsc(ctx context.Context, arg ListExpensesByAmountAscParams) ([]Expense, error)
+	ListExpensesByAmountDesc(ctx context.Context, arg ListExpensesByAmountDescParams) ([]Expense, error)
+	ListExpensesByDateAsc(ctx context.Context, arg ListExpensesByDateAscParams) ([]Expense, error)
+	// Every sort order has its own ListExpenses query, so the cursor and the
+	// ORDER BY can use the matching (wallet_id, ..., id) index.
+	ListExpensesByDateDesc(ctx context.Context, arg ListExpensesByDateDescParams) ([]Expense, error)
+	ListIncomes(ctx context.Context, arg ListIncomesParams) ([]Income, error)
+	ListOwnerBudgets(ctx context.Context, owner string) ([]Budget, error)
+	ListOwnerCategories(ctx context.Context, owner string) ([]Category, error)
+	ListOwnerExpenseSplits(ctx context.Context, owner string) ([]ExpenseSplit, error)
+	ListOwnerExpenseTags(ctx context.Context, owner string) ([]ExpenseTag, error)
+	ListOwnerExpenses(ctx context.Context, owner string) ([]Expense, error)
+	ListOwnerIncomes(ctx context.Context, owner string) ([]Income, error)
+	ListOwnerTags(ctx context.Context, owner string) ([]Tag, error)
+	ListOwnerTransfers(ctx context.Context, owner string) ([]Transfer, error)
+	ListOwnerWallets(ctx context.Context, owner string) ([]Wallet, error)
+	ListRecurringExpenses(ctx context.Context, arg ListRecurringExpensesParams) ([]RecurringExpense, error)
+	ListTags(ctx context.Context, owner string) ([]Tag, error)
+	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListWalletExpenseExternalIDs(ctx context.Context, arg ListWalletExpenseExternalIDsParams) ([]string, error)
+	ListWalletExpensesBetween(ctx context.Context, arg ListWalletExpensesBetweenParams) ([]Expense, error)
 	ListWallets(ctx context.Context, arg ListWalletsParams) ([]Wallet, error)
+	RemoveExpenseTag(ctx context.Context, arg RemoveExpenseTagParams) (int64, error)
+	RestoreBudget(ctx context.Context, arg RestoreBudgetParams) (Budget, error)
+	// An existing category with the same name is reused
+	RestoreCategory(ctx context.Context, arg RestoreCategoryParams) (Category, error)
+	RestoreExpense(ctx context.Context, arg RestoreExpenseParams) (Expense, error)
+	RestoreExpenseSplit(ctx context.Context, arg RestoreExpenseSplitParams) (ExpenseSplit, error)
+	RestoreExpenseTag(ctx context.Context, arg RestoreExpenseTagParams) error
+	RestoreIncome(ctx context.Context, arg RestoreIncomeParams) (Income, error)
+	// An existing tag with the same name is reused
+	RestoreTag(ctx context.Context, arg RestoreTagParams) (Tag, error)
+	RestoreTransfer(ctx context.Context, arg RestoreTransferParams) (Transfer, error)
+	RestoreWallet(ctx context.Context, arg RestoreWalletParams) (Wallet, error)
+	RevokeToken(ctx context.Context, arg RevokeTokenParams) error
+	// revoked_at comes from the application clock, which also sets the issue time
+	// of the tokens it is compared with
+	RevokeUserTokens(ctx context.Context, arg RevokeUserTokensParams) (time.Time, error)
+	// Moves expense ids[i] to category_ids[i]; expenses that left from_category_id
+	// in the meantime are not touched
+	SetExpenseCategories(ctx context.Context, arg SetExpenseCategoriesParams) (int64, error)
+	// Disabling also rejects every token issued before revoked_at
+	SetUserDisabled(ctx context.Context, arg SetUserDisabledParams) (User, error)
+	// Tokens carry the role, so changing it rejects every token issued before revoked_at
+	SetUserRole(ctx context.Context, arg SetUserRoleParams) (User, error)
 	UpdateBudget(ctx context.Context, arg UpdateBudgetParams) (Budget, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
 	UpdateExpense(ctx context.Context, arg UpdateExpenseParams) (Expense, error)
+	UpdateIncome(ctx context.Context, arg UpdateIncomeParams) (Income, error)
+	UpdateRecurringExpenseSchedule(ctx context.Context, arg UpdateRecurringExpenseScheduleParams) (RecurringExpense, error)
+	UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateWallet(ctx context.Context, arg UpdateWalletParams) (Wallet, error)
+	UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,249 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: recurring_expense.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createRecurringExpense = `-- name: CreateRecurringExpense :one
+INSERT INTO recurring_expenses (
+  wallet_id,
+  category_id,
+  amount,
+  expense_description,
+  frequency,
+  interval,
+  last_day_of_month,
+  start_date,
+  end_date,
+  max_occurrences,
+  next_occurrence
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $8
+)
+RETURNING id, wallet_id, category_id, amount, expense_description, frequency, interval, last_day_of_month, start_date, end_date, max_occurrences, occurrences, next_occurrence, active, created_at
+`
+
+type CreateRecurringExpenseParams struct {
+	WalletID           int64      `json:"wallet_id"`
+	CategoryID         int64      `json:"category_id"`
+	Amount             int64      `json:"amount"`
+	ExpenseDescription string     `json:"expense_description"`
+	Frequency          string     `json:"frequency"`
+	Interval           int32      `json:"interval"`
+	LastDayOfMonth     bool       `json:"last_day_of_month"`
+	StartDate          time.Time  `json:"start_date"`
+	EndDate            *time.Time `json:"end_date"`
+	MaxOccurrences     *int32     `json:"max_occurrences"`
+}
+
+func (q *Queries) CreateRecurringExpense(ctx context.Context, arg CreateRecurringExpenseParams) (RecurringExpense, error) {
+	row := q.queryRow(ctx, q.createRecurringExpenseStmt, createRecurringExpense,
+		arg.WalletID,
+		arg.CategoryID,
+		arg.Amount,
+		arg.ExpenseDescription,
+		arg.Frequency,
+		arg.Interval,
+		arg.LastDayOfMonth,
+		arg.StartDate,
+		arg.EndDate,
+		arg.MaxOccurrences,
+	)
+	var i RecurringExpense
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.CategoryID,
+		&i.Amount,
+		&i.ExpenseDescription,
+		&i.Frequency,
+		&i.Interval,
+		&i.LastDayOfMonth,
+		&i.StartDate,
+		&i.EndDate,
+		&i.MaxOccurrences,
+		&i.Occurrences,
+		&i.NextOccurrence,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteRecurringExpense = `-- name: DeleteRecurringExpense :exec
+DELETE FROM recurring_expenses
+WHERE id = $1
+`
+
+func (q *Queries) DeleteRecurringExpense(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteRecurringExpenseStmt, deleteRecurringExpense, id)
+	return err
+}
+
+const getDueRecurringExpenseForUpdate = `-- name: GetDueRecurringExpenseForUpdate :one
+SELECT id, wallet_id, category_id, amount, expense_description, frequency, interval, last_day_of_month, start_date, end_date, max_occurrences, occurrences, next_occurrence, active, created_at FROM recurring_expenses
+WHERE active AND next_occurrence <= $1::date
+ORDER BY next_occurrence, id
+LIMIT 1
+FOR UPDATE SKIP LOCKED
+`
+
+func (q *Queries) GetDueRecurringExpenseForUpdate(ctx context.Context, today time.Time) (RecurringExpense, error) {
+	row := q.queryRow(ctx, q.getDueRecurringExpenseForUpdateStmt, getDueRecurringExpenseForUpdate, today)
+	var i RecurringExpense
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.CategoryID,
+		&i.Amount,
+		&i.ExpenseDescription,
+		&i.Frequency,
+		&i.Interval,
+		&i.LastDayOfMonth,
+		&i.StartDate,
+		&i.EndDate,
+		&i.MaxOccurrences,
+		&i.Occurrences,
+		&i.NextOccurrence,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRecurringExpense = `-- name: GetRecurringExpense :one
+SELECT id, wallet_id, category_id, amount, expense_description, frequency, interval, last_day_of_month, start_date, end_date, max_occurrences, occurrences, next_occurrence, active, created_at FROM recurring_expenses
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetRecurringExpense(ctx context.Context, id int64) (RecurringExpense, error) {
+	row := q.queryRow(ctx, q.getRecurringExpenseStmt, getRecurringExpense, id)
+	var i RecurringExpense
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.CategoryID,
+		&i.Amount,
+		&i.ExpenseDescription,
+		&i.Frequency,
+		&i.Interval,
+		&i.LastDayOfMonth,
+		&i.StartDate,
+		&i.EndDate,
+		&i.MaxOccurrences,
+		&i.Occurrences,
+		&i.NextOccurrence,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listRecurringExpenses = `-- name: ListRecurringExpenses :many
+SELECT id, wallet_id, category_id, amount, expense_description, frequency, interval, last_day_of_month, start_date, end_date, max_occurrences, occurrences, next_occurrence, active, created_at FROM recurring_expenses
+WHERE wallet_id = $1
+  AND ($2::bigint IS NULL
+    OR (created_at, id) > ($3::timestamptz, $2))
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListRecurringExpensesParams struct {
+	WalletID        int64         `json:"wallet_id"`
+	CursorID        sql.NullInt64 `json:"cursor_id"`
+	CursorCreatedAt time.Time     `json:"cursor_created_at"`
+	Limit           int32         `json:"limit"`
+}
+
+func (q *Queries) ListRecurringExpenses(ctx context.Context, arg ListRecurringExpensesParams) ([]RecurringExpense, error) {
+	rows, err := q.query(ctx, q.listRecurringExpensesStmt, listRecurringExpenses,
+		arg.WalletID,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RecurringExpense{}
+	for rows.Next() {
+		var i RecurringExpense
+		if err := rows.Scan(
+			&i.ID,
+			&i.WalletID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.ExpenseDescription,
+			&i.Frequency,
+			&i.Interval,
+			&i.LastDayOfMonth,
+			&i.StartDate,
+			&i.EndDate,
+			&i.MaxOccurrences,
+			&i.Occurrences,
+			&i.NextOccurrence,
+			&i.Active,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateRecurringExpenseSchedule = `-- name: UpdateRecurringExpenseSchedule :one
+UPDATE recurring_expenses
+SET occurrences = $2, next_occurrence = $3, active = $4
+WHERE id = $1
+RETURNING id, wallet_id, category_id, amount, expense_description, frequency, interval, last_day_of_month, start_date, end_date, max_occurrences, occurrences, next_occurrence, active, created_at
+`
+
+type UpdateRecurringExpenseScheduleParams struct {
+	ID             int64     `json:"id"`
+	Occurrences    int32     `json:"occurrences"`
+	NextOccurrence time.Time `json:"next_occurrence"`
+	Active         bool      `json:"active"`
+}
+
+func (q *Queries) UpdateRecurringExpenseSchedule(ctx context.Context, arg UpdateRecurringExpenseScheduleParams) (RecurringExpense, error) {
+	row := q.queryRow(ctx, q.updateRecurringExpenseScheduleStmt, updateRecurringExpenseSchedule,
+		arg.ID,
+		arg.Occurrences,
+		arg.NextOccurrence,
+		arg.Active,
+	)
+	var i RecurringExpense
+	err := row.Scan(
+		&i.ID,
+		&i.WalletID,
+		&i.CategoryID,
+		&i.Amount,
+		&i.ExpenseDescription,
+		&i.Frequency,
+		&i.Interval,
+		&i.LastDayOfMonth,
+		&i.StartDate,
+		&i.EndDate,
+		&i.MaxOccurrences,
+		&i.Occurrences,
+		&i.NextOccurrence,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func CreateRandomRecurringExpense(t *testing.T, wallet Wallet, category Category, startDate time.Time, maxOccurrences *int32) RecurringExpense {
+	arg := CreateRecurringExpenseParams{
+		WalletID:           wallet.ID,
+		CategoryID:         category.ID,
+		Amount:             util.RandomAmount(),
+		ExpenseDescription: util.RandomString(12),
+		Frequency:          util.FrequencyDaily,
+		Interval:           1,
+		StartDate:          startDate,
+		MaxOccurrences:     maxOccurrences,
+	}
+	recurringExpense, err := testQueries.CreateRecurringExpense(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, recurringExpense)
+	require.Equal(t, arg.WalletID, recurringExpense.WalletID)
+	require.Equal(t, arg.CategoryID, recurringExpense.CategoryID)
+	require.Equal(t, arg.Amount, recurringExpense.Amount)
+	require.Equal(t, arg.Frequency, recurringExpense.Frequency)
+	require.WithinDuration(t, arg.StartDate, recurringExpense.StartDate, time.Second)
+	require.WithinDuration(t, arg.StartDate, recurringExpense.NextOccurrence, time.Second)
+	require.Zero(t, recurringExpense.Occurrences)
+	require.True(t, recurringExpense.Active)
+	require.NotZero(t, recurringExpense.ID)
+	return recurringExpense
+}
+
+func TestCreateRecurringExpense(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	CreateRandomRecurringExpense(t, wallet, category, util.Day(time.Now()).AddDate(0, 0, 1), nil)
+}
+
+func TestDeleteRecurringExpense(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	recurringExpense := CreateRandomRecurringExpense(t, wallet, category, util.Day(time.Now()).AddDate(0, 0, 1), nil)
+
+	err := testQueries.DeleteRecurringExpense(context.Background(), recurringExpense.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetRecurringExpense(context.Background(), recurringExpense.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestMaterializeRecurringExpenseTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+
+	today := util.Day(time.Now())
+	maxOccurrences := int32(3)
+	recurringExpense := CreateRandomRecurringExpense(t, wallet, category, today.AddDate(0, 0, -10), &maxOccurrences)
+
+	// other tests may leave due rules behind, so drain everything that is due
+	for {
+		_, err := testStore.MaterializeRecurringExpenseTx(context.Background(), today)
+		if errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	updated, err := testQueries.GetRecurringExpense(context.Background(), recurringExpense.ID)
+	require.NoError(t, err)
+	require.Equal(t, maxOccurrences, updated.Occurrences)
+	require.False(t, updated.Active)
+
+	updatedWallet, err := testQueries.GetWallet(context.Background(), wallet.ID)
+	require.NoError(t, err)
+	require.Equal(t, wallet.Balance-int64(maxOccurrences)*recurringExpense.Amount, updatedWallet.Balance)
+
+	_, err = testStore.MaterializeRecurringExpenseTx(context.Background(), today)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
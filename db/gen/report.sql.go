@@ -0,0 +1,196 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: report.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const getMonthlyCategoryReport = `-- name: GetMonthlyCategoryReport :many
+SELECT
+  date_trunc('month', e.created_at AT TIME ZONE 'UTC')::date AS month,
+  w.currency,
+  e.category_id,
+  c.name AS category_name,
+  COUNT(DISTINCT e.expense_id)::bigint AS expense_count,
+  SUM(e.amount)::bigint AS total,
+  round(SUM(e.amount)::numeric / COUNT(DISTINCT e.expense_id), 2)::float8 AS average,
+  (SUM(SUM(e.amount)) OVER (PARTITION BY date_trunc('month', e.created_at AT TIME ZONE 'UTC'), w.currency))::bigint AS month_total,
+  round(
+    SUM(e.amount) * 100.0
+    / SUM(SUM(e.amount)) OVER (PARTITION BY date_trunc('month', e.created_at AT TIME ZONE 'UTC'), w.currency),
+    2
+  )::float8 AS percent_of_total
+FROM expense_allocations e
+JOIN wallets w ON w.id = e.wallet_id
+JOIN categories c ON c.id = e.category_id
+WHERE w.owner = $1
+  AND (COALESCE(cardinality($2::bigint[]), 0) = 0 OR e.wallet_id = ANY($2::bigint[]))
+  AND e.created_at >= $3::timestamptz
+  AND e.created_at < $4::timestamptz
+  AND (COALESCE(cardinality($5::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = e.expense_id AND et.tag_id = ANY($5::bigint[])
+  ))
+GROUP BY 1, 2, 3, 4
+ORDER BY month, w.currency, total DESC, e.category_id
+`
+
+type GetMonthlyCategoryReportParams struct {
+	Owner     string    `json:"owner"`
+	WalletIds []int64   `json:"wallet_ids"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+	TagIds    []int64   `json:"tag_ids"`
+}
+
+type GetMonthlyCategoryReportRow struct {
+	Month          time.Time `json:"month"`
+	Currency       string    `json:"currency"`
+	CategoryID     int64     `json:"category_id"`
+	CategoryName   string    `json:"category_name"`
+	ExpenseCount   int64     `json:"expense_count"`
+	Total          int64     `json:"total"`
+	Average        float64   `json:"average"`
+	MonthTotal     int64     `json:"month_total"`
+	PercentOfTotal float64   `json:"percent_of_total"`
+}
+
+// Split expenses count towards each category they are allocated to. With tag_ids
+// only expenses carrying at least one of the tags are counted.
+func (q *Queries) GetMonthlyCategoryReport(ctx context.Context, arg GetMonthlyCategoryReportParams) ([]GetMonthlyCategoryReportRow, error) {
+	rows, err := q.query(ctx, q.getMonthlyCategoryReportStmt, getMonthlyCategoryReport,
+		arg.Owner,
+		pq.Array(arg.WalletIds),
+		arg.FromTime,
+		arg.ToTime,
+		pq.Array(arg.TagIds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMonthlyCategoryReportRow{}
+	for rows.Next() {
+		var i GetMonthlyCategoryReportRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.Currency,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.ExpenseCount,
+			&i.Total,
+			&i.Average,
+			&i.MonthTotal,
+			&i.PercentOfTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTimeSeries = `-- name: GetTimeSeries :many
+WITH entries AS (
+  SELECT e.created_at, e.wallet_id, e.category_id, w.currency, e.amount AS expense, 0::bigint AS income
+  FROM expense_allocations e
+  JOIN wallets w ON w.id = e.wallet_id
+  WHERE w.owner = $3
+    AND (COALESCE(cardinality($4::bigint[]), 0) = 0 OR e.wallet_id = ANY($4::bigint[]))
+    AND e.created_at >= $5::timestamptz
+    AND e.created_at < $6::timestamptz
+    AND (COALESCE(cardinality($7::bigint[]), 0) = 0 OR EXISTS (
+      SELECT 1 FROM expense_tags et WHERE et.expense_id = e.expense_id AND et.tag_id = ANY($7::bigint[])
+    ))
+  UNION ALL
+  SELECT i.created_at, i.wallet_id, i.category_id, w.currency, 0::bigint, i.amount
+  FROM incomes i
+  JOIN wallets w ON w.id = i.wallet_id
+  WHERE w.owner = $3
+    AND (COALESCE(cardinality($4::bigint[]), 0) = 0 OR i.wallet_id = ANY($4::bigint[]))
+    AND i.created_at >= $5::timestamptz
+    AND i.created_at < $6::timestamptz
+    AND COALESCE(cardinality($7::bigint[]), 0) = 0
+)
+SELECT
+  date_trunc($1::text, created_at AT TIME ZONE 'UTC')::timestamp AS bucket,
+  (CASE $2::text
+    WHEN 'category' THEN category_id
+    WHEN 'wallet' THEN wallet_id
+    ELSE 0
+  END)::bigint AS group_id,
+  currency,
+  SUM(expense)::bigint AS expenses,
+  SUM(income)::bigint AS incomes
+FROM entries
+GROUP BY 1, 2, 3
+ORDER BY 1, 2, 3
+`
+
+type GetTimeSeriesParams struct {
+	Bucket    string    `json:"bucket"`
+	GroupBy   string    `json:"group_by"`
+	Owner     string    `json:"owner"`
+	WalletIds []int64   `json:"wallet_ids"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+	TagIds    []int64   `json:"tag_ids"`
+}
+
+type GetTimeSeriesRow struct {
+	Bucket   time.Time `json:"bucket"`
+	GroupID  int64     `json:"group_id"`
+	Currency string    `json:"currency"`
+	Expenses int64     `json:"expenses"`
+	Incomes  int64     `json:"incomes"`
+}
+
+// Filtering by tag_ids leaves out incomes, which cannot be tagged
+func (q *Queries) GetTimeSeries(ctx context.Context, arg GetTimeSeriesParams) ([]GetTimeSeriesRow, error) {
+	rows, err := q.query(ctx, q.getTimeSeriesStmt, getTimeSeries,
+		arg.Bucket,
+		arg.GroupBy,
+		arg.Owner,
+		pq.Array(arg.WalletIds),
+		arg.FromTime,
+		arg.ToTime,
+		pq.Array(arg.TagIds),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTimeSeriesRow{}
+	for rows.Next() {
+		var i GetTimeSeriesRow
+		if err := rows.Scan(
+			&i.Bucket,
+			&i.GroupID,
+			&i.Currency,
+			&i.Expenses,
+			&i.Incomes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMonthlyCategoryReport(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category1 := CreateRandomCategory(t, user)
+	category2 := CreateRandomCategory(t, user)
+
+	totals := map[int64]int64{}
+	counts := map[int64]int64{}
+	var monthTotal int64
+	for i := 0; i < 3; i++ {
+		for _, category := range []Category{category1, category2} {
+			expense := CreateRandomExpense(t, wallet, category)
+			totals[category.ID] += expense.Amount
+			counts[category.ID]++
+			monthTotal += expense.Amount
+		}
+	}
+
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	arg := GetMonthlyCategoryReportParams{
+		Owner:     user.Username,
+		WalletIds: []int64{},
+		FromTime:  month,
+		ToTime:    month.AddDate(0, 1, 0),
+	}
+	rows, err := testQueries.GetMonthlyCategoryReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	var percent float64
+	for _, row := range rows {
+		require.True(t, month.Equal(row.Month))
+		require.Equal(t, wallet.Currency, row.Currency)
+		require.Equal(t, totals[row.CategoryID], row.Total)
+		require.Equal(t, counts[row.CategoryID], row.ExpenseCount)
+		require.InDelta(t, float64(row.Total)/float64(row.ExpenseCount), row.Average, 0.01)
+		require.Equal(t, monthTotal, row.MonthTotal)
+		percent += row.PercentOfTotal
+	}
+	require.InDelta(t, 100, percent, 0.02)
+	require.GreaterOrEqual(t, rows[0].Total, rows[1].Total)
+
+	arg.WalletIds = []int64{CreateRandomWallet(t, user).ID}
+	rows, err = testQueries.GetMonthlyCategoryReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
+
+func TestGetTimeSeries(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet1 := CreateRandomWallet(t, user)
+	wallet2 := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+
+	expenses := map[int64]int64{}
+	incomes := map[int64]int64{}
+	for _, wallet := range []Wallet{wallet1, wallet2} {
+		expense := CreateRandomExpense(t, wallet, category)
+		expenses[wallet.ID] += expense.Amount
+		income := CreateRandomIncome(t, wallet, category)
+		incomes[wallet.ID] += income.Amount
+	}
+
+	now := time.Now().UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	arg := GetTimeSeriesParams{
+		Bucket:    "day",
+		GroupBy:   "wallet",
+		Owner:     user.Username,
+		WalletIds: []int64{},
+		FromTime:  day,
+		ToTime:    day.AddDate(0, 0, 1),
+	}
+	rows, err := testQueries.GetTimeSeries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		require.True(t, day.Equal(row.Bucket.UTC()))
+		require.Equal(t, expenses[row.GroupID], row.Expenses)
+		require.Equal(t, incomes[row.GroupID], row.Incomes)
+	}
+
+	arg.GroupBy = ""
+	arg.WalletIds = []int64{wallet1.ID}
+	rows, err = testQueries.GetTimeSeries(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Zero(t, rows[0].GroupID)
+	require.Equal(t, expenses[wallet1.ID], rows[0].Expenses)
+	require.Equal(t, incomes[wallet1.ID], rows[0].Incomes)
+}
@@ -0,0 +1,96 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: revoked_token.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const deleteExpiredRevokedTokens = `-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens
+WHERE expires_at < now()
+`
+
+func (q *Queries) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	result, err := q.exec(ctx, q.deleteExpiredRevokedTokensStmt, deleteExpiredRevokedTokens)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getTokenStatus = `-- name: GetTokenStatus :one
+SELECT
+  EXISTS (SELECT 1 FROM revoked_tokens r WHERE r.id = $1)::boolean AS revoked,
+  GREATEST(u.password_changed_at, u.tokens_revoked_at)::timestamptz AS valid_after
+FROM users u
+WHERE u.username = $2 AND u.disabled_at IS NULL
+`
+
+type GetTokenStatusParams struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+}
+
+type GetTokenStatusRow struct {
+	Revoked    bool      `json:"revoked"`
+	ValidAfter time.Time `json:"valid_after"`
+}
+
+// Tokens of the user issued before valid_after are no longer accepted;
+// disabled users have no row
+func (q *Queries) GetTokenStatus(ctx context.Context, arg GetTokenStatusParams) (GetTokenStatusRow, error) {
+	row := q.queryRow(ctx, q.getTokenStatusStmt, getTokenStatus, arg.ID, arg.Username)
+	var i GetTokenStatusRow
+	err := row.Scan(&i.Revoked, &i.ValidAfter)
+	return i, err
+}
+
+const revokeToken = `-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (
+  id,
+  username,
+  expires_at
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (id) DO NOTHING
+`
+
+type RevokeTokenParams struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (q *Queries) RevokeToken(ctx context.Context, arg RevokeTokenParams) error {
+	_, err := q.exec(ctx, q.revokeTokenStmt, revokeToken, arg.ID, arg.Username, arg.ExpiresAt)
+	return err
+}
+
+const revokeUserTokens = `-- name: RevokeUserTokens :one
+UPDATE users
+SET tokens_revoked_at = $1
+WHERE username = $2
+RETURNING tokens_revoked_at
+`
+
+type RevokeUserTokensParams struct {
+	RevokedAt time.Time `json:"revoked_at"`
+	Username  string    `json:"username"`
+}
+
+// revoked_at comes from the application clock, which also sets the issue time
+// of the tokens it is compared with
+func (q *Queries) RevokeUserTokens(ctx context.Context, arg RevokeUserTokensParams) (time.Time, error) {
+	row := q.queryRow(ctx, q.revokeUserTokensStmt, revokeUserTokens, arg.RevokedAt, arg.Username)
+	var tokens_revoked_at time.Time
+	err := row.Scan(&tokens_revoked_at)
+	return tokens_revoked_at, err
+}
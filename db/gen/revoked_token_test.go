@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeToken(t *testing.T) {
+	user := CreateRandomUser(t)
+	arg := RevokeTokenParams{
+		ID:        uuid.New(),
+		Username:  user.Username,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, testQueries.RevokeToken(context.Background(), arg))
+	// revoking twice is not an error
+	require.NoError(t, testQueries.RevokeToken(context.Background(), arg))
+
+	status, err := testQueries.GetTokenStatus(context.Background(), GetTokenStatusParams{ID: arg.ID, Username: user.Username})
+	require.NoError(t, err)
+	require.True(t, status.Revoked)
+
+	status, err = testQueries.GetTokenStatus(context.Background(), GetTokenStatusParams{ID: uuid.New(), Username: user.Username})
+	require.NoError(t, err)
+	require.False(t, status.Revoked)
+	require.WithinDuration(t, user.PasswordChangedAt, status.ValidAfter, time.Second)
+
+	expired := RevokeTokenParams{ID: uuid.New(), Username: user.Username, ExpiresAt: time.Now().Add(-time.Minute)}
+	require.NoError(t, testQueries.RevokeToken(context.Background(), expired))
+
+	n, err := testQueries.DeleteExpiredRevokedTokens(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, n, int64(1))
+
+	status, err = testQueries.GetTokenStatus(context.Background(), GetTokenStatusParams{ID: expired.ID, Username: user.Username})
+	require.NoError(t, err)
+	require.False(t, status.Revoked)
+}
+
+func TestLogoutAllTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	session := CreateRandomSession(t, user, time.Now().Add(time.Hour))
+
+	revokedAt, err := testStore.LogoutAllTx(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), revokedAt, time.Minute)
+
+	got, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, got.IsBlocked)
+
+	status, err := testQueries.GetTokenStatus(context.Background(), GetTokenStatusParams{ID: uuid.New(), Username: user.Username})
+	require.NoError(t, err)
+	require.WithinDuration(t, revokedAt, status.ValidAfter, time.Second)
+}
+
+func TestLogoutTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	session := CreateRandomSession(t, user, time.Now().Add(time.Hour))
+	token := RevokeTokenParams{ID: session.ID, Username: user.Username, ExpiresAt: session.ExpiresAt}
+
+	err := testStore.LogoutTx(context.Background(), LogoutTxParams{SessionID: session.ID, Tokens: []RevokeTokenParams{token}})
+	require.NoError(t, err)
+
+	got, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, got.IsBlocked)
+
+	status, err := testQueries.GetTokenStatus(context.Background(), GetTokenStatusParams{ID: token.ID, Username: user.Username})
+	require.NoError(t, err)
+	require.True(t, status.Revoked)
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func CreateRandomSession(t *testing.T, user User, expiresAt time.Time) Session {
+	arg := CreateSessionParams{
+		ID:           uuid.New(),
+		Username:     user.Username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    "go-test",
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    expiresAt,
+	}
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.ID)
+	require.Equal(t, arg.RefreshToken, session.RefreshToken)
+	require.False(t, session.IsBlocked)
+	return session
+}
+
+func TestSessions(t *testing.T) {
+	user := CreateRandomUser(t)
+	session1 := CreateRandomSession(t, user, time.Now().Add(time.Hour))
+	session2 := CreateRandomSession(t, user, time.Now().Add(time.Hour))
+	CreateRandomSession(t, user, time.Now().Add(-time.Hour))
+
+	got, err := testQueries.GetSession(context.Background(), session1.ID)
+	require.NoError(t, err)
+	require.Equal(t, session1.Username, got.Username)
+
+	blocked, err := testQueries.BlockSession(context.Background(), session1.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+
+	// blocked and expired sessions are not listed
+	sessions, err := testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session2.ID, sessions[0].ID)
+}
@@ -1,12 +1,33 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/symyzi/financial-helper/util"
 )
 
 type Store interface {
 	Querier
-	// execTx(ctx context.Context, fn func(*Queries) error) error
+	CreateExpenseTx(ctx context.Context, arg CreateExpenseParams) (ExpenseTxResult, error)
+	UpdateExpenseTx(ctx context.Context, arg UpdateExpenseParams) (ExpenseTxResult, error)
+	DeleteExpenseTx(ctx context.Context, id int64) (DeleteExpenseTxResult, error)
+	CreateIncomeTx(ctx context.Context, arg CreateIncomeParams) (IncomeTxResult, error)
+	UpdateIncomeTx(ctx context.Context, arg UpdateIncomeParams) (IncomeTxResult, error)
+	DeleteIncomeTx(ctx context.Context, id int64) (IncomeTxResult, error)
+	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	MaterializeRecurringExpenseTx(ctx context.Context, today time.Time) (MaterializeRecurringExpenseTxResult, error)
+	ImportExpensesTx(ctx context.Context, arg ImportExpensesTxParams) (ImportExpensesTxResult, error)
+	SetExpenseSplitsTx(ctx context.Context, arg SetExpenseSplitsTxParams) (SetExpenseSplitsTxResult, error)
+	BackupTx(ctx context.Context, owner string) (OwnerData, error)
+	RestoreTx(ctx context.Context, arg RestoreTxParams) (RestoreTxResult, error)
+	LogoutTx(ctx context.Context, arg LogoutTxParams) error
+	LogoutAllTx(ctx context.Context, username string) (time.Time, error)
 }
 
 type SQLStore struct {
@@ -21,20 +42,851 @@ func NewStore(db *sql.DB) Store {
 	}
 }
 
-// func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-// 	tx, err := store.db.BeginTx(ctx, nil)
-// 	if err != nil {
-// 		return err
-// 	}
+// execTx executes fn within a database transaction
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+	return store.execTxOptions(ctx, nil, fn)
+}
+
+// execTxOptions executes fn within a database transaction started with opts
+func (store *SQLStore) execTxOptions(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	q := New(tx)
+	err = fn(q)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ExpenseTxResult is the result of an expense transaction
+type ExpenseTxResult struct {
+	Expense Expense `json:"expense"`
+	Wallet  Wallet  `json:"wallet"`
+}
+
+// CreateExpenseTx creates an expense and debits its wallet balance
+func (store *SQLStore) CreateExpenseTx(ctx context.Context, arg CreateExpenseParams) (ExpenseTxResult, error) {
+	var result ExpenseTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Expense, err = q.CreateExpense(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		result.Wallet, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+			ID:     result.Expense.WalletID,
+			Amount: -result.Expense.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateExpenseTx updates an expense and applies the amount difference to its wallet balance.
+// The amount of a split expense cannot change until its splits are replaced.
+func (store *SQLStore) UpdateExpenseTx(ctx context.Context, arg UpdateExpenseParams) (ExpenseTxResult, error) {
+	var result ExpenseTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		old, err := q.GetExpenseForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if arg.Amount.Valid && arg.Amount.Int64 != old.Amount {
+			splits, err := q.ListExpenseSplits(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			if len(splits) > 0 {
+				return ErrSplitTotal
+			}
+		}
+
+		result.Expense, err = q.UpdateExpense(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		result.Wallet, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+			ID:     result.Expense.WalletID,
+			Amount: old.Amount - result.Expense.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteExpenseTxResult is the result of the delete expense transaction
+type DeleteExpenseTxResult struct {
+	Expense  Expense  `json:"expense"`
+	Wallet   Wallet   `json:"wallet"`
+	BlobKeys []string `json:"blob_keys"`
+}
+
+// DeleteExpenseTx deletes an expense with its attachments and credits its amount
+// back to the wallet balance. It returns the blob keys of the deleted attachments.
+func (store *SQLStore) DeleteExpenseTx(ctx context.Context, id int64) (DeleteExpenseTxResult, error) {
+	var result DeleteExpenseTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Expense, err = q.GetExpenseForDelete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		result.BlobKeys, err = q.DeleteExpenseAttachments(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteExpense(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		result.Wallet, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+			ID:     result.Expense.WalletID,
+			Amount: result.Expense.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// IncomeTxResult is the result of an income transaction
+type IncomeTxResult struct {
+	Income Income `json:"income"`
+	Wallet Wallet `json:"wallet"`
+}
+
+// CreateIncomeTx creates an income and credits its wallet balance
+func (store *SQLStore) CreateIncomeTx(ctx context.Context, arg CreateIncomeParams) (IncomeTxResult, error) {
+	var result IncomeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Income, err = q.CreateIncome(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		result.Wallet, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+			ID:     result.Income.WalletID,
+			Amount: result.Income.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateIncomeTx updates an income and applies the amount difference to its wallet balance
+func (store *SQLStore) UpdateIncomeTx(ctx context.Context, arg UpdateIncomeParams) (IncomeTxResult, error) {
+	var result IncomeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		old, err := q.GetIncomeForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Income, err = q.UpdateIncome(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		result.Wallet, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+			ID:     result.Income.WalletID,
+			Amount: result.Income.Amount - old.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteIncomeTx deletes an income and debits its amount from the wallet balance
+func (store *SQLStore) DeleteIncomeTx(ctx context.Context, id int64) (IncomeTxResult, error) {
+	var result IncomeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Income, err = q.GetIncomeForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteIncome(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		result.Wallet, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+			ID:     result.Income.WalletID,
+			Amount: -result.Income.Amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// TransferTxParams contains the input parameters of the transfer transaction
+type TransferTxParams struct {
+	FromWalletID int64 `json:"from_wallet_id"`
+	ToWalletID   int64 `json:"to_wallet_id"`
+	Amount       int64 `json:"amount"`
+}
+
+// TransferTxResult is the result of the transfer transaction
+type TransferTxResult struct {
+	Transfer   Transfer `json:"transfer"`
+	FromWallet Wallet   `json:"from_wallet"`
+	ToWallet   Wallet   `json:"to_wallet"`
+}
+
+// TransferTx records a transfer and moves the amount from one wallet to the other.
+// Wallet rows are always locked in ascending ID order to avoid deadlocks between
+// concurrent transfers in opposite directions.
+func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+			FromWalletID: arg.FromWalletID,
+			ToWalletID:   arg.ToWalletID,
+			Amount:       arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.FromWalletID < arg.ToWalletID {
+			result.FromWallet, result.ToWallet, err = moveMoney(ctx, q, arg.FromWalletID, -arg.Amount, arg.ToWalletID, arg.Amount)
+		} else {
+			result.ToWallet, result.FromWallet, err = moveMoney(ctx, q, arg.ToWalletID, arg.Amount, arg.FromWalletID, -arg.Amount)
+		}
+		return err
+	})
+
+	return result, err
+}
+
+func moveMoney(
+	ctx context.Context,
+	q *Queries,
+	walletID1 int64,
+	amount1 int64,
+	walletID2 int64,
+	amount2 int64,
+) (wallet1 Wallet, wallet2 Wallet, err error) {
+	wallet1, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+		ID:     walletID1,
+		Amount: amount1,
+	})
+	if err != nil {
+		return
+	}
+
+	wallet2, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+		ID:     walletID2,
+		Amount: amount2,
+	})
+	return
+}
+
+// MaterializeRecurringExpenseTxResult is the result of the recurring expense transaction
+type MaterializeRecurringExpenseTxResult struct {
+	RecurringExpense RecurringExpense `json:"recurring_expense"`
+	Expenses         []Expense        `json:"expenses"`
+}
+
+// MaterializeRecurringExpenseTx locks one recurring expense that is due on or before
+// today and creates an expense for every occurrence up to today, debiting the wallet.
+// Rules locked by another transaction are skipped, so concurrent workers never handle
+// the same rule, and the (recurring_expense_id, occurrence_date) unique index keeps an
+// occurrence from being created twice. It returns sql.ErrNoRows when nothing is due.
+func (store *SQLStore) MaterializeRecurringExpenseTx(ctx context.Context, today time.Time) (MaterializeRecurringExpenseTxResult, error) {
+	var result MaterializeRecurringExpenseTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		rule, err := q.GetDueRecurringExpenseForUpdate(ctx, today)
+		if err != nil {
+			return err
+		}
+
+		result.Expenses = []Expense{}
+		occurrences := rule.Occurrences
+		next := rule.NextOccurrence
+		active := rule.Active
+
+		for active && !next.After(today) {
+			occurrenceDate := next
+			expense, err := q.CreateExpenseOccurrence(ctx, CreateExpenseOccurrenceParams{
+				WalletID:           rule.WalletID,
+				Amount:             rule.Amount,
+				ExpenseDescription: rule.ExpenseDescription,
+				CategoryID:         rule.CategoryID,
+				CreatedAt:          occurrenceDate,
+				RecurringExpenseID: &rule.ID,
+				OccurrenceDate:     &occurrenceDate,
+			})
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				// already materialized
+			case err != nil:
+				return err
+			default:
+				_, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+					ID:     expense.WalletID,
+					Amount: -expense.Amount,
+				})
+				if err != nil {
+					return err
+				}
+				result.Expenses = append(result.Expenses, expense)
+			}
+
+			occurrences++
+			next = util.Occurrence(rule.Frequency, int(rule.Interval), rule.LastDayOfMonth, rule.StartDate, int(occurrences))
+			if rule.MaxOccurrences != nil && occurrences >= *rule.MaxOccurrences {
+				active = false
+			}
+			if rule.EndDate != nil && next.After(*rule.EndDate) {
+				active = false
+			}
+		}
+
+		result.RecurringExpense, err = q.UpdateRecurringExpenseSchedule(ctx, UpdateRecurringExpenseScheduleParams{
+			ID:             rule.ID,
+			Occurrences:    occurrences,
+			NextOccurrence: next,
+			Active:         active,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ImportExpensesTxParams contains the input parameters of the import transaction
+type ImportExpensesTxParams struct {
+	WalletID int64                         `json:"wallet_id"`
+	Expenses []CreateImportedExpenseParams `json:"expenses"`
+}
+
+// ImportExpensesTxResult is the result of the import transaction. Duplicates holds
+// the indexes of the params that matched an existing expense and were skipped.
+type ImportExpensesTxResult struct {
+	Wallet     Wallet    `json:"wallet"`
+	Expenses   []Expense `json:"expenses"`
+	Duplicates []int     `json:"duplicates"`
+}
+
+// ImportExpensesTx creates the expenses of a statement import and debits their total
+// from the wallet. The wallet row is locked first so that concurrent imports into the
+// same wallet see each other's rows. A param carrying an external ID is skipped when
+// the wallet already has an expense with that ID; any other param is skipped when it
+// matches an existing expense of the same day, amount and description.
+func (store *SQLStore) ImportExpensesTx(ctx context.Context, arg ImportExpensesTxParams) (ImportExpensesTxResult, error) {
+	var result ImportExpensesTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Wallet, err = q.GetWalletForUpdate(ctx, arg.WalletID)
+		if err != nil {
+			return err
+		}
+
+		result.Expenses = []Expense{}
+		result.Duplicates = []int{}
+		if len(arg.Expenses) == 0 {
+			return nil
+		}
+
+		from, to := arg.Expenses[0].CreatedAt, arg.Expenses[0].CreatedAt
+		for _, expense := range arg.Expenses {
+			if expense.CreatedAt.Before(from) {
+				from = expense.CreatedAt
+			}
+			if expense.CreatedAt.After(to) {
+				to = expense.CreatedAt
+			}
+		}
+		existing, err := q.ListWalletExpensesBetween(ctx, ListWalletExpensesBetweenParams{
+			WalletID: arg.WalletID,
+			FromTime: util.Day(from),
+			ToTime:   util.Day(to).AddDate(0, 0, 1),
+		})
+		if err != nil {
+			return err
+		}
+
+		duplicates := NewDuplicateIndex(existing)
+		var total int64
+		for i, expense := range arg.Expenses {
+			expense.WalletID = arg.WalletID
+			if expense.ExternalID == nil && duplicates.Take(expense.CreatedAt, expense.Amount, expense.ExpenseDescription) {
+				result.Duplicates = append(result.Duplicates, i)
+				continue
+			}
+
+			created, err := q.CreateImportedExpense(ctx, expense)
+			if errors.Is(err, sql.ErrNoRows) {
+				// the external ID was imported before
+				result.Duplicates = append(result.Duplicates, i)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			result.Expenses = append(result.Expenses, created)
+			total += created.Amount
+		}
+
+		if total == 0 {
+			return nil
+		}
+		result.Wallet, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
+			ID:     arg.WalletID,
+			Amount: -total,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type duplicateKey struct {
+	day         time.Time
+	amount      int64
+	description string
+}
+
+// DuplicateIndex counts expenses by day, amount and description, ignoring case
+// and surrounding spaces in the description
+type DuplicateIndex map[duplicateKey]int
+
+func NewDuplicateIndex(expenses []Expense) DuplicateIndex {
+	index := DuplicateIndex{}
+	for _, expense := range expenses {
+		index[newDuplicateKey(expense.CreatedAt, expense.Amount, expense.ExpenseDescription)]++
+	}
+	return index
+}
+
+// Take reports whether an unmatched expense with the same key is left in the index
+// and, if so, marks it as matched
+func (index DuplicateIndex) Take(createdAt time.Time, amount int64, description string) bool {
+	key := newDuplicateKey(createdAt, amount, description)
+	if index[key] == 0 {
+		return false
+	}
+	index[key]--
+	return true
+}
+
+func newDuplicateKey(createdAt time.Time, amount int64, description string) duplicateKey {
+	return duplicateKey{
+		day:         util.Day(createdAt.UTC()),
+		amount:      amount,
+		description: strings.ToLower(strings.TrimSpace(description)),
+	}
+}
 
-// 	q := New(tx)
-// 	err = fn(q)
-// 	if err != nil {
-// 		if rbErr := tx.Rollback(); rbErr != nil {
-// 			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
-// 		}
-// 		return err
-// 	}
+// ErrSplitTotal is returned when the splits of an expense do not add up to its amount
+var ErrSplitTotal = errors.New("splits must add up to the expense amount")
 
-// 	return tx.Commit()
-// }
+// ExpenseSplitParams is one category allocation of a split expense
+type ExpenseSplitParams struct {
+	CategoryID int64 `json:"category_id"`
+	Amount     int64 `json:"amount"`
+}
+
+// SetExpenseSplitsTxParams contains the input parameters of the split transaction
+type SetExpenseSplitsTxParams struct {
+	ExpenseID int64                `json:"expense_id"`
+	Splits    []ExpenseSplitParams `json:"splits"`
+}
+
+// SetExpenseSplitsTxResult is the result of the split transaction
+type SetExpenseSplitsTxResult struct {
+	Expense Expense        `json:"expense"`
+	Splits  []ExpenseSplit `json:"splits"`
+}
+
+// SetExpenseSplitsTx replaces the splits of an expense. The expense row is locked so
+// its amount cannot change while the splits are checked against it; an empty list
+// charges the whole expense to its own category again.
+func (store *SQLStore) SetExpenseSplitsTx(ctx context.Context, arg SetExpenseSplitsTxParams) (SetExpenseSplitsTxResult, error) {
+	result := SetExpenseSplitsTxResult{Splits: []ExpenseSplit{}}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Expense, err = q.GetExpenseForUpdate(ctx, arg.ExpenseID)
+		if err != nil {
+			return err
+		}
+
+		if len(arg.Splits) > 0 {
+			var total int64
+			for _, split := range arg.Splits {
+				total += split.Amount
+			}
+			if total != result.Expense.Amount {
+				return fmt.Errorf("%w: %d != %d", ErrSplitTotal, total, result.Expense.Amount)
+			}
+		}
+
+		if err := q.DeleteExpenseSplits(ctx, arg.ExpenseID); err != nil {
+			return err
+		}
+		for _, split := range arg.Splits {
+			created, err := q.CreateExpenseSplit(ctx, CreateExpenseSplitParams{
+				ExpenseID:  arg.ExpenseID,
+				CategoryID: split.CategoryID,
+				Amount:     split.Amount,
+			})
+			if err != nil {
+				return err
+			}
+			result.Splits = append(result.Splits, created)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// OwnerData is everything stored for a user apart from the profile
+type OwnerData struct {
+	Wallets       []Wallet       `json:"wallets"`
+	Categories    []Category     `json:"categories"`
+	Expenses      []Expense      `json:"expenses"`
+	ExpenseSplits []ExpenseSplit `json:"expense_splits"`
+	Tags          []Tag          `json:"tags"`
+	ExpenseTags   []ExpenseTag   `json:"expense_tags"`
+	Incomes       []Income       `json:"incomes"`
+	Budgets       []Budget       `json:"budgets"`
+	Transfers     []Transfer     `json:"transfers"`
+}
+
+// BackupTx reads all data of the owner from a single read-only snapshot
+func (store *SQLStore) BackupTx(ctx context.Context, owner string) (OwnerData, error) {
+	var data OwnerData
+
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := store.execTxOptions(ctx, opts, func(q *Queries) error {
+		var err error
+
+		if data.Wallets, err = q.ListOwnerWallets(ctx, owner); err != nil {
+			return err
+		}
+		if data.Categories, err = q.ListOwnerCategories(ctx, owner); err != nil {
+			return err
+		}
+		if data.Expenses, err = q.ListOwnerExpenses(ctx, owner); err != nil {
+			return err
+		}
+		if data.ExpenseSplits, err = q.ListOwnerExpenseSplits(ctx, owner); err != nil {
+			return err
+		}
+		if data.Tags, err = q.ListOwnerTags(ctx, owner); err != nil {
+			return err
+		}
+		if data.ExpenseTags, err = q.ListOwnerExpenseTags(ctx, owner); err != nil {
+			return err
+		}
+		if data.Incomes, err = q.ListOwnerIncomes(ctx, owner); err != nil {
+			return err
+		}
+		if data.Budgets, err = q.ListOwnerBudgets(ctx, owner); err != nil {
+			return err
+		}
+		data.Transfers, err = q.ListOwnerTransfers(ctx, owner)
+		return err
+	})
+
+	return data, err
+}
+
+// RestoreTxParams contains the input parameters of the restore transaction
+type RestoreTxParams struct {
+	Owner string    `json:"owner"`
+	Data  OwnerData `json:"data"`
+}
+
+// RestoreTxResult is the result of the restore transaction. WalletIDs, CategoryIDs
+// and TagIDs map the IDs in the restored data to the newly assigned ones.
+type RestoreTxResult struct {
+	WalletIDs     map[int64]int64 `json:"wallet_ids"`
+	CategoryIDs   map[int64]int64 `json:"category_ids"`
+	TagIDs        map[int64]int64 `json:"tag_ids"`
+	Expenses      int             `json:"expenses"`
+	ExpenseSplits int             `json:"expense_splits"`
+	ExpenseTags   int             `json:"expense_tags"`
+	Incomes       int             `json:"incomes"`
+	Budgets       int             `json:"budgets"`
+	Transfers     int             `json:"transfers"`
+}
+
+// RestoreTx recreates the data under the owner with new IDs. Wallets keep their
+// stored balances, categories and tags whose name the owner already uses are
+// merged into the existing ones, and expenses lose their link to the recurring rule that
+// created them. Nothing is written when any row fails.
+func (store *SQLStore) RestoreTx(ctx context.Context, arg RestoreTxParams) (RestoreTxResult, error) {
+	result := RestoreTxResult{
+		WalletIDs:   make(map[int64]int64, len(arg.Data.Wallets)),
+		CategoryIDs: make(map[int64]int64, len(arg.Data.Categories)),
+		TagIDs:      make(map[int64]int64, len(arg.Data.Tags)),
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for _, wallet := range arg.Data.Wallets {
+			restored, err := q.RestoreWallet(ctx, RestoreWalletParams{
+				Owner:     arg.Owner,
+				Name:      wallet.Name,
+				Currency:  wallet.Currency,
+				Balance:   wallet.Balance,
+				CreatedAt: wallet.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			result.WalletIDs[wallet.ID] = restored.ID
+		}
+
+		for _, category := range arg.Data.Categories {
+			restored, err := q.RestoreCategory(ctx, RestoreCategoryParams{
+				Owner:     arg.Owner,
+				Name:      category.Name,
+				CreatedAt: category.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			result.CategoryIDs[category.ID] = restored.ID
+		}
+
+		ids := restoredIDs{wallets: result.WalletIDs, categories: result.CategoryIDs}
+		expenseIDs := make(map[int64]int64, len(arg.Data.Expenses))
+		for _, expense := range arg.Data.Expenses {
+			walletID, categoryID, err := ids.lookup("expense", expense.ID, expense.WalletID, expense.CategoryID)
+			if err != nil {
+				return err
+			}
+			restored, err := q.RestoreExpense(ctx, RestoreExpenseParams{
+				WalletID:           walletID,
+				Amount:             expense.Amount,
+				ExpenseDescription: expense.ExpenseDescription,
+				CategoryID:         categoryID,
+				CreatedAt:          expense.CreatedAt,
+				ExternalID:         expense.ExternalID,
+			})
+			if err != nil {
+				return err
+			}
+			expenseIDs[expense.ID] = restored.ID
+			result.Expenses++
+		}
+
+		for _, split := range arg.Data.ExpenseSplits {
+			expenseID, ok := expenseIDs[split.ExpenseID]
+			if !ok {
+				return fmt.Errorf("expense split %d references unknown expense %d", split.ID, split.ExpenseID)
+			}
+			categoryID, ok := ids.categories[split.CategoryID]
+			if !ok {
+				return fmt.Errorf("expense split %d references unknown category %d", split.ID, split.CategoryID)
+			}
+			_, err := q.RestoreExpenseSplit(ctx, RestoreExpenseSplitParams{
+				ExpenseID:  expenseID,
+				CategoryID: categoryID,
+				Amount:     split.Amount,
+				CreatedAt:  split.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			result.ExpenseSplits++
+		}
+
+		for _, tag := range arg.Data.Tags {
+			restored, err := q.RestoreTag(ctx, RestoreTagParams{
+				Owner:     arg.Owner,
+				Name:      tag.Name,
+				CreatedAt: tag.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			result.TagIDs[tag.ID] = restored.ID
+		}
+		for _, expenseTag := range arg.Data.ExpenseTags {
+			expenseID, ok := expenseIDs[expenseTag.ExpenseID]
+			if !ok {
+				return fmt.Errorf("expense tag references unknown expense %d", expenseTag.ExpenseID)
+			}
+			tagID, ok := result.TagIDs[expenseTag.TagID]
+			if !ok {
+				return fmt.Errorf("expense tag references unknown tag %d", expenseTag.TagID)
+			}
+			err := q.RestoreExpenseTag(ctx, RestoreExpenseTagParams{
+				ExpenseID: expenseID,
+				TagID:     tagID,
+				CreatedAt: expenseTag.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			result.ExpenseTags++
+		}
+
+		for _, income := range arg.Data.Incomes {
+			walletID, categoryID, err := ids.lookup("income", income.ID, income.WalletID, income.CategoryID)
+			if err != nil {
+				return err
+			}
+			_, err = q.RestoreIncome(ctx, RestoreIncomeParams{
+				WalletID:          walletID,
+				Amount:            income.Amount,
+				IncomeDescription: income.IncomeDescription,
+				CategoryID:        categoryID,
+				CreatedAt:         income.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			result.Incomes++
+		}
+
+		for _, budget := range arg.Data.Budgets {
+			walletID, categoryID, err := ids.lookup("budget", budget.ID, budget.WalletID, budget.CategoryID)
+			if err != nil {
+				return err
+			}
+			_, err = q.RestoreBudget(ctx, RestoreBudgetParams{
+				WalletID:   walletID,
+				Amount:     budget.Amount,
+				CategoryID: categoryID,
+				Period:     budget.Period,
+				StartDate:  budget.StartDate,
+				EndDate:    budget.EndDate,
+				Recurring:  budget.Recurring,
+				CreatedAt:  budget.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			result.Budgets++
+		}
+
+		for _, transfer := range arg.Data.Transfers {
+			fromWalletID, ok := result.WalletIDs[transfer.FromWalletID]
+			if !ok {
+				return fmt.Errorf("transfer %d references unknown wallet %d", transfer.ID, transfer.FromWalletID)
+			}
+			toWalletID, ok := result.WalletIDs[transfer.ToWalletID]
+			if !ok {
+				return fmt.Errorf("transfer %d references unknown wallet %d", transfer.ID, transfer.ToWalletID)
+			}
+			_, err := q.RestoreTransfer(ctx, RestoreTransferParams{
+				FromWalletID: fromWalletID,
+				ToWalletID:   toWalletID,
+				Amount:       transfer.Amount,
+				CreatedAt:    transfer.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			result.Transfers++
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+type restoredIDs struct {
+	wallets    map[int64]int64
+	categories map[int64]int64
+}
+
+// lookup maps the wallet and category of a restored row to their new IDs
+func (ids restoredIDs) lookup(kind string, id, walletID, categoryID int64) (int64, int64, error) {
+	newWalletID, ok := ids.wallets[walletID]
+	if !ok {
+		return 0, 0, fmt.Errorf("%s %d references unknown wallet %d", kind, id, walletID)
+	}
+	newCategoryID, ok := ids.categories[categoryID]
+	if !ok {
+		return 0, 0, fmt.Errorf("%s %d references unknown category %d", kind, id, categoryID)
+	}
+	return newWalletID, newCategoryID, nil
+}
+
+// LogoutTxParams contains the input parameters of the logout transaction
+type LogoutTxParams struct {
+	SessionID uuid.UUID           `json:"session_id"`
+	Tokens    []RevokeTokenParams `json:"tokens"`
+}
+
+// LogoutTx blocks a session and revokes the tokens still in use with it
+func (store *SQLStore) LogoutTx(ctx context.Context, arg LogoutTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.BlockSession(ctx, arg.SessionID); err != nil {
+			return err
+		}
+		for _, token := range arg.Tokens {
+			if err := q.RevokeToken(ctx, token); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LogoutAllTx blocks every session of the user and rejects all tokens issued so far.
+// It returns the time from which tokens are accepted again.
+func (store *SQLStore) LogoutAllTx(ctx context.Context, username string) (time.Time, error) {
+	var revokedAt time.Time
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		revokedAt, err = q.RevokeUserTokens(ctx, RevokeUserTokensParams{
+			Username:  username,
+			RevokedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.BlockUserSessions(ctx, username)
+		return err
+	})
+
+	return revokedAt, err
+}
@@ -0,0 +1,270 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func TestExpenseTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+
+	n := 5
+	amount := int64(10)
+
+	errs := make(chan error)
+	results := make(chan ExpenseTxResult)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			result, err := testStore.CreateExpenseTx(context.Background(), CreateExpenseParams{
+				WalletID:           wallet.ID,
+				Amount:             amount,
+				ExpenseDescription: util.RandomString(12),
+				CategoryID:         category.ID,
+			})
+			errs <- err
+			results <- result
+		}()
+	}
+
+	var expenses []Expense
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+
+		result := <-results
+		require.NotEmpty(t, result)
+		require.Equal(t, wallet.ID, result.Expense.WalletID)
+		require.Equal(t, amount, result.Expense.Amount)
+		require.Equal(t, wallet.ID, result.Wallet.ID)
+		expenses = append(expenses, result.Expense)
+	}
+
+	updatedWallet, err := testQueries.GetWallet(context.Background(), wallet.ID)
+	require.NoError(t, err)
+	require.Equal(t, wallet.Balance-int64(n)*amount, updatedWallet.Balance)
+
+	result, err := testStore.UpdateExpenseTx(context.Background(), UpdateExpenseParams{
+		ID:     expenses[0].ID,
+		Amount: sql.NullInt64{Int64: amount * 3, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, amount*3, result.Expense.Amount)
+	require.Equal(t, updatedWallet.Balance-amount*2, result.Wallet.Balance)
+
+	deleted, err := testStore.DeleteExpenseTx(context.Background(), expenses[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, updatedWallet.Balance+amount, deleted.Wallet.Balance)
+	require.Empty(t, deleted.BlobKeys)
+
+	_, err = testQueries.GetExpense(context.Background(), expenses[0].ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testStore.DeleteExpenseTx(context.Background(), expenses[0].ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestIncomeTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	amount := int64(10)
+
+	created, err := testStore.CreateIncomeTx(context.Background(), CreateIncomeParams{
+		WalletID:          wallet.ID,
+		Amount:            amount,
+		IncomeDescription: util.RandomString(12),
+		CategoryID:        category.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, wallet.Balance+amount, created.Wallet.Balance)
+
+	updated, err := testStore.UpdateIncomeTx(context.Background(), UpdateIncomeParams{
+		ID:     created.Income.ID,
+		Amount: sql.NullInt64{Int64: amount * 2, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, created.Income.IncomeDescription, updated.Income.IncomeDescription)
+	require.Equal(t, wallet.Balance+amount*2, updated.Wallet.Balance)
+
+	deleted, err := testStore.DeleteIncomeTx(context.Background(), created.Income.ID)
+	require.NoError(t, err)
+	require.Equal(t, wallet.Balance, deleted.Wallet.Balance)
+}
+
+func TestTransferTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet1 := CreateRandomWallet(t, user)
+	wallet2 := CreateRandomWallet(t, user)
+
+	n := 5
+	amount := int64(10)
+
+	errs := make(chan error)
+	results := make(chan TransferTxResult)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+				FromWalletID: wallet1.ID,
+				ToWalletID:   wallet2.ID,
+				Amount:       amount,
+			})
+			errs <- err
+			results <- result
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+
+		result := <-results
+		require.NotEmpty(t, result)
+
+		transfer := result.Transfer
+		require.Equal(t, wallet1.ID, transfer.FromWalletID)
+		require.Equal(t, wallet2.ID, transfer.ToWalletID)
+		require.Equal(t, amount, transfer.Amount)
+		require.NotZero(t, transfer.ID)
+
+		_, err = testQueries.GetTransfer(context.Background(), transfer.ID)
+		require.NoError(t, err)
+
+		require.Equal(t, wallet1.ID, result.FromWallet.ID)
+		require.Equal(t, wallet2.ID, result.ToWallet.ID)
+
+		diff1 := wallet1.Balance - result.FromWallet.Balance
+		diff2 := result.ToWallet.Balance - wallet2.Balance
+		require.Equal(t, diff1, diff2)
+		require.True(t, diff1 > 0)
+		require.True(t, diff1%amount == 0)
+	}
+
+	updatedWallet1, err := testQueries.GetWallet(context.Background(), wallet1.ID)
+	require.NoError(t, err)
+
+	updatedWallet2, err := testQueries.GetWallet(context.Background(), wallet2.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, wallet1.Balance-int64(n)*amount, updatedWallet1.Balance)
+	require.Equal(t, wallet2.Balance+int64(n)*amount, updatedWallet2.Balance)
+}
+
+func TestTransferTxDeadlock(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet1 := CreateRandomWallet(t, user)
+	wallet2 := CreateRandomWallet(t, user)
+
+	n := 10
+	amount := int64(10)
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		fromWalletID := wallet1.ID
+		toWalletID := wallet2.ID
+
+		if i%2 == 1 {
+			fromWalletID = wallet2.ID
+			toWalletID = wallet1.ID
+		}
+
+		go func() {
+			_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+				FromWalletID: fromWalletID,
+				ToWalletID:   toWalletID,
+				Amount:       amount,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+	}
+
+	updatedWallet1, err := testQueries.GetWallet(context.Background(), wallet1.ID)
+	require.NoError(t, err)
+
+	updatedWallet2, err := testQueries.GetWallet(context.Background(), wallet2.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, wallet1.Balance, updatedWallet1.Balance)
+	require.Equal(t, wallet2.Balance, updatedWallet2.Balance)
+}
+
+func TestImportExpensesTx(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	arg := ImportExpensesTxParams{
+		WalletID: wallet.ID,
+		Expenses: []CreateImportedExpenseParams{
+			{Amount: 500, ExpenseDescription: "Coffee", CategoryID: category.ID, CreatedAt: day},
+			{Amount: 500, ExpenseDescription: "Coffee", CategoryID: category.ID, CreatedAt: day},
+			{Amount: 1250, ExpenseDescription: "Taxi", CategoryID: category.ID, CreatedAt: day.AddDate(0, 0, 1)},
+		},
+	}
+
+	result, err := testStore.ImportExpensesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Expenses, 3)
+	require.Empty(t, result.Duplicates)
+	require.Equal(t, wallet.Balance-2250, result.Wallet.Balance)
+	for i, expense := range result.Expenses {
+		require.Equal(t, wallet.ID, expense.WalletID)
+		require.WithinDuration(t, arg.Expenses[i].CreatedAt, expense.CreatedAt, time.Second)
+	}
+
+	// importing an overlapping statement only adds the rows not seen before
+	arg.Expenses = append(arg.Expenses, CreateImportedExpenseParams{
+		Amount: 500, ExpenseDescription: " coffee", CategoryID: category.ID, CreatedAt: day.Add(time.Hour),
+	})
+	result, err = testStore.ImportExpensesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Expenses, 1)
+	require.Equal(t, []int{0, 1, 2}, result.Duplicates)
+	require.Equal(t, wallet.Balance-2750, result.Wallet.Balance)
+}
+
+func TestImportExpensesTxExternalID(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	fitID := util.RandomString(10)
+	arg := ImportExpensesTxParams{
+		WalletID: wallet.ID,
+		Expenses: []CreateImportedExpenseParams{
+			{Amount: 500, ExpenseDescription: "Coffee", CategoryID: category.ID, CreatedAt: day, ExternalID: &fitID},
+		},
+	}
+
+	result, err := testStore.ImportExpensesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Expenses, 1)
+	require.Equal(t, &fitID, result.Expenses[0].ExternalID)
+
+	// the same transaction id is skipped while an identical row with a new id is not
+	otherID := util.RandomString(10)
+	arg.Expenses = append(arg.Expenses, CreateImportedExpenseParams{
+		Amount: 500, ExpenseDescription: "Coffee", CategoryID: category.ID, CreatedAt: day, ExternalID: &otherID,
+	})
+	result, err = testStore.ImportExpensesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, []int{0}, result.Duplicates)
+	require.Len(t, result.Expenses, 1)
+	require.Equal(t, &otherID, result.Expenses[0].ExternalID)
+	require.Equal(t, wallet.Balance-1000, result.Wallet.Balance)
+}
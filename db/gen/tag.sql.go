@@ -0,0 +1,195 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: tag.sql
+
+package db
+
+import (
+	"context"
+)
+
+const addExpenseTag = `-- name: AddExpenseTag :exec
+INSERT INTO expense_tags (
+  expense_id,
+  tag_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT DO NOTHING
+`
+
+type AddExpenseTagParams struct {
+	ExpenseID int64 `json:"expense_id"`
+	TagID     int64 `json:"tag_id"`
+}
+
+func (q *Queries) AddExpenseTag(ctx context.Context, arg AddExpenseTagParams) error {
+	_, err := q.exec(ctx, q.addExpenseTagStmt, addExpenseTag, arg.ExpenseID, arg.TagID)
+	return err
+}
+
+const createTag = `-- name: CreateTag :one
+INSERT INTO tags (
+  owner,
+  name
+) VALUES (
+  $1, $2
+)
+RETURNING id, owner, name, created_at
+`
+
+type CreateTagParams struct {
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+}
+
+func (q *Queries) CreateTag(ctx context.Context, arg CreateTagParams) (Tag, error) {
+	row := q.queryRow(ctx, q.createTagStmt, createTag, arg.Owner, arg.Name)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteTag = `-- name: DeleteTag :exec
+DELETE FROM tags
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTag(ctx context.Context, id int64) error {
+	_, err := q.exec(ctx, q.deleteTagStmt, deleteTag, id)
+	return err
+}
+
+const getTag = `-- name: GetTag :one
+SELECT id, owner, name, created_at FROM tags
+WHERE id = $1
+`
+
+func (q *Queries) GetTag(ctx context.Context, id int64) (Tag, error) {
+	row := q.queryRow(ctx, q.getTagStmt, getTag, id)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listExpenseTags = `-- name: ListExpenseTags :many
+SELECT t.id, t.owner, t.name, t.created_at FROM tags t
+JOIN expense_tags et ON et.tag_id = t.id
+WHERE et.expense_id = $1
+ORDER BY t.name
+`
+
+func (q *Queries) ListExpenseTags(ctx context.Context, expenseID int64) ([]Tag, error) {
+	rows, err := q.query(ctx, q.listExpenseTagsStmt, listExpenseTags, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Name,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTags = `-- name: ListTags :many
+SELECT id, owner, name, created_at FROM tags
+WHERE owner = $1
+ORDER BY name
+`
+
+func (q *Queries) ListTags(ctx context.Context, owner string) ([]Tag, error) {
+	rows, err := q.query(ctx, q.listTagsStmt, listTags, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tag{}
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Name,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeExpenseTag = `-- name: RemoveExpenseTag :execrows
+DELETE FROM expense_tags
+WHERE expense_id = $1 AND tag_id = $2
+`
+
+type RemoveExpenseTagParams struct {
+	ExpenseID int64 `json:"expense_id"`
+	TagID     int64 `json:"tag_id"`
+}
+
+func (q *Queries) RemoveExpenseTag(ctx context.Context, arg RemoveExpenseTagParams) (int64, error) {
+	result, err := q.exec(ctx, q.removeExpenseTagStmt, removeExpenseTag, arg.ExpenseID, arg.TagID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateTag = `-- name: UpdateTag :one
+UPDATE tags
+SET name = $1
+WHERE id = $2
+RETURNING id, owner, name, created_at
+`
+
+type UpdateTagParams struct {
+	Name string `json:"name"`
+	ID   int64  `json:"id"`
+}
+
+func (q *Queries) UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, error) {
+	row := q.queryRow(ctx, q.updateTagStmt, updateTag, arg.Name, arg.ID)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func CreateRandomTag(t *testing.T, user User) Tag {
+	arg := CreateTagParams{
+		Owner: user.Username,
+		Name:  util.RandomString(8),
+	}
+	tag, err := testQueries.CreateTag(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Owner, tag.Owner)
+	require.Equal(t, arg.Name, tag.Name)
+	require.NotZero(t, tag.ID)
+	require.NotZero(t, tag.CreatedAt)
+	return tag
+}
+
+func TestTags(t *testing.T) {
+	user := CreateRandomUser(t)
+	tag := CreateRandomTag(t, user)
+
+	_, err := testQueries.CreateTag(context.Background(), CreateTagParams{Owner: user.Username, Name: tag.Name})
+	require.Error(t, err)
+
+	renamed, err := testQueries.UpdateTag(context.Background(), UpdateTagParams{ID: tag.ID, Name: util.RandomString(8)})
+	require.NoError(t, err)
+	require.Equal(t, tag.ID, renamed.ID)
+	require.NotEqual(t, tag.Name, renamed.Name)
+
+	tags, err := testQueries.ListTags(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, []Tag{renamed}, tags)
+
+	err = testQueries.DeleteTag(context.Background(), tag.ID)
+	require.NoError(t, err)
+	_, err = testQueries.GetTag(context.Background(), tag.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestExpenseTags(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet := CreateRandomWallet(t, user)
+	category := CreateRandomCategory(t, user)
+	tag1 := CreateRandomTag(t, user)
+	tag2 := CreateRandomTag(t, user)
+	expense1 := CreateRandomExpense(t, wallet, category)
+	expense2 := CreateRandomExpense(t, wallet, category)
+
+	for i := 0; i < 2; i++ {
+		err := testQueries.AddExpenseTag(context.Background(), AddExpenseTagParams{ExpenseID: expense1.ID, TagID: tag1.ID})
+		require.NoError(t, err)
+	}
+	err := testQueries.AddExpenseTag(context.Background(), AddExpenseTagParams{ExpenseID: expense2.ID, TagID: tag2.ID})
+	require.NoError(t, err)
+
+	tags, err := testQueries.ListExpenseTags(context.Background(), expense1.ID)
+	require.NoError(t, err)
+	require.Equal(t, []Tag{tag1}, tags)
+
+	expenses, err := testQueries.ListExpensesByDateDesc(context.Background(), ListExpensesByDateDescParams{
+		WalletID: wallet.ID,
+		TagIds:   []int64{tag1.ID},
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, expenses, 1)
+	require.Equal(t, expense1.ID, expenses[0].ID)
+
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	rows, err := testQueries.GetMonthlyCategoryReport(context.Background(), GetMonthlyCategoryReportParams{
+		Owner:     user.Username,
+		WalletIds: []int64{},
+		TagIds:    []int64{tag2.ID},
+		FromTime:  month,
+		ToTime:    month.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, expense2.Amount, rows[0].Total)
+
+	CreateRandomIncome(t, wallet, category)
+	series, err := testQueries.GetTimeSeries(context.Background(), GetTimeSeriesParams{
+		Bucket:    "month",
+		Owner:     user.Username,
+		WalletIds: []int64{},
+		TagIds:    []int64{tag1.ID, tag2.ID},
+		FromTime:  month,
+		ToTime:    month.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	require.Equal(t, expense1.Amount+expense2.Amount, series[0].Expenses)
+	require.Zero(t, series[0].Incomes)
+
+	removed, err := testQueries.RemoveExpenseTag(context.Background(), RemoveExpenseTagParams{ExpenseID: expense1.ID, TagID: tag1.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removed)
+
+	// deleting a tag detaches it from its expenses
+	err = testQueries.DeleteTag(context.Background(), tag2.ID)
+	require.NoError(t, err)
+	tags, err = testQueries.ListExpenseTags(context.Background(), expense2.ID)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
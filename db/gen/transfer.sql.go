@@ -0,0 +1,110 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: transfer.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createTransfer = `-- name: CreateTransfer :one
+INSERT INTO transfers (
+    from_wallet_id,
+    to_wallet_id,
+    amount
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, from_wallet_id, to_wallet_id, amount, created_at
+`
+
+type CreateTransferParams struct {
+	FromWalletID int64 `json:"from_wallet_id"`
+	ToWalletID   int64 `json:"to_wallet_id"`
+	Amount       int64 `json:"amount"`
+}
+
+func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	row := q.queryRow(ctx, q.createTransferStmt, createTransfer, arg.FromWalletID, arg.ToWalletID, arg.Amount)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromWalletID,
+		&i.ToWalletID,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransfer = `-- name: GetTransfer :one
+SELECT id, from_wallet_id, to_wallet_id, amount, created_at FROM transfers
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
+	row := q.queryRow(ctx, q.getTransferStmt, getTransfer, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromWalletID,
+		&i.ToWalletID,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listTransfers = `-- name: ListTransfers :many
+SELECT id, from_wallet_id, to_wallet_id, amount, created_at FROM transfers
+WHERE (from_wallet_id = $1 OR to_wallet_id = $1)
+  AND ($2::bigint IS NULL
+    OR (created_at, id) > ($3::timestamptz, $2))
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type ListTransfersParams struct {
+	WalletID        int64         `json:"wallet_id"`
+	CursorID        sql.NullInt64 `json:"cursor_id"`
+	CursorCreatedAt time.Time     `json:"cursor_created_at"`
+	Limit           int32         `json:"limit"`
+}
+
+func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
+	rows, err := q.query(ctx, q.listTransfersStmt, listTransfers,
+		arg.WalletID,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromWalletID,
+			&i.ToWalletID,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
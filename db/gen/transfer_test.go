@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func CreateRandomTransfer(t *testing.T, wallet1, wallet2 Wallet) Transfer {
+	arg := CreateTransferParams{
+		FromWalletID: wallet1.ID,
+		ToWalletID:   wallet2.ID,
+		Amount:       util.RandomAmount(),
+	}
+
+	transfer, err := testQueries.CreateTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, transfer)
+
+	require.Equal(t, arg.FromWalletID, transfer.FromWalletID)
+	require.Equal(t, arg.ToWalletID, transfer.ToWalletID)
+	require.Equal(t, arg.Amount, transfer.Amount)
+	require.NotZero(t, transfer.ID)
+	require.NotZero(t, transfer.CreatedAt)
+
+	return transfer
+}
+
+func TestCreateTransfer(t *testing.T) {
+	user := CreateRandomUser(t)
+	CreateRandomTransfer(t, CreateRandomWallet(t, user), CreateRandomWallet(t, user))
+}
+
+func TestGetTransfer(t *testing.T) {
+	user := CreateRandomUser(t)
+	transfer1 := CreateRandomTransfer(t, CreateRandomWallet(t, user), CreateRandomWallet(t, user))
+
+	transfer2, err := testQueries.GetTransfer(context.Background(), transfer1.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, transfer2)
+
+	require.Equal(t, transfer1.ID, transfer2.ID)
+	require.Equal(t, transfer1.FromWalletID, transfer2.FromWalletID)
+	require.Equal(t, transfer1.ToWalletID, transfer2.ToWalletID)
+	require.Equal(t, transfer1.Amount, transfer2.Amount)
+	require.WithinDuration(t, transfer1.CreatedAt, transfer2.CreatedAt, time.Second)
+}
+
+func TestListTransfers(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet1 := CreateRandomWallet(t, user)
+	wallet2 := CreateRandomWallet(t, user)
+
+	for i := 0; i < 5; i++ {
+		CreateRandomTransfer(t, wallet1, wallet2)
+		CreateRandomTransfer(t, wallet2, wallet1)
+	}
+
+	arg := ListTransfersParams{
+		WalletID: wallet1.ID,
+		Limit:    5,
+	}
+
+	transfers, err := testQueries.ListTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, transfers, 5)
+
+	last := transfers[len(transfers)-1]
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+	arg.CursorCreatedAt = last.CreatedAt
+	transfers, err = testQueries.ListTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, transfers, 5)
+
+	for _, transfer := range transfers {
+		require.NotEmpty(t, transfer)
+		require.True(t, transfer.FromWalletID == wallet1.ID || transfer.ToWalletID == wallet1.ID)
+	}
+}
@@ -8,6 +8,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 const createUser = `-- name: CreateUser :one
@@ -18,7 +19,7 @@ INSERT INTO users (
     hashed_password
 ) VALUES(
     $1, $2, $3, $4
-) RETURNING username, full_name, email, hashed_password, password_changed_at, created_at
+) RETURNING username, full_name, email, hashed_password, password_changed_at, created_at, tokens_revoked_at, role, disabled_at
 `
 
 type CreateUserParams struct {
@@ -43,12 +44,61 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.TokensRevokedAt,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const getSystemStats = `-- name: GetSystemStats :one
+SELECT
+    (SELECT count(*) FROM users)::bigint AS users,
+    (SELECT count(*) FROM users WHERE disabled_at IS NOT NULL)::bigint AS disabled_users,
+    (SELECT count(*) FROM users WHERE role = 'admin')::bigint AS admins,
+    (SELECT count(DISTINCT username) FROM sessions
+        WHERE NOT is_blocked AND expires_at > now())::bigint AS users_with_sessions,
+    (SELECT count(*) FROM wallets)::bigint AS wallets,
+    (SELECT count(*) FROM expenses)::bigint AS expenses,
+    (SELECT count(*) FROM incomes)::bigint AS incomes,
+    (SELECT count(*) FROM transfers)::bigint AS transfers,
+    (SELECT count(*) FROM attachments)::bigint AS attachments,
+    (SELECT COALESCE(sum(size), 0) FROM attachments)::bigint AS attachment_bytes
+`
+
+type GetSystemStatsRow struct {
+	Users             int64 `json:"users"`
+	DisabledUsers     int64 `json:"disabled_users"`
+	Admins            int64 `json:"admins"`
+	UsersWithSessions int64 `json:"users_with_sessions"`
+	Wallets           int64 `json:"wallets"`
+	Expenses          int64 `json:"expenses"`
+	Incomes           int64 `json:"incomes"`
+	Transfers         int64 `json:"transfers"`
+	Attachments       int64 `json:"attachments"`
+	AttachmentBytes   int64 `json:"attachment_bytes"`
+}
+
+func (q *Queries) GetSystemStats(ctx context.Context) (GetSystemStatsRow, error) {
+	row := q.queryRow(ctx, q.getSystemStatsStmt, getSystemStats)
+	var i GetSystemStatsRow
+	err := row.Scan(
+		&i.Users,
+		&i.DisabledUsers,
+		&i.Admins,
+		&i.UsersWithSessions,
+		&i.Wallets,
+		&i.Expenses,
+		&i.Incomes,
+		&i.Transfers,
+		&i.Attachments,
+		&i.AttachmentBytes,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, full_name, email, hashed_password, password_changed_at, created_at FROM users
+SELECT username, full_name, email, hashed_password, password_changed_at, created_at, tokens_revoked_at, role, disabled_at FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -62,6 +112,122 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.TokensRevokedAt,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT username, full_name, email, hashed_password, password_changed_at, created_at, tokens_revoked_at, role, disabled_at FROM users
+WHERE $1::varchar IS NULL
+  OR (created_at, username) > ($2::timestamptz, $1)
+ORDER BY created_at, username
+LIMIT $3
+`
+
+type ListUsersParams struct {
+	CursorUsername  sql.NullString `json:"cursor_username"`
+	CursorCreatedAt time.Time      `json:"cursor_created_at"`
+	Limit           int32          `json:"limit"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.query(ctx, q.listUsersStmt, listUsers, arg.CursorUsername, arg.CursorCreatedAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Username,
+			&i.FullName,
+			&i.Email,
+			&i.HashedPassword,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.TokensRevokedAt,
+			&i.Role,
+			&i.DisabledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserDisabled = `-- name: SetUserDisabled :one
+UPDATE users
+SET
+    disabled_at = CASE WHEN $1::boolean THEN COALESCE(disabled_at, $2::timestamptz) END,
+    tokens_revoked_at = CASE WHEN $1::boolean THEN $2::timestamptz ELSE tokens_revoked_at END
+WHERE username = $3
+RETURNING username, full_name, email, hashed_password, password_changed_at, created_at, tokens_revoked_at, role, disabled_at
+`
+
+type SetUserDisabledParams struct {
+	Disabled  bool      `json:"disabled"`
+	RevokedAt time.Time `json:"revoked_at"`
+	Username  string    `json:"username"`
+}
+
+// Disabling also rejects every token issued before revoked_at
+func (q *Queries) SetUserDisabled(ctx context.Context, arg SetUserDisabledParams) (User, error) {
+	row := q.queryRow(ctx, q.setUserDisabledStmt, setUserDisabled, arg.Disabled, arg.RevokedAt, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.FullName,
+		&i.Email,
+		&i.HashedPassword,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.TokensRevokedAt,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const setUserRole = `-- name: SetUserRole :one
+UPDATE users
+SET
+    role = $1,
+    tokens_revoked_at = CASE WHEN role <> $1 THEN $2::timestamptz ELSE tokens_revoked_at END
+WHERE username = $3
+RETURNING username, full_name, email, hashed_password, password_changed_at, created_at, tokens_revoked_at, role, disabled_at
+`
+
+type SetUserRoleParams struct {
+	Role      string    `json:"role"`
+	RevokedAt time.Time `json:"revoked_at"`
+	Username  string    `json:"username"`
+}
+
+// Tokens carry the role, so changing it rejects every token issued before revoked_at
+func (q *Queries) SetUserRole(ctx context.Context, arg SetUserRoleParams) (User, error) {
+	row := q.queryRow(ctx, q.setUserRoleStmt, setUserRole, arg.Role, arg.RevokedAt, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.FullName,
+		&i.Email,
+		&i.HashedPassword,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.TokensRevokedAt,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
@@ -75,7 +241,7 @@ SET
     password_changed_at = COALESCE($4, password_changed_at)
 WHERE
     username = $5
-RETURNING username, full_name, email, hashed_password, password_changed_at, created_at
+RETURNING username, full_name, email, hashed_password, password_changed_at, created_at, tokens_revoked_at, role, disabled_at
 `
 
 type UpdateUserParams struct {
@@ -102,6 +268,9 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.TokensRevokedAt,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
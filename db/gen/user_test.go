@@ -31,6 +31,8 @@ func CreateRandomUser(t *testing.T) User {
 	require.Equal(t, arg.HashedPassword, user.HashedPassword)
 	require.NotZero(t, user.CreatedAt)
 	require.True(t, user.PasswordChangedAt.IsZero())
+	require.Equal(t, util.UserRole, user.Role)
+	require.Nil(t, user.DisabledAt)
 
 	return user
 
@@ -141,3 +143,70 @@ func TestUpdateUserAllFields(t *testing.T) {
 	require.NotEqual(t, oldUser.HashedPassword, updatedUser.HashedPassword)
 	require.Equal(t, newHashedPassword, updatedUser.HashedPassword)
 }
+
+func TestSetUserRole(t *testing.T) {
+	user := CreateRandomUser(t)
+
+	revokedAt := time.Now()
+	admin, err := testQueries.SetUserRole(context.Background(), SetUserRoleParams{Username: user.Username, Role: util.AdminRole, RevokedAt: revokedAt})
+	require.NoError(t, err)
+	require.Equal(t, util.AdminRole, admin.Role)
+	require.WithinDuration(t, revokedAt, admin.TokensRevokedAt, time.Millisecond)
+
+	// setting the same role again keeps the tokens
+	same, err := testQueries.SetUserRole(context.Background(), SetUserRoleParams{Username: user.Username, Role: util.AdminRole, RevokedAt: time.Now()})
+	require.NoError(t, err)
+	require.Equal(t, admin.TokensRevokedAt, same.TokensRevokedAt)
+
+	_, err = testQueries.SetUserRole(context.Background(), SetUserRoleParams{Username: user.Username, Role: "root", RevokedAt: time.Now()})
+	require.Error(t, err)
+}
+
+func TestSetUserDisabled(t *testing.T) {
+	user := CreateRandomUser(t)
+
+	revokedAt := time.Now()
+	disabled, err := testQueries.SetUserDisabled(context.Background(), SetUserDisabledParams{Username: user.Username, Disabled: true, RevokedAt: revokedAt})
+	require.NoError(t, err)
+	require.NotNil(t, disabled.DisabledAt)
+	require.WithinDuration(t, revokedAt, disabled.TokensRevokedAt, time.Millisecond)
+
+	// disabled users have no token status, so all their tokens are rejected
+	_, err = testQueries.GetTokenStatus(context.Background(), GetTokenStatusParams{Username: user.Username})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	enabled, err := testQueries.SetUserDisabled(context.Background(), SetUserDisabledParams{Username: user.Username, Disabled: false, RevokedAt: time.Now()})
+	require.NoError(t, err)
+	require.Nil(t, enabled.DisabledAt)
+	require.Equal(t, disabled.TokensRevokedAt, enabled.TokensRevokedAt)
+}
+
+func TestListUsers(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		CreateRandomUser(t)
+	}
+
+	first, err := testQueries.ListUsers(context.Background(), ListUsersParams{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+
+	next, err := testQueries.ListUsers(context.Background(), ListUsersParams{
+		CursorUsername:  sql.NullString{String: first[1].Username, Valid: true},
+		CursorCreatedAt: first[1].CreatedAt,
+		Limit:           2,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, next)
+	require.NotEqual(t, first[1].Username, next[0].Username)
+	require.False(t, next[0].CreatedAt.Before(first[1].CreatedAt))
+}
+
+func TestGetSystemStats(t *testing.T) {
+	CreateRandomUser(t)
+
+	stats, err := testQueries.GetSystemStats(context.Background())
+	require.NoError(t, err)
+	require.Positive(t, stats.Users)
+	require.LessOrEqual(t, stats.DisabledUsers, stats.Users)
+	require.GreaterOrEqual(t, stats.AttachmentBytes, int64(0))
+}
@@ -7,28 +7,63 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const addWalletBalance = `-- name: AddWalletBalance :one
+UPDATE wallets
+SET balance = balance + $1
+WHERE id = $2
+RETURNING name, id, owner, currency, created_at, balance
+`
+
+type AddWalletBalanceParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddWalletBalance(ctx context.Context, arg AddWalletBalanceParams) (Wallet, error) {
+	row := q.queryRow(ctx, q.addWalletBalanceStmt, addWalletBalance, arg.Amount, arg.ID)
+	var i Wallet
+	err := row.Scan(
+		&i.Name,
+		&i.ID,
+		&i.Owner,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Balance,
+	)
+	return i, err
+}
+
 const createWallet = `-- name: CreateWallet :one
 
 INSERT INTO wallets (
     name,
     owner,
-    currency
+    currency,
+    balance
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 )
-RETURNING name, id, owner, currency, created_at
+RETURNING name, id, owner, currency, created_at, balance
 `
 
 type CreateWalletParams struct {
 	Name     string `json:"name"`
 	Owner    string `json:"owner"`
 	Currency string `json:"currency"`
+	Balance  int64  `json:"balance"`
 }
 
 func (q *Queries) CreateWallet(ctx context.Context, arg CreateWalletParams) (Wallet, error) {
-	row := q.queryRow(ctx, q.createWalletStmt, createWallet, arg.Name, arg.Owner, arg.Currency)
+	row := q.queryRow(ctx, q.createWalletStmt, createWallet,
+		arg.Name,
+		arg.Owner,
+		arg.Currency,
+		arg.Balance,
+	)
 	var i Wallet
 	err := row.Scan(
 		&i.Name,
@@ -36,6 +71,7 @@ func (q *Queries) CreateWallet(ctx context.Context, arg CreateWalletParams) (Wal
 		&i.Owner,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Balance,
 	)
 	return i, err
 }
@@ -56,7 +92,7 @@ func (q *Queries) DeleteWallet(ctx context.Context, arg DeleteWalletParams) erro
 }
 
 const getWallet = `-- name: GetWallet :one
-SELECT name, id, owner, currency, created_at FROM wallets
+SELECT name, id, owner, currency, created_at, balance FROM wallets
 WHERE id = $1 LIMIT 1
 `
 
@@ -69,26 +105,54 @@ func (q *Queries) GetWallet(ctx context.Context, id int64) (Wallet, error) {
 		&i.Owner,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Balance,
+	)
+	return i, err
+}
+
+const getWalletForUpdate = `-- name: GetWalletForUpdate :one
+SELECT name, id, owner, currency, created_at, balance FROM wallets
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetWalletForUpdate(ctx context.Context, id int64) (Wallet, error) {
+	row := q.queryRow(ctx, q.getWalletForUpdateStmt, getWalletForUpdate, id)
+	var i Wallet
+	err := row.Scan(
+		&i.Name,
+		&i.ID,
+		&i.Owner,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Balance,
 	)
 	return i, err
 }
 
 const listWallets = `-- name: ListWallets :many
-SELECT name, id, owner, currency, created_at FROM wallets
+SELECT name, id, owner, currency, created_at, balance FROM wallets
 WHERE owner = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3
+  AND ($2::bigint IS NULL
+    OR (created_at, id) > ($3::timestamptz, $2))
+ORDER BY created_at, id
+LIMIT $4
 `
 
 type ListWalletsParams struct {
-	Owner  string `json:"owner"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+	Owner           string        `json:"owner"`
+	CursorID        sql.NullInt64 `json:"cursor_id"`
+	CursorCreatedAt time.Time     `json:"cursor_created_at"`
+	Limit           int32         `json:"limit"`
 }
 
 func (q *Queries) ListWallets(ctx context.Context, arg ListWalletsParams) ([]Wallet, error) {
-	rows, err := q.query(ctx, q.listWalletsStmt, listWallets, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.query(ctx, q.listWalletsStmt, listWallets,
+		arg.Owner,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +166,7 @@ func (q *Queries) ListWallets(ctx context.Context, arg ListWalletsParams) ([]Wal
 			&i.Owner,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.Balance,
 		); err != nil {
 			return nil, err
 		}
@@ -115,3 +180,31 @@ func (q *Queries) ListWallets(ctx context.Context, arg ListWalletsParams) ([]Wal
 	}
 	return items, nil
 }
+
+const updateWallet = `-- name: UpdateWallet :one
+UPDATE wallets
+SET
+    name = COALESCE($1, name)
+WHERE
+    id = $2
+RETURNING name, id, owner, currency, created_at, balance
+`
+
+type UpdateWalletParams struct {
+	Name sql.NullString `json:"name"`
+	ID   int64          `json:"id"`
+}
+
+func (q *Queries) UpdateWallet(ctx context.Context, arg UpdateWalletParams) (Wallet, error) {
+	row := q.queryRow(ctx, q.updateWalletStmt, updateWallet, arg.Name, arg.ID)
+	var i Wallet
+	err := row.Scan(
+		&i.Name,
+		&i.ID,
+		&i.Owner,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Balance,
+	)
+	return i, err
+}
@@ -16,6 +16,7 @@ func CreateRandomWallet(t *testing.T, user User) Wallet {
 		Name:     util.RandomString(6),
 		Owner:    user.Username,
 		Currency: util.RandomCurrency(),
+		Balance:  util.RandomAmount(),
 	}
 
 	wallet, err := testQueries.CreateWallet(context.Background(), arg)
@@ -25,6 +26,7 @@ func CreateRandomWallet(t *testing.T, user User) Wallet {
 	require.Equal(t, arg.Owner, wallet.Owner)
 	require.Equal(t, arg.Name, wallet.Name)
 	require.Equal(t, arg.Currency, wallet.Currency)
+	require.Equal(t, arg.Balance, wallet.Balance)
 	require.NotZero(t, wallet.ID)
 	require.NotZero(t, wallet.CreatedAt)
 
@@ -50,6 +52,24 @@ func TestGetWallet(t *testing.T) {
 	require.WithinDuration(t, wallet1.CreatedAt, wallet2.CreatedAt, time.Second)
 }
 
+func TestUpdateWallet(t *testing.T) {
+	user := CreateRandomUser(t)
+	wallet1 := CreateRandomWallet(t, user)
+	arg := UpdateWalletParams{
+		ID:   wallet1.ID,
+		Name: sql.NullString{String: util.RandomString(6), Valid: true},
+	}
+	wallet2, err := testQueries.UpdateWallet(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, wallet2)
+
+	require.Equal(t, wallet1.ID, wallet2.ID)
+	require.Equal(t, arg.Name.String, wallet2.Name)
+	require.Equal(t, wallet1.Owner, wallet2.Owner)
+	require.Equal(t, wallet1.Currency, wallet2.Currency)
+	require.Equal(t, wallet1.Balance, wallet2.Balance)
+}
+
 func TestDeleteWallet(t *testing.T) {
 	user := CreateRandomUser(t)
 	wallet1 := CreateRandomWallet(t, user)
@@ -73,15 +93,22 @@ func TestListWallets(t *testing.T) {
 	}
 
 	arg := ListWalletsParams{
-		Owner:  user.Username,
-		Limit:  5,
-		Offset: 5,
+		Owner: user.Username,
+		Limit: 5,
 	}
 
 	wallets, err := testQueries.ListWallets(context.Background(), arg)
 	require.NoError(t, err)
 	require.Len(t, wallets, 5)
-	for _, wallet := range wallets {
+
+	last := wallets[len(wallets)-1]
+	arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+	arg.CursorCreatedAt = last.CreatedAt
+	nextWallets, err := testQueries.ListWallets(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, nextWallets, 5)
+	for _, wallet := range nextWallets {
 		require.NotEmpty(t, wallet)
+		require.Greater(t, wallet.ID, last.ID)
 	}
 }
@@ -0,0 +1,18 @@
+CREATE TABLE "incomes" (
+  "id" bigserial PRIMARY KEY,
+  "wallet_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "income_description" varchar NOT NULL,
+  "category_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "incomes" ("wallet_id");
+
+CREATE INDEX ON "incomes" ("category_id");
+
+COMMENT ON COLUMN "incomes"."amount" IS 'must be positive';
+
+ALTER TABLE "incomes" ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "incomes" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
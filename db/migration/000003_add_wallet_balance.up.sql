@@ -0,0 +1,6 @@
+ALTER TABLE "wallets" ADD COLUMN "balance" bigint NOT NULL DEFAULT 0;
+
+UPDATE "wallets" w
+SET "balance" =
+  COALESCE((SELECT sum(i.amount) FROM "incomes" i WHERE i.wallet_id = w.id), 0) -
+  COALESCE((SELECT sum(e.amount) FROM "expenses" e WHERE e.wallet_id = w.id), 0);
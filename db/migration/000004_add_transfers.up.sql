@@ -0,0 +1,19 @@
+CREATE TABLE "transfers" (
+  "id" bigserial PRIMARY KEY,
+  "from_wallet_id" bigint NOT NULL,
+  "to_wallet_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfers" ("from_wallet_id");
+
+CREATE INDEX ON "transfers" ("to_wallet_id");
+
+CREATE INDEX ON "transfers" ("from_wallet_id", "to_wallet_id");
+
+COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("from_wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("to_wallet_id") REFERENCES "wallets" ("id");
@@ -0,0 +1,13 @@
+CREATE TABLE "exchange_rates" (
+  "base_currency" varchar NOT NULL,
+  "quote_currency" varchar NOT NULL,
+  "rate_date" date NOT NULL,
+  "rate" numeric(24, 12) NOT NULL,
+  "source" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("base_currency", "quote_currency", "rate_date")
+);
+
+CREATE INDEX ON "exchange_rates" ("quote_currency", "rate_date");
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'units of quote_currency for one unit of base_currency';
@@ -0,0 +1,13 @@
+ALTER TABLE "budgets" DROP CONSTRAINT IF EXISTS "budgets_custom_range_check";
+
+ALTER TABLE "budgets" DROP CONSTRAINT IF EXISTS "budgets_date_range_check";
+
+ALTER TABLE "budgets" DROP CONSTRAINT IF EXISTS "budgets_period_check";
+
+ALTER TABLE "budgets" DROP COLUMN IF EXISTS "recurring";
+
+ALTER TABLE "budgets" DROP COLUMN IF EXISTS "end_date";
+
+ALTER TABLE "budgets" DROP COLUMN IF EXISTS "start_date";
+
+ALTER TABLE "budgets" DROP COLUMN IF EXISTS "period";
@@ -0,0 +1,22 @@
+ALTER TABLE "budgets" ADD COLUMN "period" varchar NOT NULL DEFAULT 'monthly';
+
+ALTER TABLE "budgets" ADD COLUMN "start_date" date NOT NULL DEFAULT (current_date);
+
+ALTER TABLE "budgets" ADD COLUMN "end_date" date;
+
+ALTER TABLE "budgets" ADD COLUMN "recurring" boolean NOT NULL DEFAULT true;
+
+UPDATE "budgets" SET "start_date" = "created_at"::date;
+
+ALTER TABLE "budgets" ADD CONSTRAINT "budgets_period_check"
+  CHECK ("period" IN ('weekly', 'monthly', 'yearly', 'custom'));
+
+ALTER TABLE "budgets" ADD CONSTRAINT "budgets_date_range_check"
+  CHECK ("end_date" IS NULL OR "end_date" >= "start_date");
+
+ALTER TABLE "budgets" ADD CONSTRAINT "budgets_custom_range_check"
+  CHECK ("period" <> 'custom' OR "end_date" IS NOT NULL);
+
+COMMENT ON COLUMN "budgets"."period" IS 'weekly, monthly, yearly or custom';
+
+COMMENT ON COLUMN "budgets"."end_date" IS 'last day the budget applies; for custom periods the end of the first range';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "expenses_wallet_id_category_id_created_at_idx";
@@ -0,0 +1 @@
+CREATE INDEX ON "expenses" ("wallet_id", "category_id", "created_at");
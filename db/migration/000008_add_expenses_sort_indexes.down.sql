@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "expenses_wallet_id_amount_id_idx";
+
+DROP INDEX IF EXISTS "expenses_wallet_id_created_at_id_idx";
@@ -0,0 +1,3 @@
+CREATE INDEX ON "expenses" ("wallet_id", "created_at", "id");
+
+CREATE INDEX ON "expenses" ("wallet_id", "amount", "id");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "budgets_wallet_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "categories_owner_created_at_id_idx";
+
+DROP INDEX IF EXISTS "wallets_owner_created_at_id_idx";
@@ -0,0 +1,5 @@
+CREATE INDEX ON "wallets" ("owner", "created_at", "id");
+
+CREATE INDEX ON "categories" ("owner", "created_at", "id");
+
+CREATE INDEX ON "budgets" ("wallet_id", "created_at", "id");
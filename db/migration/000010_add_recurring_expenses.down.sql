@@ -0,0 +1,5 @@
+ALTER TABLE "expenses" DROP COLUMN IF EXISTS "occurrence_date";
+
+ALTER TABLE "expenses" DROP COLUMN IF EXISTS "recurring_expense_id";
+
+DROP TABLE IF EXISTS "recurring_expenses";
@@ -0,0 +1,46 @@
+CREATE TABLE "recurring_expenses" (
+  "id" bigserial PRIMARY KEY,
+  "wallet_id" bigint NOT NULL,
+  "category_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "expense_description" varchar NOT NULL,
+  "frequency" varchar NOT NULL,
+  "interval" integer NOT NULL DEFAULT 1,
+  "last_day_of_month" boolean NOT NULL DEFAULT false,
+  "start_date" date NOT NULL,
+  "end_date" date,
+  "max_occurrences" integer,
+  "occurrences" integer NOT NULL DEFAULT 0,
+  "next_occurrence" date NOT NULL,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "recurring_expenses_frequency_check"
+    CHECK ("frequency" IN ('daily', 'weekly', 'monthly', 'yearly')),
+  CONSTRAINT "recurring_expenses_interval_check" CHECK ("interval" > 0),
+  CONSTRAINT "recurring_expenses_date_range_check"
+    CHECK ("end_date" IS NULL OR "end_date" >= "start_date"),
+  CONSTRAINT "recurring_expenses_max_occurrences_check"
+    CHECK ("max_occurrences" IS NULL OR "max_occurrences" > 0)
+);
+
+CREATE INDEX ON "recurring_expenses" ("wallet_id", "created_at", "id");
+
+CREATE INDEX ON "recurring_expenses" ("next_occurrence") WHERE "active";
+
+COMMENT ON COLUMN "recurring_expenses"."amount" IS 'must be positive';
+
+COMMENT ON COLUMN "recurring_expenses"."last_day_of_month" IS 'monthly and yearly rules fall on the last day of the month';
+
+COMMENT ON COLUMN "recurring_expenses"."next_occurrence" IS 'date of the next expense to materialize';
+
+ALTER TABLE "recurring_expenses" ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "recurring_expenses" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
+
+ALTER TABLE "expenses" ADD COLUMN "recurring_expense_id" bigint;
+
+ALTER TABLE "expenses" ADD COLUMN "occurrence_date" date;
+
+ALTER TABLE "expenses" ADD FOREIGN KEY ("recurring_expense_id") REFERENCES "recurring_expenses" ("id") ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX ON "expenses" ("recurring_expense_id", "occurrence_date");
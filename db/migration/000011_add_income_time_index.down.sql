@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "incomes_wallet_id_created_at_idx";
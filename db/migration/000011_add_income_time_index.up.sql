@@ -0,0 +1 @@
+CREATE INDEX ON "incomes" ("wallet_id", "created_at");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "expenses_wallet_id_external_id_idx";
+
+ALTER TABLE "expenses" DROP COLUMN IF EXISTS "external_id";
@@ -0,0 +1,5 @@
+ALTER TABLE "expenses" ADD COLUMN "external_id" varchar;
+
+CREATE UNIQUE INDEX ON "expenses" ("wallet_id", "external_id") WHERE "external_id" IS NOT NULL;
+
+COMMENT ON COLUMN "expenses"."external_id" IS 'transaction id from an imported bank statement';
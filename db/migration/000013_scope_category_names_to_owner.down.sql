@@ -0,0 +1,3 @@
+ALTER TABLE "categories" DROP CONSTRAINT "categories_owner_name_key";
+
+ALTER TABLE "categories" ADD CONSTRAINT "categories_name_key" UNIQUE ("name");
@@ -0,0 +1,30 @@
+CREATE TABLE "categorization_rules" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "category_id" bigint NOT NULL,
+  "priority" integer NOT NULL DEFAULT 0,
+  "match_type" varchar NOT NULL DEFAULT 'substring',
+  "pattern" varchar,
+  "min_amount" bigint,
+  "max_amount" bigint,
+  "wallet_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "categorization_rules_match_type_check"
+    CHECK ("match_type" IN ('substring', 'regex')),
+  CONSTRAINT "categorization_rules_amount_range_check"
+    CHECK ("min_amount" IS NULL OR "max_amount" IS NULL OR "min_amount" <= "max_amount"),
+  CONSTRAINT "categorization_rules_condition_check"
+    CHECK ("pattern" IS NOT NULL OR "min_amount" IS NOT NULL OR "max_amount" IS NOT NULL OR "wallet_id" IS NOT NULL)
+);
+
+CREATE INDEX ON "categorization_rules" ("owner", "priority");
+
+COMMENT ON COLUMN "categorization_rules"."priority" IS 'rules with a higher priority are tried first';
+
+COMMENT ON COLUMN "categorization_rules"."pattern" IS 'matched against the expense description, case-insensitively';
+
+ALTER TABLE "categorization_rules" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "categorization_rules" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "categorization_rules" ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id") ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS "expense_allocations";
+
+DROP TABLE IF EXISTS "expense_splits";
@@ -0,0 +1,30 @@
+CREATE TABLE "expense_splits" (
+  "id" bigserial PRIMARY KEY,
+  "expense_id" bigint NOT NULL,
+  "category_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "expense_splits_amount_check" CHECK ("amount" > 0)
+);
+
+CREATE INDEX ON "expense_splits" ("expense_id");
+
+CREATE INDEX ON "expense_splits" ("category_id");
+
+COMMENT ON TABLE "expense_splits" IS 'category allocations of an expense; they sum to the expense amount';
+
+ALTER TABLE "expense_splits" ADD FOREIGN KEY ("expense_id") REFERENCES "expenses" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "expense_splits" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
+
+-- one row per category an expense is charged to: its splits, or the expense
+-- itself when it is not split
+CREATE VIEW "expense_allocations" AS
+SELECT
+  e.id AS expense_id,
+  e.wallet_id,
+  COALESCE(s.category_id, e.category_id) AS category_id,
+  COALESCE(s.amount, e.amount) AS amount,
+  e.created_at
+FROM expenses e
+LEFT JOIN expense_splits s ON s.expense_id = e.id;
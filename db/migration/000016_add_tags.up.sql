@@ -0,0 +1,22 @@
+CREATE TABLE "tags" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "tags_owner_name_key" UNIQUE ("owner", "name")
+);
+
+CREATE TABLE "expense_tags" (
+  "expense_id" bigint NOT NULL,
+  "tag_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("expense_id", "tag_id")
+);
+
+CREATE INDEX ON "expense_tags" ("tag_id");
+
+ALTER TABLE "tags" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "expense_tags" ADD FOREIGN KEY ("expense_id") REFERENCES "expenses" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "expense_tags" ADD FOREIGN KEY ("tag_id") REFERENCES "tags" ("id") ON DELETE CASCADE;
@@ -0,0 +1,15 @@
+CREATE TABLE "attachments" (
+  "id" bigserial PRIMARY KEY,
+  "expense_id" bigint NOT NULL,
+  "blob_key" varchar NOT NULL UNIQUE,
+  "file_name" varchar NOT NULL,
+  "content_type" varchar NOT NULL,
+  "size" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "attachments" ("expense_id");
+
+COMMENT ON COLUMN "attachments"."blob_key" IS 'key of the content in the blob store';
+
+ALTER TABLE "attachments" ADD FOREIGN KEY ("expense_id") REFERENCES "expenses" ("id") ON DELETE CASCADE;
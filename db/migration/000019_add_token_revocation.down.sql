@@ -0,0 +1,2 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "tokens_revoked_at";
+DROP TABLE IF EXISTS "revoked_tokens";
@@ -0,0 +1,3 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "disabled_at";
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "users_role_check";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,7 @@
+ALTER TABLE "users" ADD COLUMN "role" varchar NOT NULL DEFAULT 'user';
+
+ALTER TABLE "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('user', 'admin'));
+
+ALTER TABLE "users" ADD COLUMN "disabled_at" timestamptz;
+
+COMMENT ON COLUMN "users"."disabled_at" IS 'disabled users cannot log in and their tokens are rejected';
@@ -7,8 +7,10 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	db "github.com/symyzi/financial-helper/db/gen"
 )
 
@@ -35,270 +37,1715 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddExpenseTag mocks base method.
+func (m *MockStore) AddExpenseTag(arg0 context.Context, arg1 db.AddExpenseTagParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddExpenseTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddExpenseTag indicates an expected call of AddExpenseTag.
+func (mr *MockStoreMockRecorder) AddExpenseTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddExpenseTag", reflect.TypeOf((*MockStore)(nil).AddExpenseTag), arg0, arg1)
+}
+
+// AddWalletBalance mocks base method.
+func (m *MockStore) AddWalletBalance(arg0 context.Context, arg1 db.AddWalletBalanceParams) (db.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWalletBalance", arg0, arg1)
+	ret0, _ := ret[0].(db.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWalletBalance indicates an expected call of AddWalletBalance.
+func (mr *MockStoreMockRecorder) AddWalletBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWalletBalance", reflect.TypeOf((*MockStore)(nil).AddWalletBalance), arg0, arg1)
+}
+
+// BackupTx mocks base method.
+func (m *MockStore) BackupTx(arg0 context.Context, arg1 string) (db.OwnerData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupTx", arg0, arg1)
+	ret0, _ := ret[0].(db.OwnerData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackupTx indicates an expected call of BackupTx.
+func (mr *MockStoreMockRecorder) BackupTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupTx", reflect.TypeOf((*MockStore)(nil).BackupTx), arg0, arg1)
+}
+
+// BlockSession mocks base method.
+func (m *MockStore) BlockSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockSession indicates an expected call of BlockSession.
+func (mr *MockStoreMockRecorder) BlockSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSession", reflect.TypeOf((*MockStore)(nil).BlockSession), arg0, arg1)
+}
+
+// BlockUserSessions mocks base method.
+func (m *MockStore) BlockUserSessions(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUserSessions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockUserSessions indicates an expected call of BlockUserSessions.
+func (mr *MockStoreMockRecorder) BlockUserSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSessions", reflect.TypeOf((*MockStore)(nil).BlockUserSessions), arg0, arg1)
+}
+
+// CreateAttachment mocks base method.
+func (m *MockStore) CreateAttachment(arg0 context.Context, arg1 db.CreateAttachmentParams) (db.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", arg0, arg1)
+	ret0, _ := ret[0].(db.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockStoreMockRecorder) CreateAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockStore)(nil).CreateAttachment), arg0, arg1)
+}
+
 // CreateBudget mocks base method.
 func (m *MockStore) CreateBudget(arg0 context.Context, arg1 db.CreateBudgetParams) (db.Budget, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBudget", arg0, arg1)
-	ret0, _ := ret[0].(db.Budget)
+	ret := m.ctrl.Call(m, "CreateBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBudget indicates an expected call of CreateBudget.
+func (mr *MockStoreMockRecorder) CreateBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudget", reflect.TypeOf((*MockStore)(nil).CreateBudget), arg0, arg1)
+}
+
+// CreateCategorizationRule mocks base method.
+func (m *MockStore) CreateCategorizationRule(arg0 context.Context, arg1 db.CreateCategorizationRuleParams) (db.CategorizationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(db.CategorizationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategorizationRule indicates an expected call of CreateCategorizationRule.
+func (mr *MockStoreMockRecorder) CreateCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategorizationRule", reflect.TypeOf((*MockStore)(nil).CreateCategorizationRule), arg0, arg1)
+}
+
+// CreateCategory mocks base method.
+func (m *MockStore) CreateCategory(arg0 context.Context, arg1 db.CreateCategoryParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
+}
+
+// CreateExpense mocks base method.
+func (m *MockStore) CreateExpense(arg0 context.Context, arg1 db.CreateExpenseParams) (db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExpense", arg0, arg1)
+	ret0, _ := ret[0].(db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExpense indicates an expected call of CreateExpense.
+func (mr *MockStoreMockRecorder) CreateExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExpense", reflect.TypeOf((*MockStore)(nil).CreateExpense), arg0, arg1)
+}
+
+// CreateExpenseOccurrence mocks base method.
+func (m *MockStore) CreateExpenseOccurrence(arg0 context.Context, arg1 db.CreateExpenseOccurrenceParams) (db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExpenseOccurrence", arg0, arg1)
+	ret0, _ := ret[0].(db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExpenseOccurrence indicates an expected call of CreateExpenseOccurrence.
+func (mr *MockStoreMockRecorder) CreateExpenseOccurrence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExpenseOccurrence", reflect.TypeOf((*MockStore)(nil).CreateExpenseOccurrence), arg0, arg1)
+}
+
+// CreateExpenseSplit mocks base method.
+func (m *MockStore) CreateExpenseSplit(arg0 context.Context, arg1 db.CreateExpenseSplitParams) (db.ExpenseSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExpenseSplit", arg0, arg1)
+	ret0, _ := ret[0].(db.ExpenseSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExpenseSplit indicates an expected call of CreateExpenseSplit.
+func (mr *MockStoreMockRecorder) CreateExpenseSplit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExpenseSplit", reflect.TypeOf((*MockStore)(nil).CreateExpenseSplit), arg0, arg1)
+}
+
+// CreateExpenseTx mocks base method.
+func (m *MockStore) CreateExpenseTx(arg0 context.Context, arg1 db.CreateExpenseParams) (db.ExpenseTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExpenseTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ExpenseTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExpenseTx indicates an expected call of CreateExpenseTx.
+func (mr *MockStoreMockRecorder) CreateExpenseTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExpenseTx", reflect.TypeOf((*MockStore)(nil).CreateExpenseTx), arg0, arg1)
+}
+
+// CreateImportedExpense mocks base method.
+func (m *MockStore) CreateImportedExpense(arg0 context.Context, arg1 db.CreateImportedExpenseParams) (db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportedExpense", arg0, arg1)
+	ret0, _ := ret[0].(db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImportedExpense indicates an expected call of CreateImportedExpense.
+func (mr *MockStoreMockRecorder) CreateImportedExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportedExpense", reflect.TypeOf((*MockStore)(nil).CreateImportedExpense), arg0, arg1)
+}
+
+// CreateIncome mocks base method.
+func (m *MockStore) CreateIncome(arg0 context.Context, arg1 db.CreateIncomeParams) (db.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncome", arg0, arg1)
+	ret0, _ := ret[0].(db.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncome indicates an expected call of CreateIncome.
+func (mr *MockStoreMockRecorder) CreateIncome(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncome", reflect.TypeOf((*MockStore)(nil).CreateIncome), arg0, arg1)
+}
+
+// CreateIncomeTx mocks base method.
+func (m *MockStore) CreateIncomeTx(arg0 context.Context, arg1 db.CreateIncomeParams) (db.IncomeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncomeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.IncomeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncomeTx indicates an expected call of CreateIncomeTx.
+func (mr *MockStoreMockRecorder) CreateIncomeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncomeTx", reflect.TypeOf((*MockStore)(nil).CreateIncomeTx), arg0, arg1)
+}
+
+// CreateRecurringExpense mocks base method.
+func (m *MockStore) CreateRecurringExpense(arg0 context.Context, arg1 db.CreateRecurringExpenseParams) (db.RecurringExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurringExpense", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurringExpense indicates an expected call of CreateRecurringExpense.
+func (mr *MockStoreMockRecorder) CreateRecurringExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurringExpense", reflect.TypeOf((*MockStore)(nil).CreateRecurringExpense), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateTag mocks base method.
+func (m *MockStore) CreateTag(arg0 context.Context, arg1 db.CreateTagParams) (db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", arg0, arg1)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockStoreMockRecorder) CreateTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockStore)(nil).CreateTag), arg0, arg1)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStore) CreateTransfer(arg0 context.Context, arg1 db.CreateTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStoreMockRecorder) CreateTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateWallet mocks base method.
+func (m *MockStore) CreateWallet(arg0 context.Context, arg1 db.CreateWalletParams) (db.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWallet", arg0, arg1)
+	ret0, _ := ret[0].(db.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWallet indicates an expected call of CreateWallet.
+func (mr *MockStoreMockRecorder) CreateWallet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockStore)(nil).CreateWallet), arg0, arg1)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockStore) DeleteAttachment(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockStoreMockRecorder) DeleteAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockStore)(nil).DeleteAttachment), arg0, arg1)
+}
+
+// DeleteBudget mocks base method.
+func (m *MockStore) DeleteBudget(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBudget", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBudget indicates an expected call of DeleteBudget.
+func (mr *MockStoreMockRecorder) DeleteBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudget", reflect.TypeOf((*MockStore)(nil).DeleteBudget), arg0, arg1)
+}
+
+// DeleteCategorizationRule mocks base method.
+func (m *MockStore) DeleteCategorizationRule(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategorizationRule indicates an expected call of DeleteCategorizationRule.
+func (mr *MockStoreMockRecorder) DeleteCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategorizationRule", reflect.TypeOf((*MockStore)(nil).DeleteCategorizationRule), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
+}
+
+// DeleteExpense mocks base method.
+func (m *MockStore) DeleteExpense(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpense", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpense indicates an expected call of DeleteExpense.
+func (mr *MockStoreMockRecorder) DeleteExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpense", reflect.TypeOf((*MockStore)(nil).DeleteExpense), arg0, arg1)
+}
+
+// DeleteExpenseAttachments mocks base method.
+func (m *MockStore) DeleteExpenseAttachments(arg0 context.Context, arg1 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpenseAttachments", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpenseAttachments indicates an expected call of DeleteExpenseAttachments.
+func (mr *MockStoreMockRecorder) DeleteExpenseAttachments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpenseAttachments", reflect.TypeOf((*MockStore)(nil).DeleteExpenseAttachments), arg0, arg1)
+}
+
+// DeleteExpenseSplits mocks base method.
+func (m *MockStore) DeleteExpenseSplits(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpenseSplits", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpenseSplits indicates an expected call of DeleteExpenseSplits.
+func (mr *MockStoreMockRecorder) DeleteExpenseSplits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpenseSplits", reflect.TypeOf((*MockStore)(nil).DeleteExpenseSplits), arg0, arg1)
+}
+
+// DeleteExpenseTx mocks base method.
+func (m *MockStore) DeleteExpenseTx(arg0 context.Context, arg1 int64) (db.DeleteExpenseTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpenseTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DeleteExpenseTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpenseTx indicates an expected call of DeleteExpenseTx.
+func (mr *MockStoreMockRecorder) DeleteExpenseTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpenseTx", reflect.TypeOf((*MockStore)(nil).DeleteExpenseTx), arg0, arg1)
+}
+
+// DeleteExpiredRevokedTokens mocks base method.
+func (m *MockStore) DeleteExpiredRevokedTokens(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRevokedTokens", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredRevokedTokens indicates an expected call of DeleteExpiredRevokedTokens.
+func (mr *MockStoreMockRecorder) DeleteExpiredRevokedTokens(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRevokedTokens", reflect.TypeOf((*MockStore)(nil).DeleteExpiredRevokedTokens), arg0)
+}
+
+// DeleteIncome mocks base method.
+func (m *MockStore) DeleteIncome(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIncome", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIncome indicates an expected call of DeleteIncome.
+func (mr *MockStoreMockRecorder) DeleteIncome(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncome", reflect.TypeOf((*MockStore)(nil).DeleteIncome), arg0, arg1)
+}
+
+// DeleteIncomeTx mocks base method.
+func (m *MockStore) DeleteIncomeTx(arg0 context.Context, arg1 int64) (db.IncomeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIncomeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.IncomeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteIncomeTx indicates an expected call of DeleteIncomeTx.
+func (mr *MockStoreMockRecorder) DeleteIncomeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncomeTx", reflect.TypeOf((*MockStore)(nil).DeleteIncomeTx), arg0, arg1)
+}
+
+// DeleteRecurringExpense mocks base method.
+func (m *MockStore) DeleteRecurringExpense(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurringExpense", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecurringExpense indicates an expected call of DeleteRecurringExpense.
+func (mr *MockStoreMockRecorder) DeleteRecurringExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurringExpense", reflect.TypeOf((*MockStore)(nil).DeleteRecurringExpense), arg0, arg1)
+}
+
+// DeleteTag mocks base method.
+func (m *MockStore) DeleteTag(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockStoreMockRecorder) DeleteTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockStore)(nil).DeleteTag), arg0, arg1)
+}
+
+// DeleteWallet mocks base method.
+func (m *MockStore) DeleteWallet(arg0 context.Context, arg1 db.DeleteWalletParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWallet", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWallet indicates an expected call of DeleteWallet.
+func (mr *MockStoreMockRecorder) DeleteWallet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallet", reflect.TypeOf((*MockStore)(nil).DeleteWallet), arg0, arg1)
+}
+
+// ExportTransactions mocks base method.
+func (m *MockStore) ExportTransactions(arg0 context.Context, arg1 db.ExportTransactionsParams) ([]db.ExportTransactionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExportTransactionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTransactions indicates an expected call of ExportTransactions.
+func (mr *MockStoreMockRecorder) ExportTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTransactions", reflect.TypeOf((*MockStore)(nil).ExportTransactions), arg0, arg1)
+}
+
+// GetAllCategories mocks base method.
+func (m *MockStore) GetAllCategories(arg0 context.Context, arg1 string) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategories indicates an expected call of GetAllCategories.
+func (mr *MockStoreMockRecorder) GetAllCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockStore)(nil).GetAllCategories), arg0, arg1)
+}
+
+// GetAttachment mocks base method.
+func (m *MockStore) GetAttachment(arg0 context.Context, arg1 int64) (db.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", arg0, arg1)
+	ret0, _ := ret[0].(db.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockStoreMockRecorder) GetAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockStore)(nil).GetAttachment), arg0, arg1)
+}
+
+// GetBudgetByID mocks base method.
+func (m *MockStore) GetBudgetByID(arg0 context.Context, arg1 int64) (db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgetByID", arg0, arg1)
+	ret0, _ := ret[0].(db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgetByID indicates an expected call of GetBudgetByID.
+func (mr *MockStoreMockRecorder) GetBudgetByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetByID", reflect.TypeOf((*MockStore)(nil).GetBudgetByID), arg0, arg1)
+}
+
+// GetBudgetProgress mocks base method.
+func (m *MockStore) GetBudgetProgress(arg0 context.Context, arg1 db.GetBudgetProgressParams) (db.GetBudgetProgressRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudgetProgress", arg0, arg1)
+	ret0, _ := ret[0].(db.GetBudgetProgressRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudgetProgress indicates an expected call of GetBudgetProgress.
+func (mr *MockStoreMockRecorder) GetBudgetProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetProgress", reflect.TypeOf((*MockStore)(nil).GetBudgetProgress), arg0, arg1)
+}
+
+// GetCategorizationRule mocks base method.
+func (m *MockStore) GetCategorizationRule(arg0 context.Context, arg1 int64) (db.CategorizationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(db.CategorizationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategorizationRule indicates an expected call of GetCategorizationRule.
+func (mr *MockStoreMockRecorder) GetCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategorizationRule", reflect.TypeOf((*MockStore)(nil).GetCategorizationRule), arg0, arg1)
+}
+
+// GetCategoryByID mocks base method.
+func (m *MockStore) GetCategoryByID(arg0 context.Context, arg1 int64) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByID", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByID indicates an expected call of GetCategoryByID.
+func (mr *MockStoreMockRecorder) GetCategoryByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockStore)(nil).GetCategoryByID), arg0, arg1)
+}
+
+// GetDueRecurringExpenseForUpdate mocks base method.
+func (m *MockStore) GetDueRecurringExpenseForUpdate(arg0 context.Context, arg1 time.Time) (db.RecurringExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRecurringExpenseForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRecurringExpenseForUpdate indicates an expected call of GetDueRecurringExpenseForUpdate.
+func (mr *MockStoreMockRecorder) GetDueRecurringExpenseForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRecurringExpenseForUpdate", reflect.TypeOf((*MockStore)(nil).GetDueRecurringExpenseForUpdate), arg0, arg1)
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockStore) GetExchangeRate(arg0 context.Context, arg1 db.GetExchangeRateParams) (db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", arg0, arg1)
+	ret0, _ := ret[0].(db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockStoreMockRecorder) GetExchangeRate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockStore)(nil).GetExchangeRate), arg0, arg1)
+}
+
+// GetExpense mocks base method.
+func (m *MockStore) GetExpense(arg0 context.Context, arg1 int64) (db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpense", arg0, arg1)
+	ret0, _ := ret[0].(db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpense indicates an expected call of GetExpense.
+func (mr *MockStoreMockRecorder) GetExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpense", reflect.TypeOf((*MockStore)(nil).GetExpense), arg0, arg1)
+}
+
+// GetExpenseForDelete mocks base method.
+func (m *MockStore) GetExpenseForDelete(arg0 context.Context, arg1 int64) (db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpenseForDelete", arg0, arg1)
+	ret0, _ := ret[0].(db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpenseForDelete indicates an expected call of GetExpenseForDelete.
+func (mr *MockStoreMockRecorder) GetExpenseForDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseForDelete", reflect.TypeOf((*MockStore)(nil).GetExpenseForDelete), arg0, arg1)
+}
+
+// GetExpenseForUpdate mocks base method.
+func (m *MockStore) GetExpenseForUpdate(arg0 context.Context, arg1 int64) (db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpenseForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpenseForUpdate indicates an expected call of GetExpenseForUpdate.
+func (mr *MockStoreMockRecorder) GetExpenseForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpenseForUpdate", reflect.TypeOf((*MockStore)(nil).GetExpenseForUpdate), arg0, arg1)
+}
+
+// GetIncome mocks base method.
+func (m *MockStore) GetIncome(arg0 context.Context, arg1 int64) (db.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncome", arg0, arg1)
+	ret0, _ := ret[0].(db.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncome indicates an expected call of GetIncome.
+func (mr *MockStoreMockRecorder) GetIncome(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncome", reflect.TypeOf((*MockStore)(nil).GetIncome), arg0, arg1)
+}
+
+// GetIncomeForUpdate mocks base method.
+func (m *MockStore) GetIncomeForUpdate(arg0 context.Context, arg1 int64) (db.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomeForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomeForUpdate indicates an expected call of GetIncomeForUpdate.
+func (mr *MockStoreMockRecorder) GetIncomeForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomeForUpdate", reflect.TypeOf((*MockStore)(nil).GetIncomeForUpdate), arg0, arg1)
+}
+
+// GetMonthlyCategoryReport mocks base method.
+func (m *MockStore) GetMonthlyCategoryReport(arg0 context.Context, arg1 db.GetMonthlyCategoryReportParams) ([]db.GetMonthlyCategoryReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthlyCategoryReport", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetMonthlyCategoryReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthlyCategoryReport indicates an expected call of GetMonthlyCategoryReport.
+func (mr *MockStoreMockRecorder) GetMonthlyCategoryReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthlyCategoryReport", reflect.TypeOf((*MockStore)(nil).GetMonthlyCategoryReport), arg0, arg1)
+}
+
+// GetOrCreateCategory mocks base method.
+func (m *MockStore) GetOrCreateCategory(arg0 context.Context, arg1 db.GetOrCreateCategoryParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrCreateCategory indicates an expected call of GetOrCreateCategory.
+func (mr *MockStoreMockRecorder) GetOrCreateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateCategory", reflect.TypeOf((*MockStore)(nil).GetOrCreateCategory), arg0, arg1)
+}
+
+// GetRecurringExpense mocks base method.
+func (m *MockStore) GetRecurringExpense(arg0 context.Context, arg1 int64) (db.RecurringExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringExpense", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringExpense indicates an expected call of GetRecurringExpense.
+func (mr *MockStoreMockRecorder) GetRecurringExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringExpense", reflect.TypeOf((*MockStore)(nil).GetRecurringExpense), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetSystemStats mocks base method.
+func (m *MockStore) GetSystemStats(arg0 context.Context) (db.GetSystemStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSystemStats", arg0)
+	ret0, _ := ret[0].(db.GetSystemStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSystemStats indicates an expected call of GetSystemStats.
+func (mr *MockStoreMockRecorder) GetSystemStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemStats", reflect.TypeOf((*MockStore)(nil).GetSystemStats), arg0)
+}
+
+// GetTag mocks base method.
+func (m *MockStore) GetTag(arg0 context.Context, arg1 int64) (db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTag", arg0, arg1)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTag indicates an expected call of GetTag.
+func (mr *MockStoreMockRecorder) GetTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTag", reflect.TypeOf((*MockStore)(nil).GetTag), arg0, arg1)
+}
+
+// GetTimeSeries mocks base method.
+func (m *MockStore) GetTimeSeries(arg0 context.Context, arg1 db.GetTimeSeriesParams) ([]db.GetTimeSeriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeSeries", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetTimeSeriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeSeries indicates an expected call of GetTimeSeries.
+func (mr *MockStoreMockRecorder) GetTimeSeries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeSeries", reflect.TypeOf((*MockStore)(nil).GetTimeSeries), arg0, arg1)
+}
+
+// GetTokenStatus mocks base method.
+func (m *MockStore) GetTokenStatus(arg0 context.Context, arg1 db.GetTokenStatusParams) (db.GetTokenStatusRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.GetTokenStatusRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenStatus indicates an expected call of GetTokenStatus.
+func (mr *MockStoreMockRecorder) GetTokenStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenStatus", reflect.TypeOf((*MockStore)(nil).GetTokenStatus), arg0, arg1)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetWallet mocks base method.
+func (m *MockStore) GetWallet(arg0 context.Context, arg1 int64) (db.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", arg0, arg1)
+	ret0, _ := ret[0].(db.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockStoreMockRecorder) GetWallet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockStore)(nil).GetWallet), arg0, arg1)
+}
+
+// GetWalletForUpdate mocks base method.
+func (m *MockStore) GetWalletForUpdate(arg0 context.Context, arg1 int64) (db.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletForUpdate indicates an expected call of GetWalletForUpdate.
+func (mr *MockStoreMockRecorder) GetWalletForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletForUpdate", reflect.TypeOf((*MockStore)(nil).GetWalletForUpdate), arg0, arg1)
+}
+
+// ImportExpensesTx mocks base method.
+func (m *MockStore) ImportExpensesTx(arg0 context.Context, arg1 db.ImportExpensesTxParams) (db.ImportExpensesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportExpensesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportExpensesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportExpensesTx indicates an expected call of ImportExpensesTx.
+func (mr *MockStoreMockRecorder) ImportExpensesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportExpensesTx", reflect.TypeOf((*MockStore)(nil).ImportExpensesTx), arg0, arg1)
+}
+
+// ListActiveSessions mocks base method.
+func (m *MockStore) ListActiveSessions(arg0 context.Context, arg1 string) ([]db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSessions", arg0, arg1)
+	ret0, _ := ret[0].([]db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSessions indicates an expected call of ListActiveSessions.
+func (mr *MockStoreMockRecorder) ListActiveSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSessions", reflect.TypeOf((*MockStore)(nil).ListActiveSessions), arg0, arg1)
+}
+
+// ListBudgets mocks base method.
+func (m *MockStore) ListBudgets(arg0 context.Context, arg1 db.ListBudgetsParams) ([]db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBudgets", arg0, arg1)
+	ret0, _ := ret[0].([]db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBudgets indicates an expected call of ListBudgets.
+func (mr *MockStoreMockRecorder) ListBudgets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBudgets", reflect.TypeOf((*MockStore)(nil).ListBudgets), arg0, arg1)
+}
+
+// ListBudgetsByWallet mocks base method.
+func (m *MockStore) ListBudgetsByWallet(arg0 context.Context, arg1 int64) ([]db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBudgetsByWallet", arg0, arg1)
+	ret0, _ := ret[0].([]db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBudgetsByWallet indicates an expected call of ListBudgetsByWallet.
+func (mr *MockStoreMockRecorder) ListBudgetsByWallet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBudgetsByWallet", reflect.TypeOf((*MockStore)(nil).ListBudgetsByWallet), arg0, arg1)
+}
+
+// ListCategories mocks base method.
+func (m *MockStore) ListCategories(arg0 context.Context, arg1 db.ListCategoriesParams) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockStoreMockRecorder) ListCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0, arg1)
+}
+
+// ListCategorizationRules mocks base method.
+func (m *MockStore) ListCategorizationRules(arg0 context.Context, arg1 string) ([]db.CategorizationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategorizationRules", arg0, arg1)
+	ret0, _ := ret[0].([]db.CategorizationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategorizationRules indicates an expected call of ListCategorizationRules.
+func (mr *MockStoreMockRecorder) ListCategorizationRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategorizationRules", reflect.TypeOf((*MockStore)(nil).ListCategorizationRules), arg0, arg1)
+}
+
+// ListCategoryExpenses mocks base method.
+func (m *MockStore) ListCategoryExpenses(arg0 context.Context, arg1 db.ListCategoryExpensesParams) ([]db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryExpenses", arg0, arg1)
+	ret0, _ := ret[0].([]db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryExpenses indicates an expected call of ListCategoryExpenses.
+func (mr *MockStoreMockRecorder) ListCategoryExpenses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryExpenses", reflect.TypeOf((*MockStore)(nil).ListCategoryExpenses), arg0, arg1)
+}
+
+// ListExchangeRatesForCurrencies mocks base method.
+func (m *MockStore) ListExchangeRatesForCurrencies(arg0 context.Context, arg1 db.ListExchangeRatesForCurrenciesParams) ([]db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExchangeRatesForCurrencies", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExchangeRatesForCurrencies indicates an expected call of ListExchangeRatesForCurrencies.
+func (mr *MockStoreMockRecorder) ListExchangeRatesForCurrencies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchangeRatesForCurrencies", reflect.TypeOf((*MockStore)(nil).ListExchangeRatesForCurrencies), arg0, arg1)
+}
+
+// ListExpenseAttachments mocks base method.
+func (m *MockStore) ListExpenseAttachments(arg0 context.Context, arg1 int64) ([]db.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpenseAttachments", arg0, arg1)
+	ret0, _ := ret[0].([]db.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpenseAttachments indicates an expected call of ListExpenseAttachments.
+func (mr *MockStoreMockRecorder) ListExpenseAttachments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpenseAttachments", reflect.TypeOf((*MockStore)(nil).ListExpenseAttachments), arg0, arg1)
+}
+
+// ListExpenseSplits mocks base method.
+func (m *MockStore) ListExpenseSplits(arg0 context.Context, arg1 int64) ([]db.ExpenseSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpenseSplits", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExpenseSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpenseSplits indicates an expected call of ListExpenseSplits.
+func (mr *MockStoreMockRecorder) ListExpenseSplits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpenseSplits", reflect.TypeOf((*MockStore)(nil).ListExpenseSplits), arg0, arg1)
+}
+
+// ListExpenseTags mocks base method.
+func (m *MockStore) ListExpenseTags(arg0 context.Context, arg1 int64) ([]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpenseTags", arg0, arg1)
+	ret0, _ := ret[0].([]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpenseTags indicates an expected call of ListExpenseTags.
+func (mr *MockStoreMockRecorder) ListExpenseTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpenseTags", reflect.TypeOf((*MockStore)(nil).ListExpenseTags), arg0, arg1)
+}
+
+// ListExpensesByAmountAsc mocks base method.
+func (m *MockStore) ListExpensesByAmountAsc(arg0 context.Context, arg1 db.ListExpensesByAmountAscParams) ([]db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpensesByAmountAsc", arg0, arg1)
+	ret0, _ := ret[0].([]db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpensesByAmountAsc indicates an expected call of ListExpensesByAmountAsc.
+func (mr *MockStoreMockRecorder) ListExpensesByAmountAsc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpensesByAmountAsc", reflect.TypeOf((*MockStore)(nil).ListExpensesByAmountAsc), arg0, arg1)
+}
+
+// ListExpensesByAmountDesc mocks base method.
+func (m *MockStore) ListExpensesByAmountDesc(arg0 context.Context, arg1 db.ListExpensesByAmountDescParams) ([]db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpensesByAmountDesc", arg0, arg1)
+	ret0, _ := ret[0].([]db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpensesByAmountDesc indicates an expected call of ListExpensesByAmountDesc.
+func (mr *MockStoreMockRecorder) ListExpensesByAmountDesc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpensesByAmountDesc", reflect.TypeOf((*MockStore)(nil).ListExpensesByAmountDesc), arg0, arg1)
+}
+
+// ListExpensesByDateAsc mocks base method.
+func (m *MockStore) ListExpensesByDateAsc(arg0 context.Context, arg1 db.ListExpensesByDateAscParams) ([]db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpensesByDateAsc", arg0, arg1)
+	ret0, _ := ret[0].([]db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpensesByDateAsc indicates an expected call of ListExpensesByDateAsc.
+func (mr *MockStoreMockRecorder) ListExpensesByDateAsc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpensesByDateAsc", reflect.TypeOf((*MockStore)(nil).ListExpensesByDateAsc), arg0, arg1)
+}
+
+// ListExpensesByDateDesc mocks base method.
+func (m *MockStore) ListExpensesByDateDesc(arg0 context.Context, arg1 db.ListExpensesByDateDescParams) ([]db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpensesByDateDesc", arg0, arg1)
+	ret0, _ := ret[0].([]db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpensesByDateDesc indicates an expected call of ListExpensesByDateDesc.
+func (mr *MockStoreMockRecorder) ListExpensesByDateDesc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpensesByDateDesc", reflect.TypeOf((*MockStore)(nil).ListExpensesByDateDesc), arg0, arg1)
+}
+
+// ListIncomes mocks base method.
+func (m *MockStore) ListIncomes(arg0 context.Context, arg1 db.ListIncomesParams) ([]db.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIncomes", arg0, arg1)
+	ret0, _ := ret[0].([]db.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIncomes indicates an expected call of ListIncomes.
+func (mr *MockStoreMockRecorder) ListIncomes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIncomes", reflect.TypeOf((*MockStore)(nil).ListIncomes), arg0, arg1)
+}
+
+// ListOwnerBudgets mocks base method.
+func (m *MockStore) ListOwnerBudgets(arg0 context.Context, arg1 string) ([]db.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnerBudgets", arg0, arg1)
+	ret0, _ := ret[0].([]db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwnerBudgets indicates an expected call of ListOwnerBudgets.
+func (mr *MockStoreMockRecorder) ListOwnerBudgets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnerBudgets", reflect.TypeOf((*MockStore)(nil).ListOwnerBudgets), arg0, arg1)
+}
+
+// ListOwnerCategories mocks base method.
+func (m *MockStore) ListOwnerCategories(arg0 context.Context, arg1 string) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnerCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwnerCategories indicates an expected call of ListOwnerCategories.
+func (mr *MockStoreMockRecorder) ListOwnerCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnerCategories", reflect.TypeOf((*MockStore)(nil).ListOwnerCategories), arg0, arg1)
+}
+
+// ListOwnerExpenseSplits mocks base method.
+func (m *MockStore) ListOwnerExpenseSplits(arg0 context.Context, arg1 string) ([]db.ExpenseSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnerExpenseSplits", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExpenseSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwnerExpenseSplits indicates an expected call of ListOwnerExpenseSplits.
+func (mr *MockStoreMockRecorder) ListOwnerExpenseSplits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnerExpenseSplits", reflect.TypeOf((*MockStore)(nil).ListOwnerExpenseSplits), arg0, arg1)
+}
+
+// ListOwnerExpenseTags mocks base method.
+func (m *MockStore) ListOwnerExpenseTags(arg0 context.Context, arg1 string) ([]db.ExpenseTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnerExpenseTags", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExpenseTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwnerExpenseTags indicates an expected call of ListOwnerExpenseTags.
+func (mr *MockStoreMockRecorder) ListOwnerExpenseTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnerExpenseTags", reflect.TypeOf((*MockStore)(nil).ListOwnerExpenseTags), arg0, arg1)
+}
+
+// ListOwnerExpenses mocks base method.
+func (m *MockStore) ListOwnerExpenses(arg0 context.Context, arg1 string) ([]db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnerExpenses", arg0, arg1)
+	ret0, _ := ret[0].([]db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwnerExpenses indicates an expected call of ListOwnerExpenses.
+func (mr *MockStoreMockRecorder) ListOwnerExpenses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnerExpenses", reflect.TypeOf((*MockStore)(nil).ListOwnerExpenses), arg0, arg1)
+}
+
+// ListOwnerIncomes mocks base method.
+func (m *MockStore) ListOwnerIncomes(arg0 context.Context, arg1 string) ([]db.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnerIncomes", arg0, arg1)
+	ret0, _ := ret[0].([]db.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwnerIncomes indicates an expected call of ListOwnerIncomes.
+func (mr *MockStoreMockRecorder) ListOwnerIncomes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnerIncomes", reflect.TypeOf((*MockStore)(nil).ListOwnerIncomes), arg0, arg1)
+}
+
+// ListOwnerTags mocks base method.
+func (m *MockStore) ListOwnerTags(arg0 context.Context, arg1 string) ([]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnerTags", arg0, arg1)
+	ret0, _ := ret[0].([]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwnerTags indicates an expected call of ListOwnerTags.
+func (mr *MockStoreMockRecorder) ListOwnerTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnerTags", reflect.TypeOf((*MockStore)(nil).ListOwnerTags), arg0, arg1)
+}
+
+// ListOwnerTransfers mocks base method.
+func (m *MockStore) ListOwnerTransfers(arg0 context.Context, arg1 string) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnerTransfers", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwnerTransfers indicates an expected call of ListOwnerTransfers.
+func (mr *MockStoreMockRecorder) ListOwnerTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnerTransfers", reflect.TypeOf((*MockStore)(nil).ListOwnerTransfers), arg0, arg1)
+}
+
+// ListOwnerWallets mocks base method.
+func (m *MockStore) ListOwnerWallets(arg0 context.Context, arg1 string) ([]db.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwnerWallets", arg0, arg1)
+	ret0, _ := ret[0].([]db.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOwnerWallets indicates an expected call of ListOwnerWallets.
+func (mr *MockStoreMockRecorder) ListOwnerWallets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwnerWallets", reflect.TypeOf((*MockStore)(nil).ListOwnerWallets), arg0, arg1)
+}
+
+// ListRecurringExpenses mocks base method.
+func (m *MockStore) ListRecurringExpenses(arg0 context.Context, arg1 db.ListRecurringExpensesParams) ([]db.RecurringExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecurringExpenses", arg0, arg1)
+	ret0, _ := ret[0].([]db.RecurringExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecurringExpenses indicates an expected call of ListRecurringExpenses.
+func (mr *MockStoreMockRecorder) ListRecurringExpenses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecurringExpenses", reflect.TypeOf((*MockStore)(nil).ListRecurringExpenses), arg0, arg1)
+}
+
+// ListTags mocks base method.
+func (m *MockStore) ListTags(arg0 context.Context, arg1 string) ([]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", arg0, arg1)
+	ret0, _ := ret[0].([]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockStoreMockRecorder) ListTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockStore)(nil).ListTags), arg0, arg1)
+}
+
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(arg0 context.Context, arg1 db.ListTransfersParams) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateBudget indicates an expected call of CreateBudget.
-func (mr *MockStoreMockRecorder) CreateBudget(arg0, arg1 interface{}) *gomock.Call {
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudget", reflect.TypeOf((*MockStore)(nil).CreateBudget), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
 }
 
-// CreateCategory mocks base method.
-func (m *MockStore) CreateCategory(arg0 context.Context, arg1 db.CreateCategoryParams) (db.Category, error) {
+// ListWalletExpenseExternalIDs mocks base method.
+func (m *MockStore) ListWalletExpenseExternalIDs(arg0 context.Context, arg1 db.ListWalletExpenseExternalIDsParams) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "ListWalletExpenseExternalIDs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCategory indicates an expected call of CreateCategory.
-func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 interface{}) *gomock.Call {
+// ListWalletExpenseExternalIDs indicates an expected call of ListWalletExpenseExternalIDs.
+func (mr *MockStoreMockRecorder) ListWalletExpenseExternalIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWalletExpenseExternalIDs", reflect.TypeOf((*MockStore)(nil).ListWalletExpenseExternalIDs), arg0, arg1)
 }
 
-// CreateExpense mocks base method.
-func (m *MockStore) CreateExpense(arg0 context.Context, arg1 db.CreateExpenseParams) (db.Expense, error) {
+// ListWalletExpensesBetween mocks base method.
+func (m *MockStore) ListWalletExpensesBetween(arg0 context.Context, arg1 db.ListWalletExpensesBetweenParams) ([]db.Expense, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateExpense", arg0, arg1)
-	ret0, _ := ret[0].(db.Expense)
+	ret := m.ctrl.Call(m, "ListWalletExpensesBetween", arg0, arg1)
+	ret0, _ := ret[0].([]db.Expense)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateExpense indicates an expected call of CreateExpense.
-func (mr *MockStoreMockRecorder) CreateExpense(arg0, arg1 interface{}) *gomock.Call {
+// ListWalletExpensesBetween indicates an expected call of ListWalletExpensesBetween.
+func (mr *MockStoreMockRecorder) ListWalletExpensesBetween(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExpense", reflect.TypeOf((*MockStore)(nil).CreateExpense), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWalletExpensesBetween", reflect.TypeOf((*MockStore)(nil).ListWalletExpensesBetween), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// ListWallets mocks base method.
+func (m *MockStore) ListWallets(arg0 context.Context, arg1 db.ListWalletsParams) ([]db.Wallet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ListWallets", arg0, arg1)
+	ret0, _ := ret[0].([]db.Wallet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// ListWallets indicates an expected call of ListWallets.
+func (mr *MockStoreMockRecorder) ListWallets(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallets", reflect.TypeOf((*MockStore)(nil).ListWallets), arg0, arg1)
 }
 
-// CreateWallet mocks base method.
-func (m *MockStore) CreateWallet(arg0 context.Context, arg1 db.CreateWalletParams) (db.Wallet, error) {
+// LogoutAllTx mocks base method.
+func (m *MockStore) LogoutAllTx(arg0 context.Context, arg1 string) (time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateWallet", arg0, arg1)
-	ret0, _ := ret[0].(db.Wallet)
+	ret := m.ctrl.Call(m, "LogoutAllTx", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateWallet indicates an expected call of CreateWallet.
-func (mr *MockStoreMockRecorder) CreateWallet(arg0, arg1 interface{}) *gomock.Call {
+// LogoutAllTx indicates an expected call of LogoutAllTx.
+func (mr *MockStoreMockRecorder) LogoutAllTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockStore)(nil).CreateWallet), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAllTx", reflect.TypeOf((*MockStore)(nil).LogoutAllTx), arg0, arg1)
 }
 
-// DeleteBudget mocks base method.
-func (m *MockStore) DeleteBudget(arg0 context.Context, arg1 int64) error {
+// LogoutTx mocks base method.
+func (m *MockStore) LogoutTx(arg0 context.Context, arg1 db.LogoutTxParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBudget", arg0, arg1)
+	ret := m.ctrl.Call(m, "LogoutTx", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteBudget indicates an expected call of DeleteBudget.
-func (mr *MockStoreMockRecorder) DeleteBudget(arg0, arg1 interface{}) *gomock.Call {
+// LogoutTx indicates an expected call of LogoutTx.
+func (mr *MockStoreMockRecorder) LogoutTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudget", reflect.TypeOf((*MockStore)(nil).DeleteBudget), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutTx", reflect.TypeOf((*MockStore)(nil).LogoutTx), arg0, arg1)
 }
 
-// DeleteCategory mocks base method.
-func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 int64) error {
+// MaterializeRecurringExpenseTx mocks base method.
+func (m *MockStore) MaterializeRecurringExpenseTx(arg0 context.Context, arg1 time.Time) (db.MaterializeRecurringExpenseTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "MaterializeRecurringExpenseTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MaterializeRecurringExpenseTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.Call {
+// MaterializeRecurringExpenseTx indicates an expected call of MaterializeRecurringExpenseTx.
+func (mr *MockStoreMockRecorder) MaterializeRecurringExpenseTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaterializeRecurringExpenseTx", reflect.TypeOf((*MockStore)(nil).MaterializeRecurringExpenseTx), arg0, arg1)
 }
 
-// DeleteExpense mocks base method.
-func (m *MockStore) DeleteExpense(arg0 context.Context, arg1 int64) error {
+// RemoveExpenseTag mocks base method.
+func (m *MockStore) RemoveExpenseTag(arg0 context.Context, arg1 db.RemoveExpenseTagParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteExpense", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RemoveExpenseTag", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteExpense indicates an expected call of DeleteExpense.
-func (mr *MockStoreMockRecorder) DeleteExpense(arg0, arg1 interface{}) *gomock.Call {
+// RemoveExpenseTag indicates an expected call of RemoveExpenseTag.
+func (mr *MockStoreMockRecorder) RemoveExpenseTag(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpense", reflect.TypeOf((*MockStore)(nil).DeleteExpense), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExpenseTag", reflect.TypeOf((*MockStore)(nil).RemoveExpenseTag), arg0, arg1)
 }
 
-// DeleteWallet mocks base method.
-func (m *MockStore) DeleteWallet(arg0 context.Context, arg1 db.DeleteWalletParams) error {
+// RestoreBudget mocks base method.
+func (m *MockStore) RestoreBudget(arg0 context.Context, arg1 db.RestoreBudgetParams) (db.Budget, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteWallet", arg0, arg1)
+	ret := m.ctrl.Call(m, "RestoreBudget", arg0, arg1)
+	ret0, _ := ret[0].(db.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBudget indicates an expected call of RestoreBudget.
+func (mr *MockStoreMockRecorder) RestoreBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBudget", reflect.TypeOf((*MockStore)(nil).RestoreBudget), arg0, arg1)
+}
+
+// RestoreCategory mocks base method.
+func (m *MockStore) RestoreCategory(arg0 context.Context, arg1 db.RestoreCategoryParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCategory indicates an expected call of RestoreCategory.
+func (mr *MockStoreMockRecorder) RestoreCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategory", reflect.TypeOf((*MockStore)(nil).RestoreCategory), arg0, arg1)
+}
+
+// RestoreExpense mocks base method.
+func (m *MockStore) RestoreExpense(arg0 context.Context, arg1 db.RestoreExpenseParams) (db.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreExpense", arg0, arg1)
+	ret0, _ := ret[0].(db.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreExpense indicates an expected call of RestoreExpense.
+func (mr *MockStoreMockRecorder) RestoreExpense(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreExpense", reflect.TypeOf((*MockStore)(nil).RestoreExpense), arg0, arg1)
+}
+
+// RestoreExpenseSplit mocks base method.
+func (m *MockStore) RestoreExpenseSplit(arg0 context.Context, arg1 db.RestoreExpenseSplitParams) (db.ExpenseSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreExpenseSplit", arg0, arg1)
+	ret0, _ := ret[0].(db.ExpenseSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreExpenseSplit indicates an expected call of RestoreExpenseSplit.
+func (mr *MockStoreMockRecorder) RestoreExpenseSplit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreExpenseSplit", reflect.TypeOf((*MockStore)(nil).RestoreExpenseSplit), arg0, arg1)
+}
+
+// RestoreExpenseTag mocks base method.
+func (m *MockStore) RestoreExpenseTag(arg0 context.Context, arg1 db.RestoreExpenseTagParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreExpenseTag", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteWallet indicates an expected call of DeleteWallet.
-func (mr *MockStoreMockRecorder) DeleteWallet(arg0, arg1 interface{}) *gomock.Call {
+// RestoreExpenseTag indicates an expected call of RestoreExpenseTag.
+func (mr *MockStoreMockRecorder) RestoreExpenseTag(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWallet", reflect.TypeOf((*MockStore)(nil).DeleteWallet), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreExpenseTag", reflect.TypeOf((*MockStore)(nil).RestoreExpenseTag), arg0, arg1)
 }
 
-// GetAllCategories mocks base method.
-func (m *MockStore) GetAllCategories(arg0 context.Context, arg1 string) ([]db.Category, error) {
+// RestoreIncome mocks base method.
+func (m *MockStore) RestoreIncome(arg0 context.Context, arg1 db.RestoreIncomeParams) (db.Income, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCategories", arg0, arg1)
-	ret0, _ := ret[0].([]db.Category)
+	ret := m.ctrl.Call(m, "RestoreIncome", arg0, arg1)
+	ret0, _ := ret[0].(db.Income)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllCategories indicates an expected call of GetAllCategories.
-func (mr *MockStoreMockRecorder) GetAllCategories(arg0, arg1 interface{}) *gomock.Call {
+// RestoreIncome indicates an expected call of RestoreIncome.
+func (mr *MockStoreMockRecorder) RestoreIncome(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockStore)(nil).GetAllCategories), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreIncome", reflect.TypeOf((*MockStore)(nil).RestoreIncome), arg0, arg1)
 }
 
-// GetBudgetByID mocks base method.
-func (m *MockStore) GetBudgetByID(arg0 context.Context, arg1 int64) (db.Budget, error) {
+// RestoreTag mocks base method.
+func (m *MockStore) RestoreTag(arg0 context.Context, arg1 db.RestoreTagParams) (db.Tag, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBudgetByID", arg0, arg1)
-	ret0, _ := ret[0].(db.Budget)
+	ret := m.ctrl.Call(m, "RestoreTag", arg0, arg1)
+	ret0, _ := ret[0].(db.Tag)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBudgetByID indicates an expected call of GetBudgetByID.
-func (mr *MockStoreMockRecorder) GetBudgetByID(arg0, arg1 interface{}) *gomock.Call {
+// RestoreTag indicates an expected call of RestoreTag.
+func (mr *MockStoreMockRecorder) RestoreTag(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudgetByID", reflect.TypeOf((*MockStore)(nil).GetBudgetByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTag", reflect.TypeOf((*MockStore)(nil).RestoreTag), arg0, arg1)
 }
 
-// GetCategoryByID mocks base method.
-func (m *MockStore) GetCategoryByID(arg0 context.Context, arg1 int64) (db.Category, error) {
+// RestoreTransfer mocks base method.
+func (m *MockStore) RestoreTransfer(arg0 context.Context, arg1 db.RestoreTransferParams) (db.Transfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategoryByID", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "RestoreTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategoryByID indicates an expected call of GetCategoryByID.
-func (mr *MockStoreMockRecorder) GetCategoryByID(arg0, arg1 interface{}) *gomock.Call {
+// RestoreTransfer indicates an expected call of RestoreTransfer.
+func (mr *MockStoreMockRecorder) RestoreTransfer(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockStore)(nil).GetCategoryByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTransfer", reflect.TypeOf((*MockStore)(nil).RestoreTransfer), arg0, arg1)
 }
 
-// GetExpense mocks base method.
-func (m *MockStore) GetExpense(arg0 context.Context, arg1 int64) (db.Expense, error) {
+// RestoreTx mocks base method.
+func (m *MockStore) RestoreTx(arg0 context.Context, arg1 db.RestoreTxParams) (db.RestoreTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExpense", arg0, arg1)
-	ret0, _ := ret[0].(db.Expense)
+	ret := m.ctrl.Call(m, "RestoreTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RestoreTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetExpense indicates an expected call of GetExpense.
-func (mr *MockStoreMockRecorder) GetExpense(arg0, arg1 interface{}) *gomock.Call {
+// RestoreTx indicates an expected call of RestoreTx.
+func (mr *MockStoreMockRecorder) RestoreTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpense", reflect.TypeOf((*MockStore)(nil).GetExpense), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTx", reflect.TypeOf((*MockStore)(nil).RestoreTx), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+// RestoreWallet mocks base method.
+func (m *MockStore) RestoreWallet(arg0 context.Context, arg1 db.RestoreWalletParams) (db.Wallet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "RestoreWallet", arg0, arg1)
+	ret0, _ := ret[0].(db.Wallet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// RestoreWallet indicates an expected call of RestoreWallet.
+func (mr *MockStoreMockRecorder) RestoreWallet(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreWallet", reflect.TypeOf((*MockStore)(nil).RestoreWallet), arg0, arg1)
 }
 
-// GetWallet mocks base method.
-func (m *MockStore) GetWallet(arg0 context.Context, arg1 int64) (db.Wallet, error) {
+// RevokeToken mocks base method.
+func (m *MockStore) RevokeToken(arg0 context.Context, arg1 db.RevokeTokenParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWallet", arg0, arg1)
-	ret0, _ := ret[0].(db.Wallet)
+	ret := m.ctrl.Call(m, "RevokeToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockStoreMockRecorder) RevokeToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockStore)(nil).RevokeToken), arg0, arg1)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockStore) RevokeUserTokens(arg0 context.Context, arg1 db.RevokeUserTokensParams) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWallet indicates an expected call of GetWallet.
-func (mr *MockStoreMockRecorder) GetWallet(arg0, arg1 interface{}) *gomock.Call {
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockStoreMockRecorder) RevokeUserTokens(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockStore)(nil).GetWallet), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockStore)(nil).RevokeUserTokens), arg0, arg1)
 }
 
-// ListBudgets mocks base method.
-func (m *MockStore) ListBudgets(arg0 context.Context, arg1 db.ListBudgetsParams) ([]db.Budget, error) {
+// SetExpenseCategories mocks base method.
+func (m *MockStore) SetExpenseCategories(arg0 context.Context, arg1 db.SetExpenseCategoriesParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListBudgets", arg0, arg1)
-	ret0, _ := ret[0].([]db.Budget)
+	ret := m.ctrl.Call(m, "SetExpenseCategories", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListBudgets indicates an expected call of ListBudgets.
-func (mr *MockStoreMockRecorder) ListBudgets(arg0, arg1 interface{}) *gomock.Call {
+// SetExpenseCategories indicates an expected call of SetExpenseCategories.
+func (mr *MockStoreMockRecorder) SetExpenseCategories(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBudgets", reflect.TypeOf((*MockStore)(nil).ListBudgets), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpenseCategories", reflect.TypeOf((*MockStore)(nil).SetExpenseCategories), arg0, arg1)
 }
 
-// ListExpenses mocks base method.
-func (m *MockStore) ListExpenses(arg0 context.Context, arg1 db.ListExpensesParams) ([]db.Expense, error) {
+// SetExpenseSplitsTx mocks base method.
+func (m *MockStore) SetExpenseSplitsTx(arg0 context.Context, arg1 db.SetExpenseSplitsTxParams) (db.SetExpenseSplitsTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExpenses", arg0, arg1)
-	ret0, _ := ret[0].([]db.Expense)
+	ret := m.ctrl.Call(m, "SetExpenseSplitsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SetExpenseSplitsTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExpenses indicates an expected call of ListExpenses.
-func (mr *MockStoreMockRecorder) ListExpenses(arg0, arg1 interface{}) *gomock.Call {
+// SetExpenseSplitsTx indicates an expected call of SetExpenseSplitsTx.
+func (mr *MockStoreMockRecorder) SetExpenseSplitsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpenses", reflect.TypeOf((*MockStore)(nil).ListExpenses), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpenseSplitsTx", reflect.TypeOf((*MockStore)(nil).SetExpenseSplitsTx), arg0, arg1)
 }
 
-// ListWallets mocks base method.
-func (m *MockStore) ListWallets(arg0 context.Context, arg1 db.ListWalletsParams) ([]db.Wallet, error) {
+// SetUserDisabled mocks base method.
+func (m *MockStore) SetUserDisabled(arg0 context.Context, arg1 db.SetUserDisabledParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWallets", arg0, arg1)
-	ret0, _ := ret[0].([]db.Wallet)
+	ret := m.ctrl.Call(m, "SetUserDisabled", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListWallets indicates an expected call of ListWallets.
-func (mr *MockStoreMockRecorder) ListWallets(arg0, arg1 interface{}) *gomock.Call {
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockStoreMockRecorder) SetUserDisabled(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallets", reflect.TypeOf((*MockStore)(nil).ListWallets), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockStore)(nil).SetUserDisabled), arg0, arg1)
+}
+
+// SetUserRole mocks base method.
+func (m *MockStore) SetUserRole(arg0 context.Context, arg1 db.SetUserRoleParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockStoreMockRecorder) SetUserRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockStore)(nil).SetUserRole), arg0, arg1)
+}
+
+// TransferTx mocks base method.
+func (m *MockStore) TransferTx(arg0 context.Context, arg1 db.TransferTxParams) (db.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferTx indicates an expected call of TransferTx.
+func (mr *MockStoreMockRecorder) TransferTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), arg0, arg1)
 }
 
 // UpdateBudget mocks base method.
@@ -346,6 +1793,81 @@ func (mr *MockStoreMockRecorder) UpdateExpense(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExpense", reflect.TypeOf((*MockStore)(nil).UpdateExpense), arg0, arg1)
 }
 
+// UpdateExpenseTx mocks base method.
+func (m *MockStore) UpdateExpenseTx(arg0 context.Context, arg1 db.UpdateExpenseParams) (db.ExpenseTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExpenseTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ExpenseTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateExpenseTx indicates an expected call of UpdateExpenseTx.
+func (mr *MockStoreMockRecorder) UpdateExpenseTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExpenseTx", reflect.TypeOf((*MockStore)(nil).UpdateExpenseTx), arg0, arg1)
+}
+
+// UpdateIncome mocks base method.
+func (m *MockStore) UpdateIncome(arg0 context.Context, arg1 db.UpdateIncomeParams) (db.Income, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIncome", arg0, arg1)
+	ret0, _ := ret[0].(db.Income)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIncome indicates an expected call of UpdateIncome.
+func (mr *MockStoreMockRecorder) UpdateIncome(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncome", reflect.TypeOf((*MockStore)(nil).UpdateIncome), arg0, arg1)
+}
+
+// UpdateIncomeTx mocks base method.
+func (m *MockStore) UpdateIncomeTx(arg0 context.Context, arg1 db.UpdateIncomeParams) (db.IncomeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIncomeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.IncomeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIncomeTx indicates an expected call of UpdateIncomeTx.
+func (mr *MockStoreMockRecorder) UpdateIncomeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncomeTx", reflect.TypeOf((*MockStore)(nil).UpdateIncomeTx), arg0, arg1)
+}
+
+// UpdateRecurringExpenseSchedule mocks base method.
+func (m *MockStore) UpdateRecurringExpenseSchedule(arg0 context.Context, arg1 db.UpdateRecurringExpenseScheduleParams) (db.RecurringExpense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurringExpenseSchedule", arg0, arg1)
+	ret0, _ := ret[0].(db.RecurringExpense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecurringExpenseSchedule indicates an expected call of UpdateRecurringExpenseSchedule.
+func (mr *MockStoreMockRecorder) UpdateRecurringExpenseSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurringExpenseSchedule", reflect.TypeOf((*MockStore)(nil).UpdateRecurringExpenseSchedule), arg0, arg1)
+}
+
+// UpdateTag mocks base method.
+func (m *MockStore) UpdateTag(arg0 context.Context, arg1 db.UpdateTagParams) (db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", arg0, arg1)
+	ret0, _ := ret[0].(db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockStoreMockRecorder) UpdateTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockStore)(nil).UpdateTag), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -360,3 +1882,33 @@ func (mr *MockStoreMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0, arg1)
 }
+
+// UpdateWallet mocks base method.
+func (m *MockStore) UpdateWallet(arg0 context.Context, arg1 db.UpdateWalletParams) (db.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWallet", arg0, arg1)
+	ret0, _ := ret[0].(db.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWallet indicates an expected call of UpdateWallet.
+func (mr *MockStoreMockRecorder) UpdateWallet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWallet", reflect.TypeOf((*MockStore)(nil).UpdateWallet), arg0, arg1)
+}
+
+// UpsertExchangeRate mocks base method.
+func (m *MockStore) UpsertExchangeRate(arg0 context.Context, arg1 db.UpsertExchangeRateParams) (db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExchangeRate", arg0, arg1)
+	ret0, _ := ret[0].(db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertExchangeRate indicates an expected call of UpsertExchangeRate.
+func (mr *MockStoreMockRecorder) UpsertExchangeRate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExchangeRate", reflect.TypeOf((*MockStore)(nil).UpsertExchangeRate), arg0, arg1)
+}
@@ -0,0 +1,29 @@
+-- name: CreateAttachment :one
+INSERT INTO attachments (
+  expense_id,
+  blob_key,
+  file_name,
+  content_type,
+  size
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetAttachment :one
+SELECT * FROM attachments
+WHERE id = $1;
+
+-- name: ListExpenseAttachments :many
+SELECT * FROM attachments
+WHERE expense_id = $1
+ORDER BY id;
+
+-- name: DeleteAttachment :exec
+DELETE FROM attachments
+WHERE id = $1;
+
+-- name: DeleteExpenseAttachments :many
+DELETE FROM attachments
+WHERE expense_id = $1
+RETURNING blob_key;
@@ -0,0 +1,160 @@
+-- name: ListOwnerWallets :many
+SELECT * FROM wallets
+WHERE owner = $1
+ORDER BY id;
+
+-- name: ListOwnerCategories :many
+SELECT * FROM categories
+WHERE owner = $1
+ORDER BY id;
+
+-- name: ListOwnerExpenses :many
+SELECT e.* FROM expenses e
+JOIN wallets w ON w.id = e.wallet_id
+WHERE w.owner = $1
+ORDER BY e.id;
+
+-- name: ListOwnerExpenseSplits :many
+SELECT s.* FROM expense_splits s
+JOIN expenses e ON e.id = s.expense_id
+JOIN wallets w ON w.id = e.wallet_id
+WHERE w.owner = $1
+ORDER BY s.id;
+
+-- name: ListOwnerTags :many
+SELECT * FROM tags
+WHERE owner = $1
+ORDER BY id;
+
+-- name: ListOwnerExpenseTags :many
+SELECT et.* FROM expense_tags et
+JOIN tags t ON t.id = et.tag_id
+WHERE t.owner = $1
+ORDER BY et.expense_id, et.tag_id;
+
+-- name: ListOwnerIncomes :many
+SELECT i.* FROM incomes i
+JOIN wallets w ON w.id = i.wallet_id
+WHERE w.owner = $1
+ORDER BY i.id;
+
+-- name: ListOwnerBudgets :many
+SELECT b.* FROM budgets b
+JOIN wallets w ON w.id = b.wallet_id
+WHERE w.owner = $1
+ORDER BY b.id;
+
+-- name: ListOwnerTransfers :many
+SELECT t.* FROM transfers t
+JOIN wallets f ON f.id = t.from_wallet_id
+JOIN wallets w ON w.id = t.to_wallet_id
+WHERE f.owner = $1 AND w.owner = $1
+ORDER BY t.id;
+
+-- name: RestoreWallet :one
+INSERT INTO wallets (
+    owner,
+    name,
+    currency,
+    balance,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: RestoreCategory :one
+-- An existing category with the same name is reused
+INSERT INTO categories (
+    owner,
+    name,
+    created_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (owner, name) DO UPDATE SET name = EXCLUDED.name
+RETURNING *;
+
+-- name: RestoreExpense :one
+INSERT INTO expenses (
+    wallet_id,
+    amount,
+    expense_description,
+    category_id,
+    created_at,
+    external_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: RestoreExpenseSplit :one
+INSERT INTO expense_splits (
+    expense_id,
+    category_id,
+    amount,
+    created_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: RestoreTag :one
+-- An existing tag with the same name is reused
+INSERT INTO tags (
+    owner,
+    name,
+    created_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (owner, name) DO UPDATE SET name = EXCLUDED.name
+RETURNING *;
+
+-- name: RestoreExpenseTag :exec
+INSERT INTO expense_tags (
+    expense_id,
+    tag_id,
+    created_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT DO NOTHING;
+
+-- name: RestoreIncome :one
+INSERT INTO incomes (
+    wallet_id,
+    amount,
+    income_description,
+    category_id,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: RestoreBudget :one
+INSERT INTO budgets (
+    wallet_id,
+    amount,
+    category_id,
+    period,
+    start_date,
+    end_date,
+    recurring,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: RestoreTransfer :one
+INSERT INTO transfers (
+    from_wallet_id,
+    to_wallet_id,
+    amount,
+    created_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
@@ -2,9 +2,13 @@
 INSERT INTO budgets (
   wallet_id,
   category_id,
-  amount
+  amount,
+  period,
+  start_date,
+  end_date,
+  recurring
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7
 )
 RETURNING *;
 
@@ -14,9 +18,16 @@ WHERE id = $1;
 
 
 -- name: UpdateBudget :one
-UPDATE budgets 
-SET amount = $2, category_id = $3 
-WHERE id = $1
+UPDATE budgets
+SET
+    amount = COALESCE(sqlc.narg(amount), amount),
+    category_id = COALESCE(sqlc.narg(category_id), category_id),
+    period = COALESCE(sqlc.narg(period), period),
+    start_date = COALESCE(sqlc.narg(start_date), start_date),
+    end_date = CASE WHEN sqlc.arg(clear_end_date)::boolean THEN NULL ELSE COALESCE(sqlc.narg(end_date), end_date) END,
+    recurring = COALESCE(sqlc.narg(recurring), recurring)
+WHERE
+    id = sqlc.arg(id)
 RETURNING *;
 
 -- name: DeleteBudget :exec
@@ -25,5 +36,36 @@ WHERE id = $1;
 
 -- name: ListBudgets :many
 SELECT * FROM budgets
-LIMIT $1
-OFFSET $2;
+WHERE wallet_id = sqlc.arg(wallet_id)
+  AND (sqlc.narg(cursor_id)::bigint IS NULL
+    OR (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)))
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: ListBudgetsByWallet :many
+SELECT * FROM budgets
+WHERE wallet_id = $1
+ORDER BY id;
+
+-- name: GetBudgetProgress :one
+WITH spent AS (
+  SELECT COALESCE(SUM(e.amount), 0)::bigint AS amount
+  FROM budgets b
+  JOIN expense_allocations e ON e.wallet_id = b.wallet_id AND e.category_id = b.category_id
+  WHERE b.id = sqlc.arg(id)
+    AND e.created_at >= sqlc.arg(period_start)::timestamptz
+    AND e.created_at < sqlc.arg(period_end)::timestamptz
+)
+SELECT
+  b.amount AS planned,
+  s.amount AS spent,
+  (b.amount - s.amount)::bigint AS remaining,
+  (CASE WHEN b.amount = 0 THEN 0
+        ELSE round(s.amount * 100.0 / b.amount, 2)
+   END)::float8 AS percent_used,
+  round(
+    s.amount * extract(epoch FROM sqlc.arg(period_end)::timestamptz - sqlc.arg(period_start)::timestamptz)
+    / greatest(extract(epoch FROM least(sqlc.arg(at)::timestamptz, sqlc.arg(period_end)::timestamptz) - sqlc.arg(period_start)::timestamptz), 1)
+  )::bigint AS projected
+FROM budgets b, spent s
+WHERE b.id = sqlc.arg(id);
@@ -0,0 +1,27 @@
+-- name: CreateCategorizationRule :one
+INSERT INTO categorization_rules (
+  owner,
+  category_id,
+  priority,
+  match_type,
+  pattern,
+  min_amount,
+  max_amount,
+  wallet_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetCategorizationRule :one
+SELECT * FROM categorization_rules
+WHERE id = $1 LIMIT 1;
+
+-- name: ListCategorizationRules :many
+SELECT * FROM categorization_rules
+WHERE owner = $1
+ORDER BY priority DESC, id;
+
+-- name: DeleteCategorizationRule :exec
+DELETE FROM categorization_rules
+WHERE id = $1;
@@ -15,13 +15,32 @@ WHERE id = $1;
 SELECT * FROM categories
 WHERE owner = $1;
 
+-- name: ListCategories :many
+SELECT * FROM categories
+WHERE owner = sqlc.arg(owner)
+  AND (sqlc.narg(cursor_id)::bigint IS NULL
+    OR (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)))
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
 
 -- name: UpdateCategory :one
-UPDATE categories 
-SET name = $2 
-WHERE id = $1
+UPDATE categories
+SET
+    name = COALESCE(sqlc.narg(name), name)
+WHERE
+    id = sqlc.arg(id)
 RETURNING *;
 
 -- name: DeleteCategory :exec
 DELETE FROM categories 
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+-- name: GetOrCreateCategory :one
+INSERT INTO categories (
+  name,
+  owner
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (owner, name) DO UPDATE SET name = EXCLUDED.name
+RETURNING *;
@@ -0,0 +1,25 @@
+-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (
+    base_currency,
+    quote_currency,
+    rate_date,
+    rate,
+    source
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (base_currency, quote_currency, rate_date)
+DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source
+RETURNING *;
+
+-- name: GetExchangeRate :one
+SELECT * FROM exchange_rates
+WHERE base_currency = $1 AND quote_currency = $2 AND rate_date = $3
+LIMIT 1;
+
+-- name: ListExchangeRatesForCurrencies :many
+SELECT * FROM exchange_rates
+WHERE (base_currency = ANY(sqlc.arg(currencies)::varchar[])
+    OR quote_currency = ANY(sqlc.arg(currencies)::varchar[]))
+  AND rate_date BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date)
+ORDER BY rate_date DESC;
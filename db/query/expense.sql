@@ -13,15 +13,91 @@ RETURNING *;
 SELECT * FROM expenses
 WHERE id = $1 LIMIT 1;
 
--- name: ListExpenses :many
+-- name: GetExpenseForUpdate :one
 SELECT * FROM expenses
-LIMIT $1
-OFFSET $2;
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetExpenseForDelete :one
+-- FOR UPDATE also waits for attachments being added to the expense
+SELECT * FROM expenses
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- Every sort order has its own ListExpenses query, so the cursor and the
+-- ORDER BY can use the matching (wallet_id, ..., id) index.
+-- name: ListExpensesByDateDesc :many
+SELECT * FROM expenses
+WHERE wallet_id = sqlc.arg(wallet_id)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time))
+  AND (COALESCE(cardinality(sqlc.arg(category_ids)::bigint[]), 0) = 0 OR category_id = ANY(sqlc.arg(category_ids)::bigint[]))
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+  AND (sqlc.narg(description)::varchar IS NULL OR expense_description ILIKE '%' || sqlc.narg(description) || '%')
+  AND (COALESCE(cardinality(sqlc.arg(tag_ids)::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = expenses.id AND et.tag_id = ANY(sqlc.arg(tag_ids)::bigint[])
+  ))
+  AND (sqlc.narg(cursor_id)::bigint IS NULL OR (created_at, id) < (sqlc.arg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListExpensesByDateAsc :many
+SELECT * FROM expenses
+WHERE wallet_id = sqlc.arg(wallet_id)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time))
+  AND (COALESCE(cardinality(sqlc.arg(category_ids)::bigint[]), 0) = 0 OR category_id = ANY(sqlc.arg(category_ids)::bigint[]))
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+  AND (sqlc.narg(description)::varchar IS NULL OR expense_description ILIKE '%' || sqlc.narg(description) || '%')
+  AND (COALESCE(cardinality(sqlc.arg(tag_ids)::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = expenses.id AND et.tag_id = ANY(sqlc.arg(tag_ids)::bigint[])
+  ))
+  AND (sqlc.narg(cursor_id)::bigint IS NULL OR (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)))
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg('limit');
+
+-- name: ListExpensesByAmountDesc :many
+SELECT * FROM expenses
+WHERE wallet_id = sqlc.arg(wallet_id)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time))
+  AND (COALESCE(cardinality(sqlc.arg(category_ids)::bigint[]), 0) = 0 OR category_id = ANY(sqlc.arg(category_ids)::bigint[]))
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+  AND (sqlc.narg(description)::varchar IS NULL OR expense_description ILIKE '%' || sqlc.narg(description) || '%')
+  AND (COALESCE(cardinality(sqlc.arg(tag_ids)::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = expenses.id AND et.tag_id = ANY(sqlc.arg(tag_ids)::bigint[])
+  ))
+  AND (sqlc.narg(cursor_id)::bigint IS NULL OR (amount, id) < (sqlc.arg(cursor_amount)::bigint, sqlc.narg(cursor_id)))
+ORDER BY amount DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListExpensesByAmountAsc :many
+SELECT * FROM expenses
+WHERE wallet_id = sqlc.arg(wallet_id)
+  AND (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time))
+  AND (COALESCE(cardinality(sqlc.arg(category_ids)::bigint[]), 0) = 0 OR category_id = ANY(sqlc.arg(category_ids)::bigint[]))
+  AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount))
+  AND (sqlc.narg(description)::varchar IS NULL OR expense_description ILIKE '%' || sqlc.narg(description) || '%')
+  AND (COALESCE(cardinality(sqlc.arg(tag_ids)::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = expenses.id AND et.tag_id = ANY(sqlc.arg(tag_ids)::bigint[])
+  ))
+  AND (sqlc.narg(cursor_id)::bigint IS NULL OR (amount, id) > (sqlc.arg(cursor_amount)::bigint, sqlc.narg(cursor_id)))
+ORDER BY amount ASC, id ASC
+LIMIT sqlc.arg('limit');
 
 -- name: UpdateExpense :one
 UPDATE expenses
-SET amount = $2, expense_description = $3, category_id = $4
-WHERE id = $1
+SET
+    amount = COALESCE(sqlc.narg(amount), amount),
+    expense_description = COALESCE(sqlc.narg(expense_description), expense_description),
+    category_id = COALESCE(sqlc.narg(category_id), category_id)
+WHERE
+    id = sqlc.arg(id)
 RETURNING *;
 
 -- name: DeleteExpense :exec
@@ -29,3 +105,58 @@ DELETE FROM expenses
 WHERE id = $1;
 
 
+
+-- name: CreateExpenseOccurrence :one
+INSERT INTO expenses (
+    wallet_id,
+    amount,
+    expense_description,
+    category_id,
+    created_at,
+    recurring_expense_id,
+    occurrence_date
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (recurring_expense_id, occurrence_date) DO NOTHING
+RETURNING *;
+
+-- name: ListWalletExpensesBetween :many
+SELECT * FROM expenses
+WHERE wallet_id = sqlc.arg(wallet_id)
+  AND created_at >= sqlc.arg(from_time)::timestamptz
+  AND created_at < sqlc.arg(to_time)::timestamptz
+ORDER BY created_at, id;
+
+-- name: CreateImportedExpense :one
+INSERT INTO expenses (
+    wallet_id,
+    amount,
+    expense_description,
+    category_id,
+    created_at,
+    external_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (wallet_id, external_id) WHERE external_id IS NOT NULL DO NOTHING
+RETURNING *;
+
+-- name: ListWalletExpenseExternalIDs :many
+SELECT external_id::varchar FROM expenses
+WHERE wallet_id = sqlc.arg(wallet_id)
+  AND external_id = ANY(sqlc.arg(external_ids)::varchar[]);
+
+-- name: ListCategoryExpenses :many
+SELECT * FROM expenses
+WHERE category_id = sqlc.arg(category_id)
+  AND (sqlc.narg(wallet_id)::bigint IS NULL OR wallet_id = sqlc.narg(wallet_id))
+ORDER BY id;
+
+-- name: SetExpenseCategories :execrows
+-- Moves expense ids[i] to category_ids[i]; expenses that left from_category_id
+-- in the meantime are not touched
+UPDATE expenses
+SET category_id = (sqlc.arg(category_ids)::bigint[])[array_position(sqlc.arg(ids)::bigint[], id)]
+WHERE id = ANY(sqlc.arg(ids)::bigint[])
+  AND category_id = sqlc.arg(from_category_id);
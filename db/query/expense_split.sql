@@ -0,0 +1,18 @@
+-- name: CreateExpenseSplit :one
+INSERT INTO expense_splits (
+  expense_id,
+  category_id,
+  amount
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: ListExpenseSplits :many
+SELECT * FROM expense_splits
+WHERE expense_id = $1
+ORDER BY id;
+
+-- name: DeleteExpenseSplits :exec
+DELETE FROM expense_splits
+WHERE expense_id = $1;
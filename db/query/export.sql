@@ -0,0 +1,45 @@
+-- name: ExportTransactions :many
+-- Keyset paged on (created_at, type, id) so an export can be streamed in batches
+SELECT * FROM (
+  SELECT
+    'expense'::varchar AS type,
+    e.id,
+    e.wallet_id,
+    w.name AS wallet_name,
+    w.currency,
+    e.amount,
+    e.expense_description AS description,
+    e.category_id,
+    c.name AS category_name,
+    e.created_at
+  FROM expenses e
+  JOIN wallets w ON w.id = e.wallet_id
+  JOIN categories c ON c.id = e.category_id
+  WHERE w.owner = sqlc.arg(owner)
+    AND (sqlc.narg(wallet_id)::bigint IS NULL OR e.wallet_id = sqlc.narg(wallet_id))
+    AND (sqlc.narg(from_time)::timestamptz IS NULL OR e.created_at >= sqlc.narg(from_time))
+    AND (sqlc.narg(to_time)::timestamptz IS NULL OR e.created_at < sqlc.narg(to_time))
+  UNION ALL
+  SELECT
+    'income'::varchar,
+    i.id,
+    i.wallet_id,
+    w.name,
+    w.currency,
+    i.amount,
+    i.income_description,
+    i.category_id,
+    c.name,
+    i.created_at
+  FROM incomes i
+  JOIN wallets w ON w.id = i.wallet_id
+  JOIN categories c ON c.id = i.category_id
+  WHERE w.owner = sqlc.arg(owner)
+    AND (sqlc.narg(wallet_id)::bigint IS NULL OR i.wallet_id = sqlc.narg(wallet_id))
+    AND (sqlc.narg(from_time)::timestamptz IS NULL OR i.created_at >= sqlc.narg(from_time))
+    AND (sqlc.narg(to_time)::timestamptz IS NULL OR i.created_at < sqlc.narg(to_time))
+) t
+WHERE sqlc.narg(cursor_id)::bigint IS NULL
+  OR (t.created_at, t.type, t.id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_type)::varchar, sqlc.narg(cursor_id))
+ORDER BY t.created_at, t.type, t.id
+LIMIT sqlc.arg('limit');
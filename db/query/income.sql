@@ -0,0 +1,41 @@
+-- name: CreateIncome :one
+INSERT INTO incomes (
+    wallet_id,
+    amount,
+    income_description,
+    category_id
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetIncome :one
+SELECT * FROM incomes
+WHERE id = $1 LIMIT 1;
+
+-- name: GetIncomeForUpdate :one
+SELECT * FROM incomes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListIncomes :many
+SELECT * FROM incomes
+WHERE wallet_id = sqlc.arg(wallet_id)
+  AND (sqlc.narg(cursor_id)::bigint IS NULL
+    OR (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)))
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: UpdateIncome :one
+UPDATE incomes
+SET
+    amount = COALESCE(sqlc.narg(amount), amount),
+    income_description = COALESCE(sqlc.narg(income_description), income_description),
+    category_id = COALESCE(sqlc.narg(category_id), category_id)
+WHERE
+    id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteIncome :exec
+DELETE FROM incomes
+WHERE id = $1;
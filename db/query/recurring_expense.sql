@@ -0,0 +1,46 @@
+-- name: CreateRecurringExpense :one
+INSERT INTO recurring_expenses (
+  wallet_id,
+  category_id,
+  amount,
+  expense_description,
+  frequency,
+  interval,
+  last_day_of_month,
+  start_date,
+  end_date,
+  max_occurrences,
+  next_occurrence
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $8
+)
+RETURNING *;
+
+-- name: GetRecurringExpense :one
+SELECT * FROM recurring_expenses
+WHERE id = $1 LIMIT 1;
+
+-- name: ListRecurringExpenses :many
+SELECT * FROM recurring_expenses
+WHERE wallet_id = sqlc.arg(wallet_id)
+  AND (sqlc.narg(cursor_id)::bigint IS NULL
+    OR (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)))
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: GetDueRecurringExpenseForUpdate :one
+SELECT * FROM recurring_expenses
+WHERE active AND next_occurrence <= sqlc.arg(today)::date
+ORDER BY next_occurrence, id
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: UpdateRecurringExpenseSchedule :one
+UPDATE recurring_expenses
+SET occurrences = $2, next_occurrence = $3, active = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteRecurringExpense :exec
+DELETE FROM recurring_expenses
+WHERE id = $1;
@@ -0,0 +1,66 @@
+-- name: GetMonthlyCategoryReport :many
+-- Split expenses count towards each category they are allocated to. With tag_ids
+-- only expenses carrying at least one of the tags are counted.
+SELECT
+  date_trunc('month', e.created_at AT TIME ZONE 'UTC')::date AS month,
+  w.currency,
+  e.category_id,
+  c.name AS category_name,
+  COUNT(DISTINCT e.expense_id)::bigint AS expense_count,
+  SUM(e.amount)::bigint AS total,
+  round(SUM(e.amount)::numeric / COUNT(DISTINCT e.expense_id), 2)::float8 AS average,
+  (SUM(SUM(e.amount)) OVER (PARTITION BY date_trunc('month', e.created_at AT TIME ZONE 'UTC'), w.currency))::bigint AS month_total,
+  round(
+    SUM(e.amount) * 100.0
+    / SUM(SUM(e.amount)) OVER (PARTITION BY date_trunc('month', e.created_at AT TIME ZONE 'UTC'), w.currency),
+    2
+  )::float8 AS percent_of_total
+FROM expense_allocations e
+JOIN wallets w ON w.id = e.wallet_id
+JOIN categories c ON c.id = e.category_id
+WHERE w.owner = sqlc.arg(owner)
+  AND (COALESCE(cardinality(sqlc.arg(wallet_ids)::bigint[]), 0) = 0 OR e.wallet_id = ANY(sqlc.arg(wallet_ids)::bigint[]))
+  AND e.created_at >= sqlc.arg(from_time)::timestamptz
+  AND e.created_at < sqlc.arg(to_time)::timestamptz
+  AND (COALESCE(cardinality(sqlc.arg(tag_ids)::bigint[]), 0) = 0 OR EXISTS (
+    SELECT 1 FROM expense_tags et WHERE et.expense_id = e.expense_id AND et.tag_id = ANY(sqlc.arg(tag_ids)::bigint[])
+  ))
+GROUP BY 1, 2, 3, 4
+ORDER BY month, w.currency, total DESC, e.category_id;
+
+-- name: GetTimeSeries :many
+-- Filtering by tag_ids leaves out incomes, which cannot be tagged
+WITH entries AS (
+  SELECT e.created_at, e.wallet_id, e.category_id, w.currency, e.amount AS expense, 0::bigint AS income
+  FROM expense_allocations e
+  JOIN wallets w ON w.id = e.wallet_id
+  WHERE w.owner = sqlc.arg(owner)
+    AND (COALESCE(cardinality(sqlc.arg(wallet_ids)::bigint[]), 0) = 0 OR e.wallet_id = ANY(sqlc.arg(wallet_ids)::bigint[]))
+    AND e.created_at >= sqlc.arg(from_time)::timestamptz
+    AND e.created_at < sqlc.arg(to_time)::timestamptz
+    AND (COALESCE(cardinality(sqlc.arg(tag_ids)::bigint[]), 0) = 0 OR EXISTS (
+      SELECT 1 FROM expense_tags et WHERE et.expense_id = e.expense_id AND et.tag_id = ANY(sqlc.arg(tag_ids)::bigint[])
+    ))
+  UNION ALL
+  SELECT i.created_at, i.wallet_id, i.category_id, w.currency, 0::bigint, i.amount
+  FROM incomes i
+  JOIN wallets w ON w.id = i.wallet_id
+  WHERE w.owner = sqlc.arg(owner)
+    AND (COALESCE(cardinality(sqlc.arg(wallet_ids)::bigint[]), 0) = 0 OR i.wallet_id = ANY(sqlc.arg(wallet_ids)::bigint[]))
+    AND i.created_at >= sqlc.arg(from_time)::timestamptz
+    AND i.created_at < sqlc.arg(to_time)::timestamptz
+    AND COALESCE(cardinality(sqlc.arg(tag_ids)::bigint[]), 0) = 0
+)
+SELECT
+  date_trunc(sqlc.arg(bucket)::text, created_at AT TIME ZONE 'UTC')::timestamp AS bucket,
+  (CASE sqlc.arg(group_by)::text
+    WHEN 'category' THEN category_id
+    WHEN 'wallet' THEN wallet_id
+    ELSE 0
+  END)::bigint AS group_id,
+  currency,
+  SUM(expense)::bigint AS expenses,
+  SUM(income)::bigint AS incomes
+FROM entries
+GROUP BY 1, 2, 3
+ORDER BY 1, 2, 3;
@@ -0,0 +1,30 @@
+-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (
+  id,
+  username,
+  expires_at
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: GetTokenStatus :one
+-- Tokens of the user issued before valid_after are no longer accepted;
+-- disabled users have no row
+SELECT
+  EXISTS (SELECT 1 FROM revoked_tokens r WHERE r.id = sqlc.arg(id))::boolean AS revoked,
+  GREATEST(u.password_changed_at, u.tokens_revoked_at)::timestamptz AS valid_after
+FROM users u
+WHERE u.username = sqlc.arg(username) AND u.disabled_at IS NULL;
+
+-- name: RevokeUserTokens :one
+-- revoked_at comes from the application clock, which also sets the issue time
+-- of the tokens it is compared with
+UPDATE users
+SET tokens_revoked_at = sqlc.arg(revoked_at)
+WHERE username = sqlc.arg(username)
+RETURNING tokens_revoked_at;
+
+-- name: DeleteExpiredRevokedTokens :execrows
+DELETE FROM revoked_tokens
+WHERE expires_at < now();
@@ -0,0 +1,46 @@
+-- name: CreateTag :one
+INSERT INTO tags (
+  owner,
+  name
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: GetTag :one
+SELECT * FROM tags
+WHERE id = $1;
+
+-- name: ListTags :many
+SELECT * FROM tags
+WHERE owner = $1
+ORDER BY name;
+
+-- name: UpdateTag :one
+UPDATE tags
+SET name = sqlc.arg(name)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteTag :exec
+DELETE FROM tags
+WHERE id = $1;
+
+-- name: AddExpenseTag :exec
+INSERT INTO expense_tags (
+  expense_id,
+  tag_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveExpenseTag :execrows
+DELETE FROM expense_tags
+WHERE expense_id = $1 AND tag_id = $2;
+
+-- name: ListExpenseTags :many
+SELECT t.* FROM tags t
+JOIN expense_tags et ON et.tag_id = t.id
+WHERE et.expense_id = $1
+ORDER BY t.name;
@@ -0,0 +1,21 @@
+-- name: CreateTransfer :one
+INSERT INTO transfers (
+    from_wallet_id,
+    to_wallet_id,
+    amount
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: GetTransfer :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: ListTransfers :many
+SELECT * FROM transfers
+WHERE (from_wallet_id = sqlc.arg(wallet_id) OR to_wallet_id = sqlc.arg(wallet_id))
+  AND (sqlc.narg(cursor_id)::bigint IS NULL
+    OR (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)))
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
@@ -21,4 +21,43 @@ SET
     password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at)
 WHERE
     username = sqlc.arg(username)
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: ListUsers :many
+SELECT * FROM users
+WHERE sqlc.narg(cursor_username)::varchar IS NULL
+  OR (created_at, username) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.narg(cursor_username))
+ORDER BY created_at, username
+LIMIT sqlc.arg('limit');
+
+-- name: SetUserRole :one
+-- Tokens carry the role, so changing it rejects every token issued before revoked_at
+UPDATE users
+SET
+    role = sqlc.arg(role),
+    tokens_revoked_at = CASE WHEN role <> sqlc.arg(role) THEN sqlc.arg(revoked_at)::timestamptz ELSE tokens_revoked_at END
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: SetUserDisabled :one
+-- Disabling also rejects every token issued before revoked_at
+UPDATE users
+SET
+    disabled_at = CASE WHEN sqlc.arg(disabled)::boolean THEN COALESCE(disabled_at, sqlc.arg(revoked_at)::timestamptz) END,
+    tokens_revoked_at = CASE WHEN sqlc.arg(disabled)::boolean THEN sqlc.arg(revoked_at)::timestamptz ELSE tokens_revoked_at END
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: GetSystemStats :one
+SELECT
+    (SELECT count(*) FROM users)::bigint AS users,
+    (SELECT count(*) FROM users WHERE disabled_at IS NOT NULL)::bigint AS disabled_users,
+    (SELECT count(*) FROM users WHERE role = 'admin')::bigint AS admins,
+    (SELECT count(DISTINCT username) FROM sessions
+        WHERE NOT is_blocked AND expires_at > now())::bigint AS users_with_sessions,
+    (SELECT count(*) FROM wallets)::bigint AS wallets,
+    (SELECT count(*) FROM expenses)::bigint AS expenses,
+    (SELECT count(*) FROM incomes)::bigint AS incomes,
+    (SELECT count(*) FROM transfers)::bigint AS transfers,
+    (SELECT count(*) FROM attachments)::bigint AS attachments,
+    (SELECT COALESCE(sum(size), 0) FROM attachments)::bigint AS attachment_bytes;
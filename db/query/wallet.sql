@@ -3,9 +3,10 @@
 INSERT INTO wallets (
     name,
     owner,
-    currency
+    currency,
+    balance
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 )
 RETURNING *;
 
@@ -13,16 +14,33 @@ RETURNING *;
 SELECT * FROM wallets
 WHERE id = $1 LIMIT 1;
 
+-- name: GetWalletForUpdate :one
+SELECT * FROM wallets
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListWallets :many
 SELECT * FROM wallets
-WHERE owner = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3;
+WHERE owner = sqlc.arg(owner)
+  AND (sqlc.narg(cursor_id)::bigint IS NULL
+    OR (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)))
+ORDER BY created_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: UpdateWallet :one
+UPDATE wallets
+SET
+    name = COALESCE(sqlc.narg(name), name)
+WHERE
+    id = sqlc.arg(id)
+RETURNING *;
 
 -- name: DeleteWallet :exec
 DELETE FROM wallets
 WHERE id = $1 AND owner = $2;
 
-
-
+-- name: AddWalletBalance :one
+UPDATE wallets
+SET balance = balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,177 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	db "github.com/symyzi/financial-helper/db/gen"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	// DefaultMaxRateAge is how far back the converter looks for a rate
+	// when none was published on the requested date (weekends, holidays)
+	DefaultMaxRateAge = 7 * 24 * time.Hour
+)
+
+var (
+	ErrRateNotFound    = errors.New("exchange rate not found")
+	ErrInvalidCurrency = errors.New("invalid currency")
+	ErrAmountOverflow  = errors.New("converted amount overflows")
+)
+
+// RateStore is the subset of db.Store the converter reads rates from
+type RateStore interface {
+	ListExchangeRatesForCurrencies(ctx context.Context, arg db.ListExchangeRatesForCurrenciesParams) ([]db.ExchangeRate, error)
+}
+
+// Converter converts amounts between currencies using historical daily rates.
+// A rate can be used directly, inverted, or crossed through a common currency.
+type Converter struct {
+	store      RateStore
+	maxRateAge time.Duration
+}
+
+func NewConverter(store RateStore) *Converter {
+	return &Converter{
+		store:      store,
+		maxRateAge: DefaultMaxRateAge,
+	}
+}
+
+// WithMaxRateAge returns a copy of the converter accepting rates up to age old
+func (converter *Converter) WithMaxRateAge(age time.Duration) *Converter {
+	c := *converter
+	c.maxRateAge = age
+	return &c
+}
+
+// Rate returns how many units of to one unit of from is worth on date
+func (converter *Converter) Rate(ctx context.Context, from, to string, date time.Time) (*big.Rat, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	if from == "" || to == "" {
+		return nil, ErrInvalidCurrency
+	}
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	day := truncateDay(date)
+	rows, err := converter.store.ListExchangeRatesForCurrencies(ctx, db.ListExchangeRatesForCurrenciesParams{
+		Currencies: []string{from, to},
+		FromDate:   day.Add(-converter.maxRateAge),
+		ToDate:     day,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot load exchange rates: %w", err)
+	}
+
+	graph, err := newRateGraph(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if rate, ok := graph.rate(from, to); ok {
+		return rate, nil
+	}
+
+	pivots := make([]string, 0, len(graph[from]))
+	for pivot := range graph[from] {
+		pivots = append(pivots, pivot)
+	}
+	sort.Strings(pivots)
+
+	for _, pivot := range pivots {
+		first, _ := graph.rate(from, pivot)
+		second, ok := graph.rate(pivot, to)
+		if ok {
+			return new(big.Rat).Mul(first, second), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s on %s", ErrRateNotFound, from, to, day.Format(dateLayout))
+}
+
+// Convert converts amount in minor units of from into minor units of to,
+// rounding half away from zero
+func (converter *Converter) Convert(ctx context.Context, amount int64, from, to string, date time.Time) (int64, error) {
+	rate, err := converter.Rate(ctx, from, to, date)
+	if err != nil {
+		return 0, err
+	}
+
+	value := new(big.Rat).Mul(big.NewRat(amount, 1), rate)
+	converted, ok := roundRat(value)
+	if !ok {
+		return 0, fmt.Errorf("%w: %d %s to %s", ErrAmountOverflow, amount, from, to)
+	}
+	return converted, nil
+}
+
+// rateGraph keeps the most recent rate for every currency pair in both directions
+type rateGraph map[string]map[string]*big.Rat
+
+func newRateGraph(rows []db.ExchangeRate) (rateGraph, error) {
+	graph := rateGraph{}
+	// rows are ordered by date descending, so the first rate seen for a pair wins
+	for _, row := range rows {
+		if _, ok := graph.rate(row.BaseCurrency, row.QuoteCurrency); ok {
+			continue
+		}
+
+		rate, ok := new(big.Rat).SetString(row.Rate)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %q for %s/%s", row.Rate, row.BaseCurrency, row.QuoteCurrency)
+		}
+
+		graph.add(row.BaseCurrency, row.QuoteCurrency, rate)
+		graph.add(row.QuoteCurrency, row.BaseCurrency, new(big.Rat).Inv(rate))
+	}
+	return graph, nil
+}
+
+func (graph rateGraph) add(from, to string, rate *big.Rat) {
+	if graph[from] == nil {
+		graph[from] = map[string]*big.Rat{}
+	}
+	graph[from][to] = rate
+}
+
+func (graph rateGraph) rate(from, to string) (*big.Rat, bool) {
+	rate, ok := graph[from][to]
+	return rate, ok
+}
+
+// roundRat rounds value half away from zero; it reports false when the result
+// does not fit in int64
+func roundRat(value *big.Rat) (int64, bool) {
+	num := new(big.Int).Set(value.Num())
+	den := value.Denom()
+
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(den) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if negative {
+		quo.Neg(quo)
+	}
+	if !quo.IsInt64() {
+		return 0, false
+	}
+	return quo.Int64(), true
+}
+
+func truncateDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
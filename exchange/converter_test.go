@@ -0,0 +1,160 @@
+package exchange
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+)
+
+func TestConvert(t *testing.T) {
+	date := time.Date(2024, 3, 4, 15, 30, 0, 0, time.UTC)
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	rates := []db.ExchangeRate{
+		{BaseCurrency: "USD", QuoteCurrency: "RUB", RateDate: day, Rate: "90.000000000000"},
+		{BaseCurrency: "EUR", QuoteCurrency: "RUB", RateDate: day, Rate: "100.000000000000"},
+		{BaseCurrency: "USD", QuoteCurrency: "RUB", RateDate: day.AddDate(0, 0, -1), Rate: "80.000000000000"},
+	}
+
+	testCases := []struct {
+		name        string
+		amount      int64
+		from        string
+		to          string
+		rates       []db.ExchangeRate
+		storeErr    error
+		expected    int64
+		expectedErr error
+	}{
+		{
+			name:     "SameCurrency",
+			amount:   1234,
+			from:     "RUB",
+			to:       "RUB",
+			expected: 1234,
+		},
+		{
+			name:     "Direct",
+			amount:   1000,
+			from:     "USD",
+			to:       "RUB",
+			rates:    rates,
+			expected: 90000,
+		},
+		{
+			name:     "Inverse",
+			amount:   100,
+			from:     "RUB",
+			to:       "USD",
+			rates:    rates,
+			expected: 1,
+		},
+		{
+			name:     "Cross",
+			amount:   900,
+			from:     "usd",
+			to:       "eur",
+			rates:    rates,
+			expected: 810,
+		},
+		{
+			name:        "Missing",
+			amount:      100,
+			from:        "USD",
+			to:          "CNY",
+			rates:       rates,
+			expectedErr: ErrRateNotFound,
+		},
+		{
+			name:        "Overflow",
+			amount:      math.MaxInt64 / 10,
+			from:        "USD",
+			to:          "RUB",
+			rates:       rates,
+			expectedErr: ErrAmountOverflow,
+		},
+		{
+			name:        "StoreError",
+			amount:      100,
+			from:        "USD",
+			to:          "RUB",
+			storeErr:    sql.ErrConnDone,
+			expectedErr: sql.ErrConnDone,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ListExchangeRatesForCurrencies(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(tc.rates, tc.storeErr)
+
+			converter := NewConverter(store)
+			amount, err := converter.Convert(context.Background(), tc.amount, tc.from, tc.to, date)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, amount)
+		})
+	}
+}
+
+func TestConvertLookupWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	date := time.Date(2024, 3, 4, 23, 59, 0, 0, time.UTC)
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListExchangeRatesForCurrencies(gomock.Any(), gomock.Eq(db.ListExchangeRatesForCurrenciesParams{
+			Currencies: []string{"USD", "RUB"},
+			FromDate:   day.Add(-time.Hour * 48),
+			ToDate:     day,
+		})).
+		Times(1).
+		Return([]db.ExchangeRate{}, nil)
+
+	_, err := NewConverter(store).WithMaxRateAge(48*time.Hour).Convert(context.Background(), 1, "USD", "RUB", date)
+	require.ErrorIs(t, err, ErrRateNotFound)
+	require.ErrorContains(t, err, "USD/RUB on 2024-03-04")
+}
+
+func TestRoundRat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListExchangeRatesForCurrencies(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return([]db.ExchangeRate{{BaseCurrency: "USD", QuoteCurrency: "RUB", RateDate: day, Rate: "1.5"}}, nil)
+
+	converter := NewConverter(store)
+
+	amount, err := converter.Convert(context.Background(), 3, "USD", "RUB", day)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), amount)
+
+	amount, err = converter.Convert(context.Background(), -3, "USD", "RUB", day)
+	require.NoError(t, err)
+	require.Equal(t, int64(-5), amount)
+}
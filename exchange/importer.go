@@ -0,0 +1,253 @@
+package exchange
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	db "github.com/symyzi/financial-helper/db/gen"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	FormatCBR = "cbr"
+	FormatECB = "ecb"
+	FormatCSV = "csv"
+
+	cbrDateLayout = "02.01.2006"
+	rateScale     = 12
+)
+
+var ErrUnknownFormat = errors.New("unknown exchange rate file format")
+
+// Rate is a single daily exchange rate: one unit of Base costs Rate units of Quote
+type Rate struct {
+	Base   string
+	Quote  string
+	Date   time.Time
+	Rate   *big.Rat
+	Source string
+}
+
+// RateWriter is the subset of db.Store used to save imported rates
+type RateWriter interface {
+	UpsertExchangeRate(ctx context.Context, arg db.UpsertExchangeRateParams) (db.ExchangeRate, error)
+}
+
+// Import saves rates, replacing any rate already stored for the same pair and date
+func Import(ctx context.Context, store RateWriter, rates []Rate) (int, error) {
+	for i, rate := range rates {
+		_, err := store.UpsertExchangeRate(ctx, db.UpsertExchangeRateParams{
+			BaseCurrency:  rate.Base,
+			QuoteCurrency: rate.Quote,
+			RateDate:      rate.Date,
+			Rate:          rate.Rate.FloatString(rateScale),
+			Source:        rate.Source,
+		})
+		if err != nil {
+			return i, fmt.Errorf("cannot save %s/%s rate for %s: %w", rate.Base, rate.Quote, rate.Date.Format(dateLayout), err)
+		}
+	}
+	return len(rates), nil
+}
+
+// ParseFile reads rates from a local file. An empty format is guessed from the file name.
+func ParseFile(path string, format string) ([]Rate, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if format == "" {
+		format = guessFormat(path)
+	}
+	return Parse(file, format)
+}
+
+// Parse reads rates in the given format from r
+func Parse(r io.Reader, format string) ([]Rate, error) {
+	switch strings.ToLower(format) {
+	case FormatCBR:
+		return ParseCBR(r)
+	case FormatECB:
+		return ParseECB(r)
+	case FormatCSV:
+		return ParseCSV(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+func guessFormat(path string) string {
+	name := strings.ToLower(filepath.Base(path))
+	switch {
+	case strings.HasSuffix(name, ".csv"):
+		return FormatCSV
+	case strings.Contains(name, "eurofxref") || strings.Contains(name, "ecb"):
+		return FormatECB
+	default:
+		return FormatCBR
+	}
+}
+
+type cbrValCurs struct {
+	Date    string `xml:"Date,attr"`
+	Valutes []struct {
+		CharCode string `xml:"CharCode"`
+		Nominal  string `xml:"Nominal"`
+		Value    string `xml:"Value"`
+	} `xml:"Valute"`
+}
+
+// ParseCBR reads the Bank of Russia daily XML (XML_daily.asp), where every
+// Valute holds the price in RUB of Nominal units of the currency
+func ParseCBR(r io.Reader) ([]Rate, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	var doc cbrValCurs
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("cannot decode CBR XML: %w", err)
+	}
+
+	date, err := time.Parse(cbrDateLayout, doc.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CBR date %q: %w", doc.Date, err)
+	}
+
+	rates := make([]Rate, 0, len(doc.Valutes))
+	for _, valute := range doc.Valutes {
+		value, err := parseDecimal(valute.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CBR value for %s: %w", valute.CharCode, err)
+		}
+		nominal, err := parseDecimal(valute.Nominal)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CBR nominal for %s: %w", valute.CharCode, err)
+		}
+
+		rates = append(rates, Rate{
+			Base:   strings.ToUpper(strings.TrimSpace(valute.CharCode)),
+			Quote:  "RUB",
+			Date:   date,
+			Rate:   new(big.Rat).Quo(value, nominal),
+			Source: FormatCBR,
+		})
+	}
+	return rates, nil
+}
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ParseECB reads the European Central Bank reference rate XML (eurofxref-daily
+// or eurofxref-hist), where rates are quoted in units of currency per one EUR
+func ParseECB(r io.Reader) ([]Rate, error) {
+	var doc ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("cannot decode ECB XML: %w", err)
+	}
+
+	var rates []Rate
+	for _, day := range doc.Days {
+		date, err := time.Parse(dateLayout, day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECB date %q: %w", day.Time, err)
+		}
+
+		for _, cube := range day.Rates {
+			rate, err := parseDecimal(cube.Rate)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ECB rate for %s: %w", cube.Currency, err)
+			}
+
+			rates = append(rates, Rate{
+				Base:   "EUR",
+				Quote:  strings.ToUpper(cube.Currency),
+				Date:   date,
+				Rate:   rate,
+				Source: FormatECB,
+			})
+		}
+	}
+	return rates, nil
+}
+
+// ParseCSV reads rates from a CSV file with a date,base,quote,rate header
+func ParseCSV(r io.Reader) ([]Rate, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read CSV header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"date", "base", "quote", "rate"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header is missing the %q column", name)
+		}
+	}
+
+	var rates []Rate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		date, err := time.Parse(dateLayout, record[columns["date"]])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date: %w", line, err)
+		}
+		rate, err := parseDecimal(record[columns["rate"]])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rate: %w", line, err)
+		}
+
+		rates = append(rates, Rate{
+			Base:   strings.ToUpper(record[columns["base"]]),
+			Quote:  strings.ToUpper(record[columns["quote"]]),
+			Date:   date,
+			Rate:   rate,
+			Source: FormatCSV,
+		})
+	}
+	return rates, nil
+}
+
+// parseDecimal parses a positive decimal written with either a dot or a comma
+func parseDecimal(s string) (*big.Rat, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", ".")
+	value, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	if value.Sign() <= 0 {
+		return nil, fmt.Errorf("number must be positive: %q", s)
+	}
+	return value, nil
+}
@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const cbrDaily = `<?xml version="1.0" encoding="windows-1251"?>
+<ValCurs Date="02.03.2024" name="Foreign Currency Market">
+<Valute ID="R01235"><NumCode>840</NumCode><CharCode>USD</CharCode><Nominal>1</Nominal><Name>Доллар США</Name><Value>91,6012</Value></Valute>
+<Valute ID="R01375"><NumCode>156</NumCode><CharCode>CNY</CharCode><Nominal>10</Nominal><Name>Юань</Name><Value>126,4810</Value></Valute>
+</ValCurs>`
+
+const ecbDaily = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-03-01">
+			<Cube currency="USD" rate="1.0838"/>
+			<Cube currency="JPY" rate="162.51"/>
+		</Cube>
+		<Cube time="2024-02-29">
+			<Cube currency="USD" rate="1.0813"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestParseCBR(t *testing.T) {
+	encoded, err := charmap.Windows1251.NewEncoder().String(cbrDaily)
+	require.NoError(t, err)
+
+	rates, err := ParseCBR(bytes.NewReader([]byte(encoded)))
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	date := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, "USD", rates[0].Base)
+	require.Equal(t, "RUB", rates[0].Quote)
+	require.Equal(t, date, rates[0].Date)
+	require.Equal(t, "91.6012", rates[0].Rate.FloatString(4))
+
+	require.Equal(t, "CNY", rates[1].Base)
+	require.Equal(t, "12.64810", rates[1].Rate.FloatString(5))
+}
+
+func TestParseECB(t *testing.T) {
+	rates, err := ParseECB(strings.NewReader(ecbDaily))
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+
+	require.Equal(t, "EUR", rates[0].Base)
+	require.Equal(t, "USD", rates[0].Quote)
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), rates[0].Date)
+	require.Equal(t, "1.0838", rates[0].Rate.FloatString(4))
+
+	require.Equal(t, "JPY", rates[1].Quote)
+	require.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), rates[2].Date)
+}
+
+func TestParseCSV(t *testing.T) {
+	data := "date,base,quote,rate\n2024-03-01,usd,rub,\"91,5\"\n2024-03-02,EUR,RUB,99.1\n"
+
+	rates, err := ParseCSV(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	require.Equal(t, "USD", rates[0].Base)
+	require.Equal(t, "RUB", rates[0].Quote)
+	require.Equal(t, "91.5", rates[0].Rate.FloatString(1))
+
+	_, err = ParseCSV(strings.NewReader("date,base,rate\n"))
+	require.Error(t, err)
+
+	_, err = ParseCSV(strings.NewReader("date,base,quote,rate\n2024-03-01,USD,RUB,-1\n"))
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestParseUnknownFormat(t *testing.T) {
+	_, err := Parse(strings.NewReader(""), "xlsx")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,102 @@
+package exporter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	db "github.com/symyzi/financial-helper/db/gen"
+)
+
+// Supported export formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// BatchSize is the number of rows read from the database at a time
+const BatchSize = 500
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Store is the subset of db.Store the exporter reads transactions from
+type Store interface {
+	ExportTransactions(ctx context.Context, arg db.ExportTransactionsParams) ([]db.ExportTransactionsRow, error)
+}
+
+// Writer encodes exported rows. Flush is called after every batch.
+type Writer interface {
+	Write(row db.ExportTransactionsRow) error
+	Flush() error
+}
+
+// NewWriter returns a Writer for the given format
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatJSONL:
+		return newJSONLWriter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// Filter selects the transactions to export. An invalid WalletID exports every
+// wallet of the owner; the time range is half-open.
+type Filter struct {
+	Owner    string
+	WalletID sql.NullInt64
+	FromTime sql.NullTime
+	ToTime   sql.NullTime
+}
+
+// Exporter streams expenses and incomes oldest first, holding at most one batch in memory
+type Exporter struct {
+	store     Store
+	batchSize int32
+}
+
+func New(store Store) *Exporter {
+	return &Exporter{store: store, batchSize: BatchSize}
+}
+
+// Export writes every transaction matching the filter and returns the number of rows
+// written. Nothing is written when the first batch cannot be loaded.
+func (exporter *Exporter) Export(ctx context.Context, w Writer, filter Filter) (int, error) {
+	arg := db.ExportTransactionsParams{
+		Owner:    filter.Owner,
+		WalletID: filter.WalletID,
+		FromTime: filter.FromTime,
+		ToTime:   filter.ToTime,
+		Limit:    exporter.batchSize,
+	}
+
+	count := 0
+	for {
+		rows, err := exporter.store.ExportTransactions(ctx, arg)
+		if err != nil {
+			return count, fmt.Errorf("cannot load transactions: %w", err)
+		}
+		for _, row := range rows {
+			if err := w.Write(row); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if err := w.Flush(); err != nil {
+			return count, err
+		}
+		if len(rows) < int(exporter.batchSize) {
+			return count, nil
+		}
+
+		last := rows[len(rows)-1]
+		arg.CursorID = sql.NullInt64{Int64: last.ID, Valid: true}
+		arg.CursorCreatedAt = last.CreatedAt
+		arg.CursorType = last.Type
+	}
+}
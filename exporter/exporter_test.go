@@ -0,0 +1,131 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+)
+
+func testRows() []db.ExportTransactionsRow {
+	day := time.Date(2024, time.March, 1, 9, 30, 0, 0, time.UTC)
+	return []db.ExportTransactionsRow{
+		{Type: "expense", ID: 1, WalletID: 1, WalletName: "Cash", Currency: "USD", Amount: 500, Description: "Coffee, large", CategoryID: 2, CategoryName: "Food", CreatedAt: day},
+		{Type: "income", ID: 1, WalletID: 1, WalletName: "Cash", Currency: "USD", Amount: 10000, Description: "Salary", CategoryID: 3, CategoryName: "Work", CreatedAt: day},
+		{Type: "expense", ID: 4, WalletID: 1, WalletName: "Cash", Currency: "USD", Amount: 1250, Description: "Taxi", CategoryID: 2, CategoryName: "Food", CreatedAt: day.AddDate(0, 0, 1)},
+	}
+}
+
+func TestExportBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rows := testRows()
+	filter := Filter{Owner: "owner", WalletID: sql.NullInt64{Int64: 1, Valid: true}}
+
+	store := mockdb.NewMockStore(ctrl)
+	first := db.ExportTransactionsParams{Owner: "owner", WalletID: filter.WalletID, Limit: 2}
+	second := first
+	second.CursorID = sql.NullInt64{Int64: rows[1].ID, Valid: true}
+	second.CursorCreatedAt = rows[1].CreatedAt
+	second.CursorType = rows[1].Type
+	gomock.InOrder(
+		store.EXPECT().ExportTransactions(gomock.Any(), gomock.Eq(first)).Times(1).Return(rows[:2], nil),
+		store.EXPECT().ExportTransactions(gomock.Any(), gomock.Eq(second)).Times(1).Return(rows[2:], nil),
+	)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatCSV)
+	require.NoError(t, err)
+
+	exporter := New(store)
+	exporter.batchSize = 2
+	count, err := exporter.Export(context.Background(), w, filter)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, []string{
+		"date,type,wallet_id,wallet,currency,amount,description,category_id,category",
+		`2024-03-01T09:30:00Z,expense,1,Cash,USD,500,"Coffee, large",2,Food`,
+		"2024-03-01T09:30:00Z,income,1,Cash,USD,10000,Salary,3,Work",
+		"2024-03-02T09:30:00Z,expense,1,Cash,USD,1250,Taxi,2,Food",
+	}, lines)
+}
+
+func TestExportJSONL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ExportTransactions(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(testRows(), nil)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatJSONL)
+	require.NoError(t, err)
+
+	count, err := New(store).Export(context.Background(), w, Filter{Owner: "owner"})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	var row db.ExportTransactionsRow
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &row))
+	require.Equal(t, testRows()[2], row)
+}
+
+func TestExportEmpty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ExportTransactions(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ExportTransactionsRow{}, nil)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatCSV)
+	require.NoError(t, err)
+
+	count, err := New(store).Export(context.Background(), w, Filter{Owner: "owner"})
+	require.NoError(t, err)
+	require.Zero(t, count)
+	require.Equal(t, strings.Join(csvHeader, ",")+"\n", buf.String())
+}
+
+func TestExportStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ExportTransactions(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil, sql.ErrConnDone)
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatCSV)
+	require.NoError(t, err)
+
+	_, err = New(store).Export(context.Background(), w, Filter{Owner: "owner"})
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.Empty(t, buf.String())
+}
+
+func TestNewWriterUnknownFormat(t *testing.T) {
+	_, err := NewWriter(&bytes.Buffer{}, "xml")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,78 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	db "github.com/symyzi/financial-helper/db/gen"
+)
+
+var csvHeader = []string{
+	"date", "type", "wallet_id", "wallet", "currency", "amount", "description", "category_id", "category",
+}
+
+type csvWriter struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	return w.writer.Write(csvHeader)
+}
+
+func (w *csvWriter) Write(row db.ExportTransactionsRow) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.writer.Write([]string{
+		row.CreatedAt.UTC().Format(time.RFC3339),
+		row.Type,
+		strconv.FormatInt(row.WalletID, 10),
+		row.WalletName,
+		row.Currency,
+		strconv.FormatInt(row.Amount, 10),
+		row.Description,
+		strconv.FormatInt(row.CategoryID, 10),
+		row.CategoryName,
+	})
+}
+
+// Flush also writes the header, so an empty export is still a valid CSV file
+func (w *csvWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type jsonlWriter struct {
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	buf := bufio.NewWriter(w)
+	return &jsonlWriter{buf: buf, encoder: json.NewEncoder(buf)}
+}
+
+// Write encodes the row as a single line; json.Encoder terminates each value with a newline
+func (w *jsonlWriter) Write(row db.ExportTransactionsRow) error {
+	return w.encoder.Encode(row)
+}
+
+func (w *jsonlWriter) Flush() error {
+	return w.buf.Flush()
+}
@@ -0,0 +1,283 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Sign conventions for the amount column
+const (
+	// SignNegative means expenses are negative amounts and positive rows are credits
+	SignNegative = "negative"
+	// SignPositive means expenses are positive amounts and negative rows are credits
+	SignPositive = "positive"
+)
+
+// Row statuses
+const (
+	StatusNew       = "new"
+	StatusDuplicate = "duplicate"
+	StatusSkipped   = "skipped"
+	StatusInvalid   = "invalid"
+)
+
+const (
+	defaultDateFormat = "2006-01-02"
+
+	// MaxRows bounds the number of data rows a single statement may contain
+	MaxRows = 10000
+)
+
+var ErrTooManyRows = fmt.Errorf("statement has more than %d rows", MaxRows)
+
+// Mapping describes how to read a bank statement. Columns are given either by
+// their header name (case-insensitive) or by their 1-based position.
+type Mapping struct {
+	DateColumn        string
+	AmountColumn      string
+	DescriptionColumn string
+	CategoryColumn    string
+	// DateFormat is a Go layout or a pattern such as DD.MM.YYYY
+	DateFormat string
+	// DecimalSeparator is "." or ","; the other one is treated as a thousands separator
+	DecimalSeparator string
+	Sign             string
+	Delimiter        string
+	// NoHeader is set when the first line already holds data
+	NoHeader bool
+}
+
+// Validate fills in the defaults and checks the mapping
+func (mapping *Mapping) Validate() error {
+	if mapping.DateColumn == "" || mapping.AmountColumn == "" {
+		return errors.New("date and amount columns are required")
+	}
+	if mapping.DateFormat == "" {
+		mapping.DateFormat = defaultDateFormat
+	}
+	if mapping.DecimalSeparator == "" {
+		mapping.DecimalSeparator = "."
+	}
+	if mapping.DecimalSeparator != "." && mapping.DecimalSeparator != "," {
+		return fmt.Errorf("invalid decimal separator %q", mapping.DecimalSeparator)
+	}
+	if mapping.Sign == "" {
+		mapping.Sign = SignNegative
+	}
+	if mapping.Sign != SignNegative && mapping.Sign != SignPositive {
+		return fmt.Errorf("invalid sign convention %q", mapping.Sign)
+	}
+	if mapping.Delimiter == "" {
+		mapping.Delimiter = ","
+	}
+	if utf8.RuneCountInString(mapping.Delimiter) != 1 {
+		return fmt.Errorf("invalid delimiter %q", mapping.Delimiter)
+	}
+	return nil
+}
+
+// Row is one parsed statement line. Amount is positive, in minor units.
+type Row struct {
+	Line        int       `json:"line"`
+	Date        time.Time `json:"date"`
+	Amount      int64     `json:"amount"`
+	Description string    `json:"description"`
+	Category    string    `json:"category,omitempty"`
+	CategoryID  int64     `json:"category_id,omitempty"`
+	ExternalID  string    `json:"external_id,omitempty"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+}
+
+func (row *Row) invalidate(format string, args ...any) {
+	row.Status = StatusInvalid
+	row.Error = fmt.Sprintf(format, args...)
+}
+
+// ParseCSV reads a statement with the given mapping. Lines that cannot be parsed
+// are returned as invalid rows, credits as skipped rows; an error is only returned
+// when the file itself cannot be read.
+func ParseCSV(r io.Reader, mapping Mapping) ([]Row, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma, _ = utf8.DecodeRuneInString(mapping.Delimiter)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var header []string
+	line := 1
+	if !mapping.NoHeader {
+		record, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read CSV header: %w", err)
+		}
+		header = record
+		line++
+	}
+
+	columns := map[string]int{}
+	for name, column := range map[string]string{
+		"date":        mapping.DateColumn,
+		"amount":      mapping.AmountColumn,
+		"description": mapping.DescriptionColumn,
+		"category":    mapping.CategoryColumn,
+	} {
+		if column == "" {
+			continue
+		}
+		index, err := columnIndex(header, column)
+		if err != nil {
+			return nil, fmt.Errorf("%s column: %w", name, err)
+		}
+		columns[name] = index
+	}
+
+	layout := dateLayout(mapping.DateFormat)
+	rows := []Row{}
+	for ; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(rows) == MaxRows {
+			return nil, ErrTooManyRows
+		}
+		rows = append(rows, parseRecord(record, line, columns, layout, mapping))
+	}
+	return rows, nil
+}
+
+func parseRecord(record []string, line int, columns map[string]int, layout string, mapping Mapping) Row {
+	row := Row{Line: line, Status: StatusNew}
+
+	field := func(name string) (string, bool) {
+		index, ok := columns[name]
+		if !ok || index >= len(record) {
+			return "", false
+		}
+		return strings.TrimSpace(record[index]), true
+	}
+
+	if value, ok := field("description"); ok {
+		row.Description = value
+	}
+	if value, ok := field("category"); ok {
+		row.Category = value
+	}
+
+	value, ok := field("date")
+	if !ok {
+		row.invalidate("missing date")
+		return row
+	}
+	date, err := time.Parse(layout, value)
+	if err != nil {
+		row.invalidate("invalid date %q", value)
+		return row
+	}
+	row.Date = date
+
+	value, ok = field("amount")
+	if !ok {
+		row.invalidate("missing amount")
+		return row
+	}
+	amount, err := parseAmount(value, mapping.DecimalSeparator)
+	if err != nil {
+		row.invalidate("%s", err)
+		return row
+	}
+	if mapping.Sign == SignNegative {
+		amount = -amount
+	}
+	if amount <= 0 {
+		row.Status = StatusSkipped
+	}
+	row.Amount = max(amount, -amount)
+	return row
+}
+
+func columnIndex(header []string, column string) (int, error) {
+	if n, err := strconv.Atoi(column); err == nil {
+		if n < 1 {
+			return 0, fmt.Errorf("invalid position %d", n)
+		}
+		return n - 1, nil
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(column)) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no column named %q", column)
+}
+
+// dateLayout turns a DD.MM.YYYY style pattern into a Go layout.
+// Anything without those tokens is taken to be a Go layout already.
+func dateLayout(format string) string {
+	return strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02").Replace(format)
+}
+
+// parseAmount reads a decimal amount such as "-1 234,50" into minor units
+func parseAmount(s string, decimalSeparator string) (int64, error) {
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+
+	value := strings.NewReplacer(" ", "", "\u00a0", "", "'", "", thousandsSeparator, "").Replace(s)
+	value = strings.Replace(value, decimalSeparator, ".", 1)
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		value = "-" + strings.Trim(value, "()")
+	}
+
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	amount.Mul(amount, big.NewRat(100, 1))
+	if !amount.IsInt() || !amount.Num().IsInt64() {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	return amount.Num().Int64(), nil
+}
+
+// guessDecimalSeparator picks the decimal separator of an amount in a format that
+// does not declare one. The last "." or "," is the decimal separator only when one
+// or two digits follow it, as in "12,5" or "1.234,56"; otherwise it separates
+// thousands, as in "-1,234".
+func guessDecimalSeparator(s string) string {
+	i := strings.LastIndexAny(s, ".,")
+	if i < 0 {
+		return "."
+	}
+	digits := 0
+	for _, c := range s[i+1:] {
+		if c >= '0' && c <= '9' {
+			digits++
+		}
+	}
+	if digits == 1 || digits == 2 {
+		return s[i : i+1]
+	}
+	if s[i] == '.' {
+		return ","
+	}
+	return "."
+}
@@ -0,0 +1,164 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	data := "Datum;Betrag;Verwendungszweck;Kategorie\n" +
+		"01.03.2024;-1.234,50;Rent;Housing\n" +
+		"02.03.2024;2 000,00;Salary;\n" +
+		"03.03.2024;-4,99;Coffee;food\n" +
+		"31.02.2024;-1,00;Typo;\n" +
+		"04.03.2024;-abc;Broken;\n" +
+		"\n"
+
+	rows, err := ParseCSV(strings.NewReader(data), Mapping{
+		DateColumn:        "datum",
+		AmountColumn:      "Betrag",
+		DescriptionColumn: "3",
+		CategoryColumn:    "Kategorie",
+		DateFormat:        "DD.MM.YYYY",
+		DecimalSeparator:  ",",
+		Delimiter:         ";",
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	require.Equal(t, Row{
+		Line:        2,
+		Date:        time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Amount:      123450,
+		Description: "Rent",
+		Category:    "Housing",
+		Status:      StatusNew,
+	}, rows[0])
+
+	require.Equal(t, StatusSkipped, rows[1].Status)
+	require.Equal(t, int64(200000), rows[1].Amount)
+
+	require.Equal(t, StatusNew, rows[2].Status)
+	require.Equal(t, int64(499), rows[2].Amount)
+
+	require.Equal(t, StatusInvalid, rows[3].Status)
+	require.Contains(t, rows[3].Error, "invalid date")
+
+	require.Equal(t, StatusInvalid, rows[4].Status)
+	require.Equal(t, 6, rows[4].Line)
+}
+
+func TestParseCSVPositiveWithoutHeader(t *testing.T) {
+	data := "2024-03-01,\"1,000.25\",Groceries\n2024-03-02,-5.00,Refund\n2024-03-03,(7.50),Fee\n"
+
+	rows, err := ParseCSV(strings.NewReader(data), Mapping{
+		DateColumn:        "1",
+		AmountColumn:      "2",
+		DescriptionColumn: "3",
+		Sign:              SignPositive,
+		NoHeader:          true,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, 1, rows[0].Line)
+	require.Equal(t, int64(100025), rows[0].Amount)
+	require.Equal(t, StatusNew, rows[0].Status)
+	require.Equal(t, StatusSkipped, rows[1].Status)
+	require.Equal(t, StatusSkipped, rows[2].Status)
+	require.Equal(t, int64(750), rows[2].Amount)
+}
+
+func TestParseCSVMapping(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    string
+		mapping Mapping
+	}{
+		{
+			name:    "MissingColumns",
+			data:    "date,amount\n",
+			mapping: Mapping{DateColumn: "date"},
+		},
+		{
+			name:    "UnknownColumn",
+			data:    "date,amount\n",
+			mapping: Mapping{DateColumn: "date", AmountColumn: "sum"},
+		},
+		{
+			name:    "InvalidDecimalSeparator",
+			data:    "date,amount\n",
+			mapping: Mapping{DateColumn: "date", AmountColumn: "amount", DecimalSeparator: "'"},
+		},
+		{
+			name:    "InvalidSign",
+			data:    "date,amount\n",
+			mapping: Mapping{DateColumn: "date", AmountColumn: "amount", Sign: "debit"},
+		},
+		{
+			name:    "InvalidDelimiter",
+			data:    "date,amount\n",
+			mapping: Mapping{DateColumn: "date", AmountColumn: "amount", Delimiter: ";;"},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(tc.data), tc.mapping)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	testCases := []struct {
+		value     string
+		separator string
+		expected  int64
+		valid     bool
+	}{
+		{"12", ".", 1200, true},
+		{"-12.3", ".", -1230, true},
+		{"1,234.56", ".", 123456, true},
+		{"1.234,56", ",", 123456, true},
+		{"1'234.56", ".", 123456, true},
+		{"0.001", ".", 0, false},
+		{"12a", ".", 0, false},
+	}
+
+	for _, tc := range testCases {
+		amount, err := parseAmount(tc.value, tc.separator)
+		if !tc.valid {
+			require.Error(t, err, tc.value)
+			continue
+		}
+		require.NoError(t, err, tc.value)
+		require.Equal(t, tc.expected, amount, tc.value)
+	}
+}
+
+func TestGuessDecimalSeparator(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected int64
+	}{
+		{"-1,234", -123400},
+		{"-1.234", -123400},
+		{"1,234,567", 123456700},
+		{"-12,5", -1250},
+		{"-12,50", -1250},
+		{"1.234,56", 123456},
+		{"1,234.56", 123456},
+		{"(1,234)", -123400},
+		{"42", 4200},
+	}
+
+	for _, tc := range testCases {
+		amount, err := parseAmount(tc.value, guessDecimalSeparator(tc.value))
+		require.NoError(t, err, tc.value)
+		require.Equal(t, tc.expected, amount, tc.value)
+	}
+}
@@ -0,0 +1,233 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/symyzi/financial-helper/categorizer"
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/util"
+)
+
+// Supported statement formats
+const (
+	FormatCSV = "csv"
+	FormatOFX = "ofx"
+	FormatQIF = "qif"
+)
+
+var ErrUnknownFormat = errors.New("unknown statement format")
+
+// Parse reads a statement in the given format. The mapping is only used for CSV.
+func Parse(r io.Reader, format string, mapping Mapping) ([]Row, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return ParseCSV(r, mapping)
+	case FormatOFX:
+		return ParseOFX(r)
+	case FormatQIF:
+		return ParseQIF(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// Store is the subset of db.Store the importer reads categories and expenses from
+type Store interface {
+	categorizer.Store
+	GetAllCategories(ctx context.Context, owner string) ([]db.Category, error)
+	ListWalletExpensesBetween(ctx context.Context, arg db.ListWalletExpensesBetweenParams) ([]db.Expense, error)
+	ListWalletExpenseExternalIDs(ctx context.Context, arg db.ListWalletExpenseExternalIDsParams) ([]string, error)
+	ImportExpensesTx(ctx context.Context, arg db.ImportExpensesTxParams) (db.ImportExpensesTxResult, error)
+}
+
+// Importer resolves categories for parsed statement rows, flags the rows already
+// recorded in the wallet and creates the rest as expenses
+type Importer struct {
+	store Store
+}
+
+func New(store Store) *Importer {
+	return &Importer{store: store}
+}
+
+// Result is the outcome of an import
+type Result struct {
+	Wallet   db.Wallet    `json:"wallet"`
+	Expenses []db.Expense `json:"expenses"`
+	Rows     []Row        `json:"rows"`
+}
+
+// Preview resolves categories and marks duplicates without writing anything.
+// Rows with an external ID are matched on it alone, other rows on their day,
+// amount and description. Rows whose category matches none of the owner's categories get
+// the category of the first matching categorization rule, else defaultCategoryID, or are
+// marked invalid when it is zero.
+func (importer *Importer) Preview(ctx context.Context, wallet db.Wallet, rows []Row, defaultCategoryID int64) ([]Row, error) {
+	rows, err := importer.resolveCategories(ctx, wallet, rows, defaultCategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	externalIDs := []string{}
+	for _, row := range rows {
+		if row.Status == StatusNew && row.ExternalID != "" {
+			externalIDs = append(externalIDs, row.ExternalID)
+		}
+	}
+	if len(externalIDs) > 0 {
+		imported, err := importer.store.ListWalletExpenseExternalIDs(ctx, db.ListWalletExpenseExternalIDsParams{
+			WalletID:    wallet.ID,
+			ExternalIds: externalIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot load imported transaction ids: %w", err)
+		}
+		seen := make(map[string]bool, len(imported))
+		for _, id := range imported {
+			seen[id] = true
+		}
+		for i, row := range rows {
+			if row.Status == StatusNew && seen[row.ExternalID] {
+				rows[i].Status = StatusDuplicate
+			}
+		}
+	}
+
+	from, to, ok := dateRange(rows)
+	if !ok {
+		return rows, nil
+	}
+	existing, err := importer.store.ListWalletExpensesBetween(ctx, db.ListWalletExpensesBetweenParams{
+		WalletID: wallet.ID,
+		FromTime: from,
+		ToTime:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot load existing expenses: %w", err)
+	}
+
+	duplicates := db.NewDuplicateIndex(existing)
+	for i, row := range rows {
+		if row.Status == StatusNew && row.ExternalID == "" && duplicates.Take(row.Date, row.Amount, row.Description) {
+			rows[i].Status = StatusDuplicate
+		}
+	}
+	return rows, nil
+}
+
+// Import creates an expense for every new row in a single transaction. Duplicates
+// are detected again inside the transaction, so the result may differ from a
+// preview taken earlier.
+func (importer *Importer) Import(ctx context.Context, wallet db.Wallet, rows []Row, defaultCategoryID int64) (Result, error) {
+	rows, err := importer.resolveCategories(ctx, wallet, rows, defaultCategoryID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	arg := db.ImportExpensesTxParams{
+		WalletID: wallet.ID,
+		Expenses: []db.CreateImportedExpenseParams{},
+	}
+	var indexes []int
+	for i, row := range rows {
+		if row.Status != StatusNew {
+			continue
+		}
+		expense := db.CreateImportedExpenseParams{
+			WalletID:           wallet.ID,
+			Amount:             row.Amount,
+			ExpenseDescription: row.Description,
+			CategoryID:         row.CategoryID,
+			CreatedAt:          row.Date,
+		}
+		if row.ExternalID != "" {
+			expense.ExternalID = &row.ExternalID
+		}
+		arg.Expenses = append(arg.Expenses, expense)
+		indexes = append(indexes, i)
+	}
+
+	txResult, err := importer.store.ImportExpensesTx(ctx, arg)
+	if err != nil {
+		return Result{}, fmt.Errorf("cannot import expenses: %w", err)
+	}
+	for _, duplicate := range txResult.Duplicates {
+		rows[indexes[duplicate]].Status = StatusDuplicate
+	}
+
+	return Result{
+		Wallet:   txResult.Wallet,
+		Expenses: txResult.Expenses,
+		Rows:     rows,
+	}, nil
+}
+
+// resolveCategories assigns each new row the owner's category named in the row,
+// else the category of the first matching rule, else the default category
+func (importer *Importer) resolveCategories(ctx context.Context, wallet db.Wallet, rows []Row, defaultCategoryID int64) ([]Row, error) {
+	categories, err := importer.store.GetAllCategories(ctx, wallet.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load categories: %w", err)
+	}
+	byName := make(map[string]int64, len(categories))
+	for _, category := range categories {
+		byName[strings.ToLower(category.Name)] = category.ID
+	}
+
+	var rules *categorizer.Categorizer
+	for i, row := range rows {
+		if row.Status != StatusNew {
+			continue
+		}
+		if id, ok := byName[strings.ToLower(row.Category)]; ok && row.Category != "" {
+			rows[i].CategoryID = id
+			continue
+		}
+
+		if rules == nil {
+			rules, err = categorizer.Load(ctx, importer.store, wallet.Owner)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rule, ok := rules.Match(categorizer.Expense{
+			WalletID:    wallet.ID,
+			Amount:      row.Amount,
+			Description: row.Description,
+		})
+		if ok {
+			rows[i].CategoryID = rule.CategoryID
+			continue
+		}
+
+		if defaultCategoryID == 0 {
+			rows[i].invalidate("unknown category %q", row.Category)
+			continue
+		}
+		rows[i].CategoryID = defaultCategoryID
+	}
+	return rows, nil
+}
+
+// dateRange returns the half-open range of days covering the new rows without an external ID
+func dateRange(rows []Row) (from, to time.Time, ok bool) {
+	for _, row := range rows {
+		if row.Status != StatusNew || row.ExternalID != "" {
+			continue
+		}
+		day := util.Day(row.Date)
+		if !ok || day.Before(from) {
+			from = day
+		}
+		if !ok || !day.Before(to) {
+			to = day.AddDate(0, 0, 1)
+		}
+		ok = true
+	}
+	return from, to, ok
+}
@@ -0,0 +1,206 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+)
+
+func testRows() []Row {
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	return []Row{
+		{Line: 2, Date: day, Amount: 500, Description: "Coffee", Category: "food", Status: StatusNew},
+		{Line: 3, Date: day.AddDate(0, 0, 1), Amount: 900, Description: "Taxi", Category: "Travel", Status: StatusNew},
+		{Line: 4, Date: day, Amount: 100, Description: "Salary", Status: StatusSkipped},
+	}
+}
+
+func TestPreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wallet := db.Wallet{ID: 1, Owner: "owner"}
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAllCategories(gomock.Any(), gomock.Eq(wallet.Owner)).
+		Times(1).
+		Return([]db.Category{{ID: 7, Name: "Food"}}, nil)
+	store.EXPECT().
+		ListCategorizationRules(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.CategorizationRule{}, nil)
+	store.EXPECT().
+		ListWalletExpensesBetween(gomock.Any(), gomock.Eq(db.ListWalletExpensesBetweenParams{
+			WalletID: wallet.ID,
+			FromTime: day,
+			ToTime:   day.AddDate(0, 0, 2),
+		})).
+		Times(1).
+		Return([]db.Expense{{Amount: 500, ExpenseDescription: " coffee", CreatedAt: day.Add(9 * time.Hour)}}, nil)
+
+	rows, err := New(store).Preview(context.Background(), wallet, testRows(), 3)
+	require.NoError(t, err)
+	require.Equal(t, StatusDuplicate, rows[0].Status)
+	require.Equal(t, int64(7), rows[0].CategoryID)
+	require.Equal(t, StatusNew, rows[1].Status)
+	require.Equal(t, int64(3), rows[1].CategoryID)
+	require.Equal(t, StatusSkipped, rows[2].Status)
+}
+
+func TestPreviewUnknownCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAllCategories(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Category{}, nil)
+	store.EXPECT().
+		ListCategorizationRules(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.CategorizationRule{}, nil)
+	store.EXPECT().
+		ListWalletExpensesBetween(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	rows, err := New(store).Preview(context.Background(), db.Wallet{ID: 1}, testRows(), 0)
+	require.NoError(t, err)
+	require.Equal(t, StatusInvalid, rows[0].Status)
+	require.Equal(t, StatusInvalid, rows[1].Status)
+	require.Contains(t, rows[1].Error, "Travel")
+}
+
+func TestPreviewExternalIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wallet := db.Wallet{ID: 1, Owner: "owner"}
+	rows := testRows()
+	rows[0].ExternalID = "fit-1"
+	rows[1].ExternalID = "fit-2"
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAllCategories(gomock.Any(), gomock.Eq(wallet.Owner)).
+		Times(1).
+		Return([]db.Category{{ID: 7, Name: "Food"}}, nil)
+	store.EXPECT().
+		ListCategorizationRules(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.CategorizationRule{}, nil)
+	store.EXPECT().
+		ListWalletExpenseExternalIDs(gomock.Any(), gomock.Eq(db.ListWalletExpenseExternalIDsParams{
+			WalletID:    wallet.ID,
+			ExternalIds: []string{"fit-1", "fit-2"},
+		})).
+		Times(1).
+		Return([]string{"fit-2"}, nil)
+	// rows with an id are never matched on date, amount and description
+	store.EXPECT().
+		ListWalletExpensesBetween(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	rows, err := New(store).Preview(context.Background(), wallet, rows, 3)
+	require.NoError(t, err)
+	require.Equal(t, StatusNew, rows[0].Status)
+	require.Equal(t, StatusDuplicate, rows[1].Status)
+	require.Equal(t, StatusSkipped, rows[2].Status)
+}
+
+func TestImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wallet := db.Wallet{ID: 1, Owner: "owner"}
+	rows := testRows()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAllCategories(gomock.Any(), gomock.Eq(wallet.Owner)).
+		Times(1).
+		Return([]db.Category{{ID: 7, Name: "Food"}, {ID: 8, Name: "travel"}}, nil)
+	store.EXPECT().
+		ListCategorizationRules(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	arg := db.ImportExpensesTxParams{
+		WalletID: wallet.ID,
+		Expenses: []db.CreateImportedExpenseParams{
+			{WalletID: wallet.ID, Amount: 500, ExpenseDescription: "Coffee", CategoryID: 7, CreatedAt: rows[0].Date},
+			{WalletID: wallet.ID, Amount: 900, ExpenseDescription: "Taxi", CategoryID: 8, CreatedAt: rows[1].Date},
+		},
+	}
+	expense := db.Expense{ID: 10, WalletID: wallet.ID, Amount: 900, CategoryID: 8}
+	store.EXPECT().
+		ImportExpensesTx(gomock.Any(), gomock.Eq(arg)).
+		Times(1).
+		Return(db.ImportExpensesTxResult{Wallet: wallet, Expenses: []db.Expense{expense}, Duplicates: []int{0}}, nil)
+
+	result, err := New(store).Import(context.Background(), wallet, rows, 0)
+	require.NoError(t, err)
+	require.Equal(t, []db.Expense{expense}, result.Expenses)
+	require.Equal(t, StatusDuplicate, result.Rows[0].Status)
+	require.Equal(t, StatusNew, result.Rows[1].Status)
+	require.Equal(t, StatusSkipped, result.Rows[2].Status)
+}
+
+func TestImportStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAllCategories(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Category{}, nil)
+	store.EXPECT().
+		ListCategorizationRules(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.CategorizationRule{}, nil)
+	store.EXPECT().
+		ImportExpensesTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.ImportExpensesTxResult{}, sql.ErrConnDone)
+
+	_, err := New(store).Import(context.Background(), db.Wallet{ID: 1}, testRows(), 3)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestPreviewRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wallet := db.Wallet{ID: 1, Owner: "owner"}
+	pattern := "TAXI"
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetAllCategories(gomock.Any(), gomock.Eq(wallet.Owner)).
+		Times(1).
+		Return([]db.Category{{ID: 7, Name: "Food"}}, nil)
+	store.EXPECT().
+		ListCategorizationRules(gomock.Any(), gomock.Eq(wallet.Owner)).
+		Times(1).
+		Return([]db.CategorizationRule{{ID: 1, CategoryID: 9, MatchType: "substring", Pattern: &pattern}}, nil)
+	store.EXPECT().
+		ListWalletExpensesBetween(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Expense{}, nil)
+
+	// the category column wins over rules, rules win over the default
+	rows := testRows()
+	rows[0].Description = "Taxi to the cafe"
+	rows, err := New(store).Preview(context.Background(), wallet, rows, 3)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), rows[0].CategoryID)
+	require.Equal(t, int64(9), rows[1].CategoryID)
+}
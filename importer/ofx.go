@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ParseOFX reads the bank and credit card transactions of an OFX statement, in
+// either the SGML (1.x) or the XML (2.x) flavour. Debits become expenses, credits
+// are skipped, and FITID is kept as the row's external ID. Each row's line is
+// the line its STMTTRN aggregate starts on.
+func ParseOFX(r io.Reader) ([]Row, error) {
+	tokens, err := scanOFX(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []Row{}
+	var fields map[string]string
+	var line int
+	for _, token := range tokens {
+		switch {
+		case token.tag == "STMTTRN":
+			fields = map[string]string{}
+			line = token.line
+		case token.tag == "/STMTTRN":
+			if fields == nil {
+				continue
+			}
+			if len(rows) == MaxRows {
+				return nil, ErrTooManyRows
+			}
+			rows = append(rows, ofxRow(fields, line))
+			fields = nil
+		case fields != nil && !strings.HasPrefix(token.tag, "/"):
+			fields[token.tag] = token.value
+		}
+	}
+	if fields != nil {
+		return nil, fmt.Errorf("line %d: unterminated STMTTRN", line)
+	}
+	if len(rows) == 0 && !hasTag(tokens, "OFX") {
+		return nil, fmt.Errorf("not an OFX document")
+	}
+	return rows, nil
+}
+
+func ofxRow(fields map[string]string, line int) Row {
+	row := Row{
+		Line:        line,
+		Status:      StatusNew,
+		Description: joinNonEmpty(" - ", fields["NAME"], fields["PAYEE"], fields["MEMO"]),
+		ExternalID:  fields["FITID"],
+	}
+
+	date, err := parseOFXDate(fields["DTPOSTED"])
+	if err != nil {
+		row.invalidate("invalid DTPOSTED %q", fields["DTPOSTED"])
+		return row
+	}
+	row.Date = date
+
+	value := fields["TRNAMT"]
+	amount, err := parseAmount(value, guessDecimalSeparator(value))
+	if err != nil {
+		row.invalidate("%s", err)
+		return row
+	}
+	if amount >= 0 {
+		row.Status = StatusSkipped
+	}
+	row.Amount = max(amount, -amount)
+	return row
+}
+
+// parseOFXDate reads YYYYMMDD[HHMMSS[.XXX]][[offset:TZ]] and keeps the calendar date
+// the bank reported, whatever its time zone
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+type ofxToken struct {
+	tag   string
+	value string
+	line  int
+}
+
+// scanOFX splits the document into tags and their text. SGML leaf elements have no
+// closing tag, so the value of a tag is simply the text up to the next tag.
+func scanOFX(r io.Reader) ([]ofxToken, error) {
+	reader := bufio.NewReader(r)
+	var tokens []ofxToken
+	line := 1
+	var current *ofxToken
+	var text strings.Builder
+
+	flush := func() {
+		if current != nil {
+			current.value = html.UnescapeString(strings.TrimSpace(text.String()))
+			tokens = append(tokens, *current)
+			current = nil
+		}
+		text.Reset()
+	}
+
+	for {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read OFX: %w", err)
+		}
+
+		switch c {
+		case '\n':
+			line++
+			text.WriteByte(c)
+		case '<':
+			flush()
+			tag, err := reader.ReadString('>')
+			if err != nil {
+				return nil, fmt.Errorf("line %d: unterminated tag", line)
+			}
+			line += strings.Count(tag, "\n")
+			tag = strings.ToUpper(strings.TrimSpace(strings.TrimSuffix(tag, ">")))
+			if strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") {
+				continue
+			}
+			if fields := strings.Fields(tag); len(fields) > 0 {
+				tag = fields[0]
+			}
+			current = &ofxToken{tag: tag, line: line}
+		default:
+			text.WriteByte(c)
+		}
+	}
+	flush()
+	return tokens, nil
+}
+
+func hasTag(tokens []ofxToken, tag string) bool {
+	for _, token := range tokens {
+		if token.tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func joinNonEmpty(separator string, values ...string) string {
+	var parts []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(parts, value) {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, separator)
+}
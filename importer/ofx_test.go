@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const ofxSGML = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKTRANLIST>
+<DTSTART>20240301
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240301120000.000[-5:EST]
+<TRNAMT>-12.50
+<FITID>2024030101
+<NAME>COFFEE &amp; CO
+<MEMO>Card 1234
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240302
+<TRNAMT>1000.00
+<FITID>2024030201
+<NAME>SALARY
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>2024
+<TRNAMT>-1.00
+<FITID>2024030301
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const ofxXML = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX>
+  <CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><BANKTRANLIST>
+    <STMTTRN>
+      <TRNTYPE>DEBIT</TRNTYPE>
+      <DTPOSTED>20240305</DTPOSTED>
+      <TRNAMT>-7,25</TRNAMT>
+      <FITID>cc-1</FITID>
+      <NAME>Bookshop</NAME>
+      <MEMO>Bookshop</MEMO>
+    </STMTTRN>
+  </BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestParseOFXSGML(t *testing.T) {
+	rows, err := ParseOFX(strings.NewReader(ofxSGML))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	require.Equal(t, Row{
+		Line:        9,
+		Date:        time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Amount:      1250,
+		Description: "COFFEE & CO - Card 1234",
+		ExternalID:  "2024030101",
+		Status:      StatusNew,
+	}, rows[0])
+
+	require.Equal(t, StatusSkipped, rows[1].Status)
+	require.Equal(t, "2024030201", rows[1].ExternalID)
+
+	require.Equal(t, StatusInvalid, rows[2].Status)
+	require.Equal(t, 24, rows[2].Line)
+}
+
+func TestParseOFXXML(t *testing.T) {
+	rows, err := ParseOFX(strings.NewReader(ofxXML))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(725), rows[0].Amount)
+	require.Equal(t, "Bookshop", rows[0].Description)
+	require.Equal(t, "cc-1", rows[0].ExternalID)
+	require.Equal(t, StatusNew, rows[0].Status)
+}
+
+func TestParseOFXInvalid(t *testing.T) {
+	_, err := ParseOFX(strings.NewReader("date,amount\n2024-03-01,-1\n"))
+	require.Error(t, err)
+
+	_, err = ParseOFX(strings.NewReader("<OFX><STMTTRN><TRNAMT>-1"))
+	require.ErrorContains(t, err, "unterminated STMTTRN")
+}
@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var qifDateLayouts = []string{"1/2/2006", "1/2/06", "2006-01-02", "2.1.2006", "2.1.06"}
+
+// ParseQIF reads the transactions of a QIF bank, cash or credit card account.
+// Debits become expenses, credits are skipped, and the L field is kept as the
+// category name. QIF has no transaction IDs, so each row gets one derived from
+// its date, amount, payee and memo plus its position among identical records,
+// which keeps re-importing the same file idempotent.
+func ParseQIF(r io.Reader) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	rows := []Row{}
+	seen := map[string]int{}
+
+	var fields map[byte]string
+	start := 0
+	skip := false
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "!") {
+			// only bank-like lists hold plain transactions; account, category and
+			// investment lists are skipped up to the next !Type header
+			header := strings.ToLower(strings.TrimSpace(text))
+			switch {
+			case strings.HasPrefix(header, "!type:"):
+				skip = !qifTransactionType(header)
+			case header == "!account":
+				skip = true
+			}
+			continue
+		}
+		if skip {
+			continue
+		}
+
+		if text[0] == '^' {
+			if fields != nil {
+				if len(rows) == MaxRows {
+					return nil, ErrTooManyRows
+				}
+				rows = append(rows, qifRow(fields, start, seen))
+			}
+			fields = nil
+			continue
+		}
+
+		if fields == nil {
+			fields = map[byte]string{}
+			start = line
+		}
+		code := text[0]
+		if _, ok := fields[code]; !ok {
+			// split lines (S, E, $) repeat codes; the first value is the total
+			fields[code] = strings.TrimSpace(text[1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read QIF: %w", err)
+	}
+	if fields != nil {
+		return nil, fmt.Errorf("line %d: record is not terminated by ^", start)
+	}
+	return rows, nil
+}
+
+func qifTransactionType(header string) bool {
+	switch strings.TrimPrefix(header, "!type:") {
+	case "bank", "cash", "ccard", "oth a", "oth l":
+		return true
+	}
+	return false
+}
+
+func qifRow(fields map[byte]string, line int, seen map[string]int) Row {
+	row := Row{
+		Line:        line,
+		Status:      StatusNew,
+		Description: joinNonEmpty(" - ", fields['P'], fields['M']),
+		Category:    fields['L'],
+	}
+
+	date, err := parseQIFDate(fields['D'])
+	if err != nil {
+		row.invalidate("invalid date %q", fields['D'])
+		return row
+	}
+	row.Date = date
+
+	value, ok := fields['T']
+	if !ok {
+		value = fields['U']
+	}
+	amount, err := parseAmount(value, guessDecimalSeparator(value))
+	if err != nil {
+		row.invalidate("%s", err)
+		return row
+	}
+	if amount >= 0 {
+		row.Status = StatusSkipped
+	}
+	row.Amount = max(amount, -amount)
+
+	key := strings.Join([]string{date.Format(defaultDateFormat), fmt.Sprint(amount), fields['P'], fields['M']}, "\x00")
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", key, seen[key])))
+	seen[key]++
+	row.ExternalID = "qif-" + hex.EncodeToString(sum[:12])
+	return row
+}
+
+// parseQIFDate accepts the common Quicken spellings such as 03/01/2024,
+// 3/ 1'24 (years from 2000 on) and 2024-03-01
+func parseQIFDate(value string) (time.Time, error) {
+	value = strings.ReplaceAll(value, " ", "")
+	value = strings.ReplaceAll(value, "'", "/")
+	for _, layout := range qifDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const qifBank = `!Option:AutoSwitch
+!Account
+NChecking
+TBank
+^
+!Clear:AutoSwitch
+!Type:Bank
+D03/01/2024
+T-1,234.50
+PLandlord
+MMarch rent
+LHousing
+^
+D3/ 2'24
+T-4.99
+PCoffee
+^
+D3/ 2'24
+T-4.99
+PCoffee
+^
+D03/03/2024
+T2000.00
+PEmployer
+^
+D31/31/2024
+T-1.00
+^
+!Type:Cat
+NFood
+E
+^
+`
+
+func TestParseQIF(t *testing.T) {
+	rows, err := ParseQIF(strings.NewReader(qifBank))
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	require.Equal(t, 8, rows[0].Line)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), rows[0].Date)
+	require.Equal(t, int64(123450), rows[0].Amount)
+	require.Equal(t, "Landlord - March rent", rows[0].Description)
+	require.Equal(t, "Housing", rows[0].Category)
+	require.Equal(t, StatusNew, rows[0].Status)
+
+	// identical records get distinct but stable ids
+	require.Equal(t, time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC), rows[1].Date)
+	require.NotEmpty(t, rows[1].ExternalID)
+	require.NotEqual(t, rows[1].ExternalID, rows[2].ExternalID)
+
+	again, err := ParseQIF(strings.NewReader(qifBank))
+	require.NoError(t, err)
+	for i := range rows {
+		require.Equal(t, rows[i].ExternalID, again[i].ExternalID)
+	}
+
+	require.Equal(t, StatusSkipped, rows[3].Status)
+	require.Equal(t, StatusInvalid, rows[4].Status)
+	require.Equal(t, 26, rows[4].Line)
+}
+
+func TestParseQIFUnterminated(t *testing.T) {
+	_, err := ParseQIF(strings.NewReader("!Type:Bank\nD03/01/2024\nT-1.00\n"))
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestParseQIFSeparators(t *testing.T) {
+	data := "!Type:Bank\nD03/01/2024\nT-1,234\n^\nD03/01/2024\nT-12,50\n^\n"
+	rows, err := ParseQIF(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	// a comma followed by three digits separates thousands
+	require.Equal(t, int64(123400), rows[0].Amount)
+	require.Equal(t, int64(1250), rows[1].Amount)
+}
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"os"
 
 	_ "github.com/lib/pq"
 	"github.com/symyzi/financial-helper/api"
 	db "github.com/symyzi/financial-helper/db/gen"
 	"github.com/symyzi/financial-helper/util"
+	"github.com/symyzi/financial-helper/worker"
 )
 
 func main() {
@@ -22,6 +25,21 @@ func main() {
 	}
 
 	store := db.NewStore(conn)
+
+	if len(os.Args) > 1 {
+		err = runCommand(config, store, os.Args[1:])
+		if err != nil {
+			log.Fatal("cannot run command:", err)
+		}
+		return
+	}
+
+	materializer := worker.NewRecurringExpenseMaterializer(store, config.RecurringInterval)
+	go materializer.Run(context.Background())
+
+	cleaner := worker.NewRevokedTokenCleaner(store, config.RevokedTokenInterval)
+	go cleaner.Run(context.Background())
+
 	server, err := api.NewServer(config, store)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(root)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = store.Put(ctx, "expenses/1/receipt", strings.NewReader("pdf data"), "application/pdf")
+	require.NoError(t, err)
+
+	blob, err := store.Get(ctx, "expenses/1/receipt")
+	require.NoError(t, err)
+	data, err := io.ReadAll(blob)
+	require.NoError(t, err)
+	require.NoError(t, blob.Close())
+	require.Equal(t, "pdf data", string(data))
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Join(root, "expenses", "1"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.NoError(t, store.Delete(ctx, "expenses/1/receipt"))
+	require.NoError(t, store.Delete(ctx, "expenses/1/receipt"))
+
+	_, err = store.Get(ctx, "expenses/1/receipt")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"a", "expenses/1/receipt"} {
+		require.NoError(t, ValidateKey(key), key)
+	}
+	for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../b", "a//b", `a\b`, "a/"} {
+		require.Error(t, ValidateKey(key), key)
+	}
+}
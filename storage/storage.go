@@ -0,0 +1,39 @@
+// Package storage keeps binary blobs such as receipt attachments outside the
+// database. Blobs are addressed by slash separated keys chosen by the caller.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNotFound is returned when no blob is stored under a key
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores and retrieves blobs. Implementations must be safe for
+// concurrent use; LocalStore keeps blobs on disk and an S3 compatible store can
+// satisfy the same interface.
+type BlobStore interface {
+	// Put stores the content read from r under key, replacing any existing blob
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// Get opens the blob stored under key, the caller must close it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key, deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// ValidateKey rejects keys that are empty, absolute or try to leave the store
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return nil
+}
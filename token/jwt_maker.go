@@ -5,38 +5,60 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v4"
 )
 
 type JWTMaker struct {
-	secretKey string
+	keys *keyRing
 }
 
 const minSecretKeySize = 32
 
 func NewJWTMaker(secretKey string) (Maker, error) {
-	if len(secretKey) < minSecretKeySize {
-		return nil, fmt.Errorf("invalid key size: must be at least %d characters", minSecretKeySize)
+	return NewJWTKeyRingMaker("", []Key{{Secret: []byte(secretKey)}})
+}
+
+// NewJWTKeyRingMaker creates a maker that signs with the active key and verifies
+// with the key named by kid in the token header. Tokens without kid belong to
+// the key with an empty ID.
+func NewJWTKeyRingMaker(activeID string, keys []Key) (Maker, error) {
+	for _, key := range keys {
+		if len(key.Secret) < minSecretKeySize {
+			return nil, fmt.Errorf("invalid key size: must be at least %d characters", minSecretKeySize)
+		}
+	}
+	ring, err := newKeyRing(activeID, keys)
+	if err != nil {
+		return nil, err
 	}
-	return &JWTMaker{secretKey}, nil
+	return &JWTMaker{keys: ring}, nil
 }
 
-func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (string, error) {
-	payload, err := NewPayload(username, duration)
+func (maker *JWTMaker) CreateToken(username string, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, tokenType, duration)
 	if err != nil {
-		return "", err
+		return "", payload, err
 	}
 	jwt_token := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
-	return jwt_token.SignedString([]byte(maker.secretKey))
+	if maker.keys.active.ID != "" {
+		jwt_token.Header["kid"] = maker.keys.active.ID
+	}
+	token, err := jwt_token.SignedString(maker.keys.active.Secret)
+	return token, payload, err
 }
 
-func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
+func (maker *JWTMaker) VerifyToken(token string, tokenType TokenType) (*Payload, error) {
 	keyFunc := func(token *jwt.Token) (interface{}, error) {
 		_, ok := token.Method.(*jwt.SigningMethodHMAC)
 		if !ok {
 			return nil, ErrInvalidToken
 		}
-		return []byte(maker.secretKey), nil
+		kid, _ := token.Header["kid"].(string)
+		key, err := maker.keys.lookup(kid)
+		if err != nil {
+			return nil, err
+		}
+		return key.Secret, nil
 	}
 
 	jwt_token, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
@@ -51,5 +73,8 @@ func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 	if !ok {
 		return nil, ErrInvalidToken
 	}
+	if err := payload.checkType(tokenType); err != nil {
+		return nil, err
+	}
 	return payload, nil
 }
@@ -0,0 +1,108 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// JWTPublicMaker signs JWTs with an Ed25519 (EdDSA) or RSA (RS256) private key.
+// The key ID is set as kid in the header so verifiers can pick the public key.
+type JWTPublicMaker struct {
+	keys *keyRing
+}
+
+func NewJWTPublicMaker(keyID string, privateKey crypto.Signer) (Maker, error) {
+	return NewJWTPublicKeyRingMaker(keyID, []Key{{ID: keyID, Signer: privateKey}})
+}
+
+// NewJWTPublicKeyRingMaker creates a maker that signs with the active key and
+// verifies with the key named by kid. Keys of both types can be mixed.
+func NewJWTPublicKeyRingMaker(activeID string, keys []Key) (Maker, error) {
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("key ID is not set")
+		}
+		if _, err := jwtSigningMethod(key); err != nil {
+			return nil, err
+		}
+	}
+	ring, err := newKeyRing(activeID, keys)
+	if err != nil {
+		return nil, err
+	}
+	return &JWTPublicMaker{keys: ring}, nil
+}
+
+func jwtSigningMethod(key Key) (jwt.SigningMethod, error) {
+	switch key.Signer.(type) {
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA, nil
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key.Signer)
+	}
+}
+
+func (maker *JWTPublicMaker) CreateToken(username string, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, tokenType, duration)
+	if err != nil {
+		return "", payload, err
+	}
+	key := maker.keys.active
+	method, err := jwtSigningMethod(key)
+	if err != nil {
+		return "", payload, err
+	}
+	jwtToken := jwt.NewWithClaims(method, payload)
+	jwtToken.Header["kid"] = key.ID
+	token, err := jwtToken.SignedString(key.Signer)
+	return token, payload, err
+}
+
+func (maker *JWTPublicMaker) VerifyToken(token string, tokenType TokenType) (*Payload, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := maker.keys.lookup(kid)
+		if err != nil {
+			return nil, err
+		}
+		method, err := jwtSigningMethod(key)
+		if err != nil || token.Method.Alg() != method.Alg() {
+			return nil, ErrInvalidToken
+		}
+		return key.Signer.Public(), nil
+	}
+
+	jwtToken, err := jwt.ParseWithClaims(token, &Payload{}, keyFunc)
+	if err != nil {
+		verr, ok := err.(*jwt.ValidationError)
+		if ok && errors.Is(verr.Inner, ErrExpiredToken) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+	payload, ok := jwtToken.Claims.(*Payload)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	if err := payload.checkType(tokenType); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (maker *JWTPublicMaker) PublicKeys() []JWK {
+	var keys []JWK
+	for _, key := range maker.keys.verifyKeys() {
+		method, _ := jwtSigningMethod(key)
+		keys = append(keys, newJWK(key.ID, method.Alg(), key.Signer.Public()))
+	}
+	return keys
+}
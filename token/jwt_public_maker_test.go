@@ -0,0 +1,96 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func TestJWTPublicMaker(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		key     crypto.Signer
+		alg     string
+		keyType string
+	}{
+		{name: "EdDSA", key: edKey, alg: "EdDSA", keyType: "OKP"},
+		{name: "RS256", key: rsaKey, alg: "RS256", keyType: "RSA"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			maker, err := NewJWTPublicMaker("key-1", tc.key)
+			require.NoError(t, err)
+
+			username := util.RandomUsername()
+			token, payload, err := maker.CreateToken(username, util.UserRole, TokenTypeAccess, time.Minute)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(token, &Payload{})
+			require.NoError(t, err)
+			require.Equal(t, tc.alg, parsed.Header["alg"])
+			require.Equal(t, "key-1", parsed.Header["kid"])
+
+			verified, err := maker.VerifyToken(token, TokenTypeAccess)
+			require.NoError(t, err)
+			require.Equal(t, payload.ID, verified.ID)
+			require.Equal(t, username, verified.Username)
+
+			keys := maker.(PublicKeyProvider).PublicKeys()
+			require.Len(t, keys, 1)
+			require.Equal(t, tc.alg, keys[0].Algorithm)
+			require.Equal(t, tc.keyType, keys[0].KeyType)
+
+			token, _, err = maker.CreateToken(username, util.UserRole, TokenTypeAccess, -time.Minute)
+			require.NoError(t, err)
+			_, err = maker.VerifyToken(token, TokenTypeAccess)
+			require.EqualError(t, err, ErrExpiredToken.Error())
+		})
+	}
+}
+
+func TestJWTPublicTokenWrongKeyID(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	maker, err := NewJWTPublicMaker("key-1", key)
+	require.NoError(t, err)
+	other, err := NewJWTPublicMaker("key-2", key)
+	require.NoError(t, err)
+
+	token, _, err := other.CreateToken(util.RandomUsername(), util.UserRole, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+	_, err = maker.VerifyToken(token, TokenTypeAccess)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
+
+func TestJWTPublicTokenAlgNone(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	maker, err := NewJWTPublicMaker("key-1", key)
+	require.NoError(t, err)
+
+	payload, err := NewPayload(util.RandomUsername(), util.UserRole, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodNone, payload)
+	jwtToken.Header["kid"] = "key-1"
+	token, err := jwtToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(token, TokenTypeAccess)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
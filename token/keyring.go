@@ -0,0 +1,77 @@
+package token
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Key is one key of a maker's key ring. Symmetric makers use Secret, public-key
+// makers use Signer. Only the active key signs tokens; the others verify tokens
+// until ExpiresAt, a zero time meaning they never expire.
+type Key struct {
+	ID        string
+	Secret    []byte
+	Signer    crypto.Signer
+	ExpiresAt time.Time
+}
+
+type keyRing struct {
+	active Key
+	keys   map[string]Key
+	now    func() time.Time
+}
+
+func newKeyRing(activeID string, keys []Key) (*keyRing, error) {
+	ring := &keyRing{
+		keys: make(map[string]Key, len(keys)),
+		now:  time.Now,
+	}
+	for _, key := range keys {
+		if _, ok := ring.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		ring.keys[key.ID] = key
+	}
+
+	active, ok := ring.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q is not in the key ring", activeID)
+	}
+	if !active.ExpiresAt.IsZero() {
+		return nil, errors.New("the active key cannot expire")
+	}
+	ring.active = active
+	return ring, nil
+}
+
+// lookup returns the key that verifies tokens signed with the given key ID
+func (ring *keyRing) lookup(id string) (Key, error) {
+	key, ok := ring.keys[id]
+	if !ok {
+		return key, ErrInvalidToken
+	}
+	if !key.ExpiresAt.IsZero() && ring.now().After(key.ExpiresAt) {
+		return key, ErrInvalidToken
+	}
+	return key, nil
+}
+
+// verifyKeys returns the keys that still verify tokens, the active one first
+func (ring *keyRing) verifyKeys() []Key {
+	keys := []Key{ring.active}
+	for _, key := range ring.keys {
+		if key.ID == ring.active.ID {
+			continue
+		}
+		if _, err := ring.lookup(key.ID); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys[1:], func(i, j int) bool {
+		return keys[i+1].ID < keys[j+1].ID
+	})
+	return keys
+}
@@ -0,0 +1,92 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func TestKeyRingRotation(t *testing.T) {
+	oldKey := Key{ID: "old", Secret: []byte(util.RandomString(32))}
+	newKey := Key{ID: "new", Secret: []byte(util.RandomString(32))}
+
+	testCases := []struct {
+		name     string
+		newMaker func(activeID string, keys []Key) (Maker, error)
+	}{
+		{name: "Paseto", newMaker: NewPasetoKeyRingMaker},
+		{name: "JWT", newMaker: NewJWTKeyRingMaker},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			before, err := tc.newMaker("old", []Key{oldKey})
+			require.NoError(t, err)
+			oldToken, _, err := before.CreateToken(util.RandomUsername(), util.UserRole, TokenTypeAccess, time.Minute)
+			require.NoError(t, err)
+
+			retired := oldKey
+			retired.ExpiresAt = time.Now().Add(time.Hour)
+			after, err := tc.newMaker("new", []Key{retired, newKey})
+			require.NoError(t, err)
+
+			// tokens of the retired key are still accepted
+			_, err = after.VerifyToken(oldToken, TokenTypeAccess)
+			require.NoError(t, err)
+
+			newToken, _, err := after.CreateToken(util.RandomUsername(), util.UserRole, TokenTypeAccess, time.Minute)
+			require.NoError(t, err)
+			_, err = after.VerifyToken(newToken, TokenTypeAccess)
+			require.NoError(t, err)
+			_, err = before.VerifyToken(newToken, TokenTypeAccess)
+			require.EqualError(t, err, ErrInvalidToken.Error())
+
+			retired.ExpiresAt = time.Now().Add(-time.Second)
+			expired, err := tc.newMaker("new", []Key{retired, newKey})
+			require.NoError(t, err)
+			_, err = expired.VerifyToken(oldToken, TokenTypeAccess)
+			require.EqualError(t, err, ErrInvalidToken.Error())
+		})
+	}
+}
+
+func TestPublicKeyRing(t *testing.T) {
+	_, oldSigner, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, newSigner, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, goneSigner, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys := []Key{
+		{ID: "gone", Signer: goneSigner, ExpiresAt: time.Now().Add(-time.Hour)},
+		{ID: "old", Signer: oldSigner, ExpiresAt: time.Now().Add(time.Hour)},
+		{ID: "new", Signer: newSigner},
+	}
+	for _, newMaker := range []func(string, []Key) (Maker, error){NewPasetoPublicKeyRingMaker, NewJWTPublicKeyRingMaker} {
+		maker, err := newMaker("new", keys)
+		require.NoError(t, err)
+
+		published := maker.(PublicKeyProvider).PublicKeys()
+		require.Len(t, published, 2)
+		require.Equal(t, "new", published[0].KeyID)
+		require.Equal(t, "old", published[1].KeyID)
+
+		before, err := newMaker("old", []Key{{ID: "old", Signer: oldSigner}})
+		require.NoError(t, err)
+		token, _, err := before.CreateToken(util.RandomUsername(), util.UserRole, TokenTypeAccess, time.Minute)
+		require.NoError(t, err)
+		_, err = maker.VerifyToken(token, TokenTypeAccess)
+		require.NoError(t, err)
+	}
+
+	_, err = NewPasetoPublicKeyRingMaker("new", []Key{{ID: "new", Signer: newSigner, ExpiresAt: time.Now().Add(time.Hour)}})
+	require.Error(t, err)
+	_, err = NewPasetoPublicKeyRingMaker("missing", keys)
+	require.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const minRSAKeySize = 2048
+
+// ParsePrivateKey reads an Ed25519 or RSA private key from a PEM encoded PKCS #8 block
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse private key: %w", err)
+	}
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		return key, nil
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < minRSAKeySize {
+			return nil, fmt.Errorf("invalid key size: RSA keys must have at least %d bits", minRSAKeySize)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// MarshalPrivateKey encodes a private key as a PEM encoded PKCS #8 block
+func MarshalPrivateKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// JWK is a public verification key in JSON Web Key format
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKS is a set of verification keys as served to other services
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKeyProvider is implemented by makers whose tokens can be verified
+// without the signing key
+type PublicKeyProvider interface {
+	PublicKeys() []JWK
+}
+
+func newJWK(keyID, algorithm string, key crypto.PublicKey) JWK {
+	encode := base64.RawURLEncoding.EncodeToString
+	jwk := JWK{KeyID: keyID, Use: "sig", Algorithm: algorithm}
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = encode(key)
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encode(key.N.Bytes())
+		jwk.E = encode(big.NewInt(int64(key.E)).Bytes())
+	}
+	return jwk
+}
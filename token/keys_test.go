@@ -0,0 +1,31 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	data, err := MarshalPrivateKey(edKey)
+	require.NoError(t, err)
+
+	key, err := ParsePrivateKey(data)
+	require.NoError(t, err)
+	require.Equal(t, edKey, key)
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	data, err = MarshalPrivateKey(weakKey)
+	require.NoError(t, err)
+	_, err = ParsePrivateKey(data)
+	require.Error(t, err)
+
+	_, err = ParsePrivateKey([]byte("not a key"))
+	require.Error(t, err)
+}
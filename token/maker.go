@@ -3,6 +3,8 @@ package token
 import "time"
 
 type Maker interface {
-	CreateToken(username string, duration time.Duration) (string, error)
-	VerifyToken(token string) (*Payload, error)
+	// CreateToken returns the token together with its payload, whose ID identifies the token
+	CreateToken(username string, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error)
+	// VerifyToken returns the payload of a valid token issued with the given type
+	VerifyToken(token string, tokenType TokenType) (*Payload, error)
 }
@@ -8,34 +8,60 @@ import (
 )
 
 type PasetoMaker struct {
-	paseto       *paseto.V2
-	symmetricKey []byte
+	paseto *paseto.V2
+	keys   *keyRing
 }
 
 func NewPasetoMaker(symmetricKey string) (Maker, error) {
-	if len(symmetricKey) != 32 {
-		return nil, fmt.Errorf("invalid key size: must be exactly %d characters", 32)
+	return NewPasetoKeyRingMaker("", []Key{{Secret: []byte(symmetricKey)}})
+}
+
+// NewPasetoKeyRingMaker creates a maker that encrypts with the active key and
+// decrypts with the key named in the token footer. Tokens without a key ID in
+// the footer belong to the key with an empty ID.
+func NewPasetoKeyRingMaker(activeID string, keys []Key) (Maker, error) {
+	for _, key := range keys {
+		if len(key.Secret) != 32 {
+			return nil, fmt.Errorf("invalid key size: must be exactly %d characters", 32)
+		}
+	}
+	ring, err := newKeyRing(activeID, keys)
+	if err != nil {
+		return nil, err
 	}
 	maker := &PasetoMaker{
-		paseto:       paseto.NewV2(),
-		symmetricKey: []byte(symmetricKey),
+		paseto: paseto.NewV2(),
+		keys:   ring,
 	}
 	return maker, nil
 }
 
-func (maker *PasetoMaker) CreateToken(username string, duration time.Duration) (string, error) {
-	payload, err := NewPayload(username, duration)
+func (maker *PasetoMaker) CreateToken(username string, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, tokenType, duration)
 	if err != nil {
-		return "", err
+		return "", payload, err
 	}
 
-	return maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	var footer interface{}
+	if maker.keys.active.ID != "" {
+		footer = pasetoFooter{KeyID: maker.keys.active.ID}
+	}
+	token, err := maker.paseto.Encrypt(maker.keys.active.Secret, payload, footer)
+	return token, payload, err
 }
 
-func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
-	payload := &Payload{}
+func (maker *PasetoMaker) VerifyToken(token string, tokenType TokenType) (*Payload, error) {
+	var footer pasetoFooter
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		return nil, ErrInvalidToken
+	}
+	key, err := maker.keys.lookup(footer.KeyID)
+	if err != nil {
+		return nil, err
+	}
 
-	err := maker.paseto.Decrypt(token, maker.symmetricKey, payload, nil)
+	payload := &Payload{}
+	err = maker.paseto.Decrypt(token, key.Secret, payload, nil)
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
@@ -44,6 +70,9 @@ func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := payload.checkType(tokenType); err != nil {
+		return nil, err
+	}
 
 	return payload, nil
 }
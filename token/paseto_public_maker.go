@@ -0,0 +1,122 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+)
+
+// pasetoPublicAlgorithm is announced in the JWK of a v4.public key; JOSE has no
+// registered value for PASETO
+const pasetoPublicAlgorithm = "v4.public"
+
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+type pasetoPublicKey struct {
+	secretKey paseto.V4AsymmetricSecretKey
+	publicKey paseto.V4AsymmetricPublicKey
+}
+
+// PasetoPublicMaker signs v4.public PASETO tokens with an Ed25519 key. The key ID
+// is carried in the footer so verifiers can pick the public key.
+type PasetoPublicMaker struct {
+	keys    *keyRing
+	pasetos map[string]pasetoPublicKey
+}
+
+func NewPasetoPublicMaker(keyID string, privateKey ed25519.PrivateKey) (Maker, error) {
+	return NewPasetoPublicKeyRingMaker(keyID, []Key{{ID: keyID, Signer: privateKey}})
+}
+
+// NewPasetoPublicKeyRingMaker creates a maker that signs with the active key and
+// verifies with the key named in the footer
+func NewPasetoPublicKeyRingMaker(activeID string, keys []Key) (Maker, error) {
+	maker := &PasetoPublicMaker{pasetos: make(map[string]pasetoPublicKey, len(keys))}
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("key ID is not set")
+		}
+		privateKey, ok := key.Signer.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("PASETO v4.public needs Ed25519 keys")
+		}
+		secretKey, err := paseto.NewV4AsymmetricSecretKeyFromEd25519(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		maker.pasetos[key.ID] = pasetoPublicKey{secretKey: secretKey, publicKey: secretKey.Public()}
+	}
+	ring, err := newKeyRing(activeID, keys)
+	if err != nil {
+		return nil, err
+	}
+	maker.keys = ring
+	return maker, nil
+}
+
+func (maker *PasetoPublicMaker) CreateToken(username string, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, tokenType, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	claims, err := json.Marshal(payload)
+	if err != nil {
+		return "", payload, err
+	}
+	footer, err := json.Marshal(pasetoFooter{KeyID: maker.keys.active.ID})
+	if err != nil {
+		return "", payload, err
+	}
+	pasetoToken, err := paseto.NewTokenFromClaimsJSON(claims, footer)
+	if err != nil {
+		return "", payload, err
+	}
+	return pasetoToken.V4Sign(maker.pasetos[maker.keys.active.ID].secretKey, nil), payload, nil
+}
+
+func (maker *PasetoPublicMaker) VerifyToken(token string, tokenType TokenType) (*Payload, error) {
+	parser := paseto.MakeParser(nil)
+
+	var footer pasetoFooter
+	data, err := parser.UnsafeParseFooter(paseto.V4Public, token)
+	if err != nil || json.Unmarshal(data, &footer) != nil {
+		return nil, ErrInvalidToken
+	}
+	key, err := maker.keys.lookup(footer.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	pasetoToken, err := parser.ParseV4Public(maker.pasetos[key.ID].publicKey, token, nil)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	payload := &Payload{}
+	if err := json.Unmarshal(pasetoToken.ClaimsJSON(), payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+	if err := payload.checkType(tokenType); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+func (maker *PasetoPublicMaker) PublicKeys() []JWK {
+	var keys []JWK
+	for _, key := range maker.keys.verifyKeys() {
+		keys = append(keys, newJWK(key.ID, pasetoPublicAlgorithm, key.Signer.Public()))
+	}
+	return keys
+}
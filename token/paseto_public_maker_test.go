@@ -0,0 +1,75 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/symyzi/financial-helper/util"
+)
+
+func newPasetoPublicMaker(t *testing.T, keyID string) Maker {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	maker, err := NewPasetoPublicMaker(keyID, privateKey)
+	require.NoError(t, err)
+	return maker
+}
+
+func TestPasetoPublicMaker(t *testing.T) {
+	maker := newPasetoPublicMaker(t, "key-1")
+
+	username := util.RandomUsername()
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(username, util.UserRole, TokenTypeAccess, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+	require.Regexp(t, `^v4\.public\.`, token)
+
+	payload, err = maker.VerifyToken(token, TokenTypeAccess)
+	require.NoError(t, err)
+
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.Username)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+	keys := maker.(PublicKeyProvider).PublicKeys()
+	require.Len(t, keys, 1)
+	require.Equal(t, "key-1", keys[0].KeyID)
+	require.Equal(t, "OKP", keys[0].KeyType)
+	require.NotEmpty(t, keys[0].X)
+}
+
+func TestExpiredPasetoPublicToken(t *testing.T) {
+	maker := newPasetoPublicMaker(t, "key-1")
+
+	token, _, err := maker.CreateToken(util.RandomUsername(), util.UserRole, TokenTypeAccess, -time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token, TokenTypeAccess)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPasetoPublicTokenOtherKey(t *testing.T) {
+	maker := newPasetoPublicMaker(t, "key-1")
+
+	// same key ID, different key
+	token, _, err := newPasetoPublicMaker(t, "key-1").CreateToken(util.RandomUsername(), util.UserRole, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+	_, err = maker.VerifyToken(token, TokenTypeAccess)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+
+	token, _, err = newPasetoPublicMaker(t, "key-2").CreateToken(util.RandomUsername(), util.UserRole, TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+	_, err = maker.VerifyToken(token, TokenTypeAccess)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
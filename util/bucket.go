@@ -0,0 +1,39 @@
+package util
+
+import "time"
+
+// Supported time-series bucket sizes, named after the matching date_trunc fields
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+	BucketYear  = "year"
+)
+
+// BucketStart truncates t to the start of its bucket in UTC. Weeks start on Monday.
+func BucketStart(bucket string, t time.Time) time.Time {
+	switch bucket {
+	case BucketDay:
+		return Day(t.UTC())
+	case BucketWeek:
+		return periodStart(PeriodWeekly, t.UTC())
+	case BucketYear:
+		return periodStart(PeriodYearly, t.UTC())
+	default:
+		return periodStart(PeriodMonthly, t.UTC())
+	}
+}
+
+// AddBuckets moves the bucket start t by n buckets
+func AddBuckets(bucket string, t time.Time, n int) time.Time {
+	switch bucket {
+	case BucketDay:
+		return t.AddDate(0, 0, n)
+	case BucketWeek:
+		return t.AddDate(0, 0, 7*n)
+	case BucketYear:
+		return t.AddDate(n, 0, 0)
+	default:
+		return t.AddDate(0, n, 0)
+	}
+}
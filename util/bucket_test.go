@@ -0,0 +1,33 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketStart(t *testing.T) {
+	at := time.Date(2024, time.February, 29, 15, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		bucket string
+		start  time.Time
+		next   time.Time
+	}{
+		{BucketDay, date(2024, time.February, 29), date(2024, time.March, 1)},
+		{BucketWeek, date(2024, time.February, 26), date(2024, time.March, 4)},
+		{BucketMonth, date(2024, time.February, 1), date(2024, time.March, 1)},
+		{BucketYear, date(2024, time.January, 1), date(2025, time.January, 1)},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.bucket, func(t *testing.T) {
+			start := BucketStart(tc.bucket, at)
+			require.Equal(t, tc.start, start)
+			require.Equal(t, tc.next, AddBuckets(tc.bucket, start, 1))
+			require.Equal(t, start, AddBuckets(tc.bucket, tc.next, -1))
+		})
+	}
+}
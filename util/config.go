@@ -1,17 +1,34 @@
 package util
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	DBDriver            string        `mapstructure:"DB_DRIVER"`
-	DBSource            string        `mapstructure:"DB_SOURCE"`
-	ServerAddress       string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSymmetricKey   string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	DBDriver             string        `mapstructure:"DB_DRIVER"`
+	DBSource             string        `mapstructure:"DB_SOURCE"`
+	ServerAddress        string        `mapstructure:"SERVER_ADDRESS"`
+	TokenType            string        `mapstructure:"TOKEN_TYPE"`
+	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	TokenKeyID           string        `mapstructure:"TOKEN_KEY_ID"`
+	TokenPrivateKeyFile  string        `mapstructure:"TOKEN_PRIVATE_KEY_FILE"`
+	TokenKeyRingFile     string        `mapstructure:"TOKEN_KEY_RING_FILE"`
+	TokenKeyRing         TokenKeyRing  `mapstructure:"-"`
+	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	DefaultPageSize      int32         `mapstructure:"DEFAULT_PAGE_SIZE"`
+	MaxPageSize          int32         `mapstructure:"MAX_PAGE_SIZE"`
+	RecurringInterval    time.Duration `mapstructure:"RECURRING_INTERVAL"`
+	AttachmentDir        string        `mapstructure:"ATTACHMENT_DIR"`
+	MaxAttachmentSize    int64         `mapstructure:"MAX_ATTACHMENT_SIZE"`
+	RevocationCacheTTL   time.Duration `mapstructure:"REVOCATION_CACHE_TTL"`
+	RevokedTokenInterval time.Duration `mapstructure:"REVOKED_TOKEN_INTERVAL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -19,6 +36,19 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigName("app")
 	viper.SetConfigType("env")
 
+	viper.SetDefault("TOKEN_TYPE", "paseto")
+	viper.SetDefault("TOKEN_KEY_ID", "")
+	viper.SetDefault("TOKEN_PRIVATE_KEY_FILE", "")
+	viper.SetDefault("TOKEN_KEY_RING_FILE", "")
+	viper.SetDefault("REFRESH_TOKEN_DURATION", 24*time.Hour)
+	viper.SetDefault("DEFAULT_PAGE_SIZE", 50)
+	viper.SetDefault("MAX_PAGE_SIZE", 1000)
+	viper.SetDefault("RECURRING_INTERVAL", time.Minute)
+	viper.SetDefault("ATTACHMENT_DIR", "attachments")
+	viper.SetDefault("MAX_ATTACHMENT_SIZE", 10<<20)
+	viper.SetDefault("REVOCATION_CACHE_TTL", 30*time.Second)
+	viper.SetDefault("REVOKED_TOKEN_INTERVAL", time.Hour)
+
 	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
@@ -27,5 +57,37 @@ func LoadConfig(path string) (config Config, err error) {
 	}
 
 	err = viper.Unmarshal(&config)
+	if err != nil {
+		return
+	}
+
+	// without a key ring file the single key settings are used until the first rotation
+	if config.TokenKeyRingFile != "" {
+		config.TokenKeyRing, err = LoadTokenKeyRing(config.TokenKeyRingFile)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		} else if err != nil {
+			err = fmt.Errorf("cannot load token key ring: %w", err)
+		}
+	}
 	return
 }
+
+// TokenKeys returns the token key ring, or a ring holding only the key set by
+// TOKEN_SYMMETRIC_KEY or TOKEN_PRIVATE_KEY_FILE when no key ring file is used
+func (config Config) TokenKeys() (TokenKeyRing, error) {
+	if len(config.TokenKeyRing.Keys) > 0 {
+		return config.TokenKeyRing, nil
+	}
+
+	key := TokenKey{ID: config.TokenKeyID, Secret: config.TokenSymmetricKey}
+	if config.TokenPrivateKeyFile != "" {
+		data, err := os.ReadFile(config.TokenPrivateKeyFile)
+		if err != nil {
+			return TokenKeyRing{}, fmt.Errorf("cannot read private key: %w", err)
+		}
+		key = TokenKey{ID: config.TokenKeyID, PrivateKey: string(data)}
+	}
+	ring := TokenKeyRing{Active: key.ID, Keys: []TokenKey{key}}
+	return ring, ring.Validate()
+}
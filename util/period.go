@@ -0,0 +1,84 @@
+package util
+
+import "time"
+
+// Supported budget periods
+const (
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+	PeriodYearly  = "yearly"
+	PeriodCustom  = "custom"
+)
+
+// PeriodWindow returns the half-open [from, to) window of the period that contains at.
+// Weekly, monthly and yearly periods follow the calendar (weeks start on Monday) and
+// begin with the period containing start. A custom period is the [start, end] range,
+// repeated back to back when recurring. A zero end means the periods never stop.
+// ok is false when at falls outside every window.
+func PeriodWindow(period string, start, end time.Time, recurring bool, at time.Time) (from, to time.Time, ok bool) {
+	start = Day(start)
+	at = at.UTC()
+	if !end.IsZero() {
+		end = Day(end)
+	}
+
+	if period == PeriodCustom {
+		if end.IsZero() {
+			return time.Time{}, time.Time{}, false
+		}
+
+		days := int(end.Sub(start).Hours()/24) + 1
+		from, to = start, end.AddDate(0, 0, 1)
+		if recurring && !at.Before(to) {
+			n := int(at.Sub(start).Hours()/24) / days
+			from = start.AddDate(0, 0, n*days)
+			to = from.AddDate(0, 0, days)
+		}
+		return from, to, !at.Before(from) && at.Before(to)
+	}
+
+	first := periodStart(period, start)
+	if at.Before(first) {
+		return time.Time{}, time.Time{}, false
+	}
+	if !end.IsZero() && !at.Before(end.AddDate(0, 0, 1)) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	from = periodStart(period, at)
+	if !recurring && !from.Equal(first) {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, NextPeriodStart(period, from), true
+}
+
+// NextPeriodStart returns the start of the calendar period following the one starting at from
+func NextPeriodStart(period string, from time.Time) time.Time {
+	switch period {
+	case PeriodWeekly:
+		return from.AddDate(0, 0, 7)
+	case PeriodYearly:
+		return from.AddDate(1, 0, 0)
+	default:
+		return from.AddDate(0, 1, 0)
+	}
+}
+
+func periodStart(period string, t time.Time) time.Time {
+	day := Day(t)
+	switch period {
+	case PeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case PeriodYearly:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// Day truncates t to midnight UTC of its calendar date
+func Day(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
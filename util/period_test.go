@@ -0,0 +1,136 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPeriodWindow(t *testing.T) {
+	testCases := []struct {
+		name      string
+		period    string
+		start     time.Time
+		end       time.Time
+		recurring bool
+		at        time.Time
+		from      time.Time
+		to        time.Time
+		ok        bool
+	}{
+		{
+			name:      "Monthly",
+			period:    PeriodMonthly,
+			start:     date(2024, time.January, 15),
+			recurring: true,
+			at:        time.Date(2024, time.March, 31, 23, 59, 0, 0, time.UTC),
+			from:      date(2024, time.March, 1),
+			to:        date(2024, time.April, 1),
+			ok:        true,
+		},
+		{
+			name:      "MonthlyBeforeStart",
+			period:    PeriodMonthly,
+			start:     date(2024, time.February, 15),
+			recurring: true,
+			at:        date(2024, time.January, 31),
+		},
+		{
+			name:      "MonthlyFirstPeriodIncludesWholeMonth",
+			period:    PeriodMonthly,
+			start:     date(2024, time.February, 15),
+			recurring: false,
+			at:        date(2024, time.February, 2),
+			from:      date(2024, time.February, 1),
+			to:        date(2024, time.March, 1),
+			ok:        true,
+		},
+		{
+			name:      "MonthlyNotRecurring",
+			period:    PeriodMonthly,
+			start:     date(2024, time.February, 15),
+			recurring: false,
+			at:        date(2024, time.March, 2),
+		},
+		{
+			name:      "MonthlyAfterEnd",
+			period:    PeriodMonthly,
+			start:     date(2024, time.January, 1),
+			end:       date(2024, time.June, 30),
+			recurring: true,
+			at:        date(2024, time.July, 1),
+		},
+		{
+			name:      "Weekly",
+			period:    PeriodWeekly,
+			start:     date(2024, time.January, 1),
+			recurring: true,
+			at:        date(2024, time.March, 10), // Sunday
+			from:      date(2024, time.March, 4),
+			to:        date(2024, time.March, 11),
+			ok:        true,
+		},
+		{
+			name:      "Yearly",
+			period:    PeriodYearly,
+			start:     date(2023, time.May, 1),
+			recurring: true,
+			at:        date(2024, time.December, 31),
+			from:      date(2024, time.January, 1),
+			to:        date(2025, time.January, 1),
+			ok:        true,
+		},
+		{
+			name:   "Custom",
+			period: PeriodCustom,
+			start:  date(2024, time.March, 10),
+			end:    date(2024, time.March, 24),
+			at:     date(2024, time.March, 24),
+			from:   date(2024, time.March, 10),
+			to:     date(2024, time.March, 25),
+			ok:     true,
+		},
+		{
+			name:   "CustomNotRecurring",
+			period: PeriodCustom,
+			start:  date(2024, time.March, 10),
+			end:    date(2024, time.March, 24),
+			at:     date(2024, time.March, 25),
+		},
+		{
+			name:      "CustomRecurring",
+			period:    PeriodCustom,
+			start:     date(2024, time.March, 10),
+			end:       date(2024, time.March, 23),
+			recurring: true,
+			at:        date(2024, time.April, 8),
+			from:      date(2024, time.April, 7),
+			to:        date(2024, time.April, 21),
+			ok:        true,
+		},
+		{
+			name:      "CustomWithoutEnd",
+			period:    PeriodCustom,
+			start:     date(2024, time.March, 10),
+			recurring: true,
+			at:        date(2024, time.March, 12),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, ok := PeriodWindow(tc.period, tc.start, tc.end, tc.recurring, tc.at)
+			require.Equal(t, tc.ok, ok)
+			if tc.ok {
+				require.Equal(t, tc.from, from)
+				require.Equal(t, tc.to, to)
+			}
+		})
+	}
+}
@@ -42,7 +42,7 @@ func RandomPassword() string {
 }
 
 func RandomCurrency() string {
-	currencies := []string{"USD", "EUR", "CAD", "RUB"}
+	currencies := []string{"USD", "EUR", "RUB"}
 	n := len(currencies)
 	return currencies[rng.Intn(n)]
 }
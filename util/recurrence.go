@@ -0,0 +1,38 @@
+package util
+
+import "time"
+
+// Supported recurrence frequencies
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+	FrequencyYearly  = "yearly"
+)
+
+// Occurrence returns the date of the n-th (0-based) occurrence of a rule
+// starting at start and repeating every interval units of frequency.
+// Monthly and yearly rules keep the day of month of start, clamped to the
+// length of shorter months, or use the last day when lastDayOfMonth is set.
+func Occurrence(frequency string, interval int, lastDayOfMonth bool, start time.Time, n int) time.Time {
+	start = Day(start)
+	step := interval * n
+
+	switch frequency {
+	case FrequencyDaily:
+		return start.AddDate(0, 0, step)
+	case FrequencyWeekly:
+		return start.AddDate(0, 0, 7*step)
+	case FrequencyYearly:
+		step *= 12
+	}
+
+	first := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+
+	day := start.Day()
+	if lastDayOfMonth || day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
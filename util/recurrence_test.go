@@ -0,0 +1,94 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccurrence(t *testing.T) {
+	testCases := []struct {
+		name           string
+		frequency      string
+		interval       int
+		lastDayOfMonth bool
+		start          time.Time
+		n              int
+		want           time.Time
+	}{
+		{
+			name:      "First",
+			frequency: FrequencyMonthly,
+			interval:  1,
+			start:     time.Date(2024, time.January, 15, 13, 30, 0, 0, time.UTC),
+			n:         0,
+			want:      date(2024, time.January, 15),
+		},
+		{
+			name:      "Daily",
+			frequency: FrequencyDaily,
+			interval:  3,
+			start:     date(2024, time.February, 27),
+			n:         2,
+			want:      date(2024, time.March, 4),
+		},
+		{
+			name:      "Weekly",
+			frequency: FrequencyWeekly,
+			interval:  2,
+			start:     date(2024, time.January, 1),
+			n:         3,
+			want:      date(2024, time.February, 12),
+		},
+		{
+			name:      "MonthlyClampsShortMonths",
+			frequency: FrequencyMonthly,
+			interval:  1,
+			start:     date(2024, time.January, 31),
+			n:         1,
+			want:      date(2024, time.February, 29),
+		},
+		{
+			name:      "MonthlyKeepsDayAfterShortMonth",
+			frequency: FrequencyMonthly,
+			interval:  1,
+			start:     date(2024, time.January, 31),
+			n:         2,
+			want:      date(2024, time.March, 31),
+		},
+		{
+			name:           "MonthlyLastDayOfMonth",
+			frequency:      FrequencyMonthly,
+			interval:       1,
+			lastDayOfMonth: true,
+			start:          date(2024, time.February, 29),
+			n:              2,
+			want:           date(2024, time.April, 30),
+		},
+		{
+			name:      "QuarterlyAcrossYear",
+			frequency: FrequencyMonthly,
+			interval:  3,
+			start:     date(2024, time.November, 30),
+			n:         1,
+			want:      date(2025, time.February, 28),
+		},
+		{
+			name:      "YearlyLeapDay",
+			frequency: FrequencyYearly,
+			interval:  1,
+			start:     date(2024, time.February, 29),
+			n:         1,
+			want:      date(2025, time.February, 28),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			got := Occurrence(tc.frequency, tc.interval, tc.lastDayOfMonth, tc.start, tc.n)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package util
+
+// Roles a user can have
+const (
+	UserRole  = "user"
+	AdminRole = "admin"
+)
+
+// IsSupportedRole reports whether role is one of the known roles
+func IsSupportedRole(role string) bool {
+	switch role {
+	case UserRole, AdminRole:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TokenKey is one key of the token key ring. Secret is used by the paseto and
+// jwt token types, PrivateKey (PEM encoded PKCS #8) by the public-key types.
+type TokenKey struct {
+	ID         string     `json:"id"`
+	Secret     string     `json:"secret,omitempty"`
+	PrivateKey string     `json:"private_key,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// TokenKeyRing holds the key that signs new tokens and the retired keys that
+// still verify tokens until they expire
+type TokenKeyRing struct {
+	Active string     `json:"active"`
+	Keys   []TokenKey `json:"keys"`
+}
+
+// LoadTokenKeyRing reads a key ring written by Save
+func LoadTokenKeyRing(path string) (TokenKeyRing, error) {
+	var ring TokenKeyRing
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ring, err
+	}
+	if err := json.Unmarshal(data, &ring); err != nil {
+		return ring, fmt.Errorf("cannot parse key ring: %w", err)
+	}
+	return ring, ring.Validate()
+}
+
+// Save replaces the key ring file atomically, so a server starting meanwhile
+// reads either the old or the new ring
+func (ring TokenKeyRing) Save(path string) error {
+	if err := ring.Validate(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ring, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".keyring-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := file.Chmod(0o600); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (ring TokenKeyRing) Validate() error {
+	ids := make(map[string]bool, len(ring.Keys))
+	for _, key := range ring.Keys {
+		if ids[key.ID] {
+			return fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		ids[key.ID] = true
+		if key.Secret == "" && key.PrivateKey == "" {
+			return fmt.Errorf("key %q has neither a secret nor a private key", key.ID)
+		}
+		if key.ID == ring.Active && key.ExpiresAt != nil {
+			return errors.New("the active key cannot expire")
+		}
+	}
+	if !ids[ring.Active] {
+		return fmt.Errorf("active key %q is not in the key ring", ring.Active)
+	}
+	return nil
+}
+
+// Rotate makes key the active key. The previous active key keeps verifying
+// tokens until retireAt and keys that expired before now are dropped.
+func (ring *TokenKeyRing) Rotate(key TokenKey, retireAt, now time.Time) error {
+	keys := make([]TokenKey, 0, len(ring.Keys)+1)
+	for _, old := range ring.Keys {
+		if old.ID == key.ID {
+			return fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		if old.ID == ring.Active {
+			old.ExpiresAt = &retireAt
+		}
+		if old.ExpiresAt != nil && old.ExpiresAt.Before(now) {
+			continue
+		}
+		keys = append(keys, old)
+	}
+
+	key.ExpiresAt = nil
+	ring.Active = key.ID
+	ring.Keys = append(keys, key)
+	return nil
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenKeyRingRotate(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Hour)
+	ring := TokenKeyRing{
+		Active: "b",
+		Keys: []TokenKey{
+			{ID: "a", Secret: RandomString(32), ExpiresAt: &expired},
+			{ID: "b", Secret: RandomString(32)},
+		},
+	}
+	require.NoError(t, ring.Validate())
+
+	err := ring.Rotate(TokenKey{ID: "c", Secret: RandomString(32)}, now.Add(24*time.Hour), now)
+	require.NoError(t, err)
+	require.Equal(t, "c", ring.Active)
+
+	// the expired key is dropped and the previous one retires later
+	require.Len(t, ring.Keys, 2)
+	require.Equal(t, "b", ring.Keys[0].ID)
+	require.Equal(t, now.Add(24*time.Hour), *ring.Keys[0].ExpiresAt)
+	require.Equal(t, "c", ring.Keys[1].ID)
+	require.Nil(t, ring.Keys[1].ExpiresAt)
+
+	err = ring.Rotate(TokenKey{ID: "b", Secret: RandomString(32)}, now, now)
+	require.Error(t, err)
+}
+
+func TestTokenKeyRingSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	ring := TokenKeyRing{Active: "a", Keys: []TokenKey{{ID: "a", Secret: RandomString(32)}}}
+	require.NoError(t, ring.Save(path))
+
+	loaded, err := LoadTokenKeyRing(path)
+	require.NoError(t, err)
+	require.Equal(t, ring, loaded)
+
+	invalid := []TokenKeyRing{
+		{Active: "missing", Keys: []TokenKey{{ID: "a", Secret: "x"}}},
+		{Active: "a", Keys: []TokenKey{{ID: "a", Secret: "x"}, {ID: "a", Secret: "y"}}},
+		{Active: "a", Keys: []TokenKey{{ID: "a"}}},
+		{Active: "a", Keys: []TokenKey{{ID: "a", Secret: "x", ExpiresAt: &time.Time{}}}},
+	}
+	for _, ring := range invalid {
+		require.Error(t, ring.Save(path))
+	}
+}
+
+func TestConfigTokenKeys(t *testing.T) {
+	config := Config{TokenKeyID: "legacy", TokenSymmetricKey: RandomString(32)}
+	ring, err := config.TokenKeys()
+	require.NoError(t, err)
+	require.Equal(t, "legacy", ring.Active)
+	require.Len(t, ring.Keys, 1)
+	require.Equal(t, config.TokenSymmetricKey, ring.Keys[0].Secret)
+
+	config.TokenKeyRing = TokenKeyRing{Active: "a", Keys: []TokenKey{{ID: "a", Secret: RandomString(32)}}}
+	ring, err = config.TokenKeys()
+	require.NoError(t, err)
+	require.Equal(t, config.TokenKeyRing, ring)
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/symyzi/financial-helper/db/gen"
+	"github.com/symyzi/financial-helper/util"
+)
+
+// DefaultInterval is how often due recurring expenses are checked
+const DefaultInterval = time.Minute
+
+// RecurringExpenseStore is the subset of db.Store the materializer uses
+type RecurringExpenseStore interface {
+	MaterializeRecurringExpenseTx(ctx context.Context, today time.Time) (db.MaterializeRecurringExpenseTxResult, error)
+}
+
+// RecurringExpenseMaterializer periodically turns due recurring expense rules into expenses.
+// Several instances may run against the same database: every rule is handled under a
+// row lock and skipped by the others.
+type RecurringExpenseMaterializer struct {
+	store    RecurringExpenseStore
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewRecurringExpenseMaterializer(store RecurringExpenseStore, interval time.Duration) *RecurringExpenseMaterializer {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &RecurringExpenseMaterializer{
+		store:    store,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run materializes due expenses right away and then every interval until ctx is done
+func (materializer *RecurringExpenseMaterializer) Run(ctx context.Context) {
+	ticker := time.NewTicker(materializer.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := materializer.RunOnce(ctx)
+		if err != nil {
+			log.Println("cannot materialize recurring expenses:", err)
+		} else if n > 0 {
+			log.Printf("materialized %d recurring expenses", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce materializes every occurrence due today or earlier and returns
+// the number of expenses created
+func (materializer *RecurringExpenseMaterializer) RunOnce(ctx context.Context) (int, error) {
+	today := util.Day(materializer.now())
+
+	count := 0
+	for ctx.Err() == nil {
+		result, err := materializer.store.MaterializeRecurringExpenseTx(ctx, today)
+		if errors.Is(err, sql.ErrNoRows) {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		count += len(result.Expenses)
+	}
+	return count, ctx.Err()
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	db "github.com/symyzi/financial-helper/db/gen"
+	mockdb "github.com/symyzi/financial-helper/db/mock"
+)
+
+func TestRunOnce(t *testing.T) {
+	now := time.Date(2024, time.March, 31, 18, 45, 0, 0, time.UTC)
+	today := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		count      int
+		err        error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().
+						MaterializeRecurringExpenseTx(gomock.Any(), gomock.Eq(today)).
+						Return(db.MaterializeRecurringExpenseTxResult{Expenses: make([]db.Expense, 2)}, nil),
+					store.EXPECT().
+						MaterializeRecurringExpenseTx(gomock.Any(), gomock.Eq(today)).
+						Return(db.MaterializeRecurringExpenseTxResult{Expenses: make([]db.Expense, 1)}, nil),
+					store.EXPECT().
+						MaterializeRecurringExpenseTx(gomock.Any(), gomock.Eq(today)).
+						Return(db.MaterializeRecurringExpenseTxResult{}, sql.ErrNoRows),
+				)
+			},
+			count: 3,
+		},
+		{
+			name: "NothingDue",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MaterializeRecurringExpenseTx(gomock.Any(), gomock.Eq(today)).
+					Times(1).
+					Return(db.MaterializeRecurringExpenseTxResult{}, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MaterializeRecurringExpenseTx(gomock.Any(), gomock.Eq(today)).
+					Times(1).
+					Return(db.MaterializeRecurringExpenseTxResult{}, sql.ErrConnDone)
+			},
+			err: sql.ErrConnDone,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			materializer := NewRecurringExpenseMaterializer(store, time.Minute)
+			materializer.now = func() time.Time { return now }
+
+			count, err := materializer.RunOnce(context.Background())
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.count, count)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultCleanupInterval is how often expired revoked tokens are purged
+const DefaultCleanupInterval = time.Hour
+
+// RevokedTokenStore is the subset of db.Store the cleaner uses
+type RevokedTokenStore interface {
+	DeleteExpiredRevokedTokens(ctx context.Context) (int64, error)
+}
+
+// RevokedTokenCleaner periodically deletes revocations of tokens that have expired,
+// since such tokens are rejected anyway
+type RevokedTokenCleaner struct {
+	store    RevokedTokenStore
+	interval time.Duration
+}
+
+func NewRevokedTokenCleaner(store RevokedTokenStore, interval time.Duration) *RevokedTokenCleaner {
+	if interval <= 0 {
+		interval = DefaultCleanupInterval
+	}
+	return &RevokedTokenCleaner{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run purges expired revocations right away and then every interval until ctx is done
+func (cleaner *RevokedTokenCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(cleaner.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := cleaner.store.DeleteExpiredRevokedTokens(ctx)
+		if err != nil {
+			log.Println("cannot delete expired revoked tokens:", err)
+		} else if n > 0 {
+			log.Printf("deleted %d expired revoked tokens", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}